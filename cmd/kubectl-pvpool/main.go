@@ -0,0 +1,24 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/puppetlabs/pvpool/pkg/plugin"
+)
+
+func main() {
+	// Cancel the context on interrupt so that commands like checkout can clean
+	// up after themselves.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := plugin.NewCommand().ExecuteContext(ctx); err != nil {
+		stop()
+		fmt.Fprintf(os.Stderr, "error: %+v\n", err)
+		os.Exit(1)
+	}
+}
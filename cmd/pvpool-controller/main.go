@@ -23,5 +23,14 @@ func main() {
 		func(mgr manager.Manager) error {
 			return reconciler.AddPoolReconcilerToManager(mgr, cfg)
 		},
+		func(mgr manager.Manager) error {
+			return reconciler.AddClusterPoolReconcilerToManager(mgr, cfg)
+		},
+		func(mgr manager.Manager) error {
+			return reconciler.AddPoolTemplateReconcilerToManager(mgr, cfg)
+		},
+		func(mgr manager.Manager) error {
+			return reconciler.AddVolumeReconcilerToManager(mgr, cfg)
+		},
 	))
 }
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"strconv"
+)
+
+type int32Value struct {
+	p *int32
+}
+
+func (v int32Value) String() string {
+	if v.p == nil {
+		return "0"
+	}
+
+	return strconv.FormatInt(int64(*v.p), 10)
+}
+
+func (v int32Value) Set(s string) error {
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return err
+	}
+
+	*v.p = int32(n)
+	return nil
+}
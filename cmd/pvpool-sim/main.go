@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/puppetlabs/pvpool/pkg/sim"
+	"k8s.io/klog/v2"
+)
+
+func main() {
+	var (
+		cfg sim.Config
+		as  sim.AutoscalePolicy
+
+		autoscale bool
+		arrivals  string
+		rate      float64
+		interval  time.Duration
+		burstSize int
+		output    string
+	)
+
+	cfg.Replicas = 3
+	as.MinReplicas = 1
+	as.MaxReplicas = 20
+
+	flag.Var(int32Value{&cfg.Replicas}, "replicas", "number of replicas requested by the pool")
+	flag.BoolVar(&autoscale, "autoscale", false, "size the pool from the recent checkout rate instead of using -replicas")
+	flag.Var(int32Value{&as.MinReplicas}, "autoscale-min", "minimum replicas when autoscaling")
+	flag.Var(int32Value{&as.MaxReplicas}, "autoscale-max", "maximum replicas when autoscaling")
+	flag.DurationVar(&as.Window, "autoscale-window", 10*time.Minute, "period over which to measure the checkout rate when autoscaling")
+	flag.Float64Var(&as.Headroom, "autoscale-headroom", 1.5, "multiplier applied to the expected checkouts when autoscaling")
+	flag.DurationVar(&cfg.BindLatency, "bind-latency", 10*time.Second, "time for the provisioner to bind a new PVC")
+	flag.DurationVar(&cfg.InitDuration, "init-duration", 30*time.Second, "time for an init job to run once its PVC is bound")
+	flag.Float64Var(&cfg.Jitter, "jitter", 0, "fraction by which to randomly vary bind latency and init job duration")
+	flag.StringVar(&arrivals, "arrivals", "poisson", "checkout arrival pattern: constant, poisson, or burst")
+	flag.Float64Var(&rate, "rate", 1, "average checkouts per minute for poisson arrivals")
+	flag.DurationVar(&interval, "interval", time.Minute, "time between checkouts for constant arrivals or between bursts for burst arrivals")
+	flag.IntVar(&burstSize, "burst-size", 5, "number of checkouts in each burst for burst arrivals")
+	flag.DurationVar(&cfg.Duration, "duration", time.Hour, "amount of time to simulate")
+	flag.DurationVar(&cfg.Step, "step", time.Second, "resolution of the simulated clock")
+	flag.Int64Var(&cfg.Seed, "seed", 1, "random seed")
+	flag.StringVar(&output, "output", "text", "report format: text or json")
+
+	flag.Parse()
+
+	// The controller logs liberally at the default level, which would drown
+	// out the report.
+	klog.LogToStderr(false)
+	klog.SetOutput(ioutil.Discard)
+
+	if autoscale {
+		cfg.Autoscale = &as
+	}
+
+	switch arrivals {
+	case "constant":
+		cfg.Arrivals = sim.ConstantArrivals{Interval: interval}
+	case "poisson":
+		cfg.Arrivals = sim.PoissonArrivals{RatePerMinute: rate}
+	case "burst":
+		cfg.Arrivals = sim.BurstArrivals{Size: burstSize, Interval: interval}
+	default:
+		fmt.Fprintf(os.Stderr, "unknown arrival pattern %q\n", arrivals)
+		os.Exit(2)
+	}
+
+	report, err := sim.Run(context.Background(), cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "simulation failed: %+v\n", err)
+		os.Exit(1)
+	}
+
+	switch output {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(report)
+	default:
+		err = report.WriteText(os.Stdout)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write report: %+v\n", err)
+		os.Exit(1)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/puppetlabs/leg/k8sutil/pkg/app/selfsignedsecret"
 	"github.com/puppetlabs/leg/k8sutil/pkg/app/webhookcert"
 	corev1obj "github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/api/corev1"
+	"github.com/puppetlabs/pvpool/pkg/conversioncert"
 	"github.com/puppetlabs/pvpool/pkg/opt"
 	"github.com/puppetlabs/pvpool/pkg/runtime"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -48,8 +49,17 @@ func main() {
 				mgr,
 				secretKey,
 				webhookcert.WithValidatingWebhookConfiguration(cfg.ValidatingWebhookConfigurationName),
+				webhookcert.WithMutatingWebhookConfiguration(cfg.MutatingWebhookConfigurationName),
 			)
 		},
+		func(mgr manager.Manager) error {
+			var opts []conversioncert.Option
+			for _, name := range cfg.ConversionCustomResourceDefinitionNames {
+				opts = append(opts, conversioncert.WithCustomResourceDefinition(name))
+			}
+
+			return conversioncert.AddReconcilerToManager(mgr, secretKey, opts...)
+		},
 		func(mgr manager.Manager) error {
 			return selfsignedsecret.AddReconcilerToManager(
 				mgr,
@@ -17,7 +17,13 @@ func main() {
 		manager.Options{
 			HealthProbeBindAddress: ":8000",
 		},
+		webhook.AddCheckoutDefaulterToManager,
 		webhook.AddCheckoutValidatorToManager,
+		webhook.AddClusterPoolDefaulterToManager,
+		webhook.AddClusterPoolValidatorToManager,
+		webhook.AddPoolDefaulterToManager,
 		webhook.AddPoolValidatorToManager,
+		webhook.AddPoolTemplateValidatorToManager,
+		webhook.AddConversionToManager,
 	))
 }
@@ -0,0 +1,36 @@
+package apis_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// maxLastAppliedConfigurationSize is the total size limit of an object's
+// annotations. Client-side apply stores the whole CRD in the
+// kubectl.kubernetes.io/last-applied-configuration annotation, so each CRD
+// must fit within it.
+const maxLastAppliedConfigurationSize = 256 * 1024
+
+func TestCRDSize(t *testing.T) {
+	files, err := filepath.Glob("../../manifests/crd/generated/*.yaml")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		file := file
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			b, err := ioutil.ReadFile(file)
+			require.NoError(t, err)
+
+			data, err := yaml.ToJSON(b)
+			require.NoError(t, err)
+
+			assert.Less(t, len(data), maxLastAppliedConfigurationSize, "CRD is too large for kubectl apply")
+		})
+	}
+}
@@ -1,3 +1,4 @@
 //go:generate go run sigs.k8s.io/controller-tools/cmd/controller-gen crd:preserveUnknownFields=false object paths=./... output:artifacts:config=../../manifests/crd/generated
+//go:generate ../../scripts/generate-client
 
 package apis
@@ -11,6 +11,7 @@ var CheckoutKind = SchemeGroupVersion.WithKind("Checkout")
 
 // Checkout requests a PVC from a Pool.
 //
+// +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
@@ -61,6 +62,11 @@ const (
 	// not have any available PVCs.
 	CheckoutAcquiredReasonNotAvailable = "NotAvailable"
 
+	// CheckoutAcquiredReasonHealthCheckPending is used to indicate that the
+	// pool requires a PVC to be verified before it can be checked out and the
+	// verification has not yet completed.
+	CheckoutAcquiredReasonHealthCheckPending = "HealthCheckPending"
+
 	// CheckoutAcquiredReasonInvalid is used to indicate that the PVC template
 	// for this checkout is invalid.
 	CheckoutAcquiredReasonInvalid = "Invalid"
@@ -73,6 +79,66 @@ const (
 	// CheckoutAcquiredReasonCheckedOut is used to indicate that a PVC was
 	// successfully taken and is now available.
 	CheckoutAcquiredReasonCheckedOut = "CheckedOut"
+
+	// CheckoutVolumeSelected indicates whether the controller has chosen a PV
+	// from the pool for a Checkout. When it is false, its reason is one of
+	// the PoolDoesNotExist, NotAvailable, or HealthCheckPending reasons of the
+	// Acquired condition.
+	CheckoutVolumeSelected CheckoutConditionType = "VolumeSelected"
+
+	// CheckoutVolumeSelectedReasonSelected is used to indicate that a PV was
+	// chosen from the pool.
+	CheckoutVolumeSelectedReasonSelected = "Selected"
+
+	// CheckoutVolumeSelectedReasonPending is used to indicate that the
+	// controller has not yet tried to choose a PV.
+	CheckoutVolumeSelectedReasonPending = "Pending"
+
+	// CheckoutTransferring indicates whether the selected PV is being handed
+	// off from the pool to the checked out PVC. When it is false because the
+	// handoff failed, its reason is the Conflict or Invalid reason of the
+	// Acquired condition.
+	CheckoutTransferring CheckoutConditionType = "Transferring"
+
+	// CheckoutTransferringReasonWaitingForVolume is used to indicate that the
+	// handoff has not started because no PV has been selected yet.
+	CheckoutTransferringReasonWaitingForVolume = "WaitingForVolume"
+
+	// CheckoutTransferringReasonInProgress is used to indicate that the PV is
+	// being handed off to the checked out PVC.
+	CheckoutTransferringReasonInProgress = "InProgress"
+
+	// CheckoutTransferringReasonComplete is used to indicate that the handoff
+	// finished.
+	CheckoutTransferringReasonComplete = "Complete"
+
+	// CheckoutBound indicates whether the checked out PVC is bound to its PV.
+	CheckoutBound CheckoutConditionType = "Bound"
+
+	// CheckoutBoundReasonClaimPending is used to indicate that the checked out
+	// PVC does not exist or is not bound yet.
+	CheckoutBoundReasonClaimPending = "ClaimPending"
+
+	// CheckoutBoundReasonClaimBound is used to indicate that the checked out
+	// PVC is bound.
+	CheckoutBoundReasonClaimBound = "ClaimBound"
+
+	// CheckoutReady indicates whether the checked out PVC is ready to use.
+	// When it is false, its reason is the reason of the first stage that has
+	// not completed.
+	CheckoutReady CheckoutConditionType = "Ready"
+
+	// CheckoutReadyReasonCheckedOut is used to indicate that every stage of
+	// the checkout has completed and the PVC can be used.
+	CheckoutReadyReasonCheckedOut = "CheckedOut"
+
+	// CheckoutReadyReasonTransferring is used to indicate that the checkout
+	// is waiting for the PV handoff to finish.
+	CheckoutReadyReasonTransferring = "Transferring"
+
+	// CheckoutReadyReasonPending is used to indicate that the controller has
+	// not yet determined why the checkout is not ready.
+	CheckoutReadyReasonPending = "Pending"
 )
 
 // CheckoutCondition is a status condition for a Checkout.
@@ -81,7 +147,7 @@ type CheckoutCondition struct {
 
 	// Type is the identifier for this condition.
 	//
-	// +kubebuilder:validation:Enum=Acquired
+	// +kubebuilder:validation:Enum=Acquired;VolumeSelected;Transferring;Bound;Ready
 	Type CheckoutConditionType `json:"type"`
 }
 
@@ -104,6 +170,37 @@ type CheckoutStatus struct {
 	// +optional
 	VolumeClaimRef corev1.LocalObjectReference `json:"volumeClaimRef,omitempty"`
 
+	// Source identifies the pool replica that the checked out volume was taken
+	// from.
+	//
+	// This field will be set as soon as a PVC is selected from the pool.
+	//
+	// +optional
+	Source *CheckoutSource `json:"source,omitempty"`
+
+	// RequestedAt is the time the checkout was created.
+	//
+	// +optional
+	RequestedAt *metav1.Time `json:"requestedAt,omitempty"`
+
+	// VolumeSelectedAt is the time the controller selected a volume from the
+	// pool for this checkout.
+	//
+	// +optional
+	VolumeSelectedAt *metav1.Time `json:"volumeSelectedAt,omitempty"`
+
+	// ClaimBoundAt is the time the controller first observed the checked out
+	// PVC bound and ready to use.
+	//
+	// +optional
+	ClaimBoundAt *metav1.Time `json:"claimBoundAt,omitempty"`
+
+	// WaitDuration is the amount of time between the checkout being requested
+	// and its PVC being bound.
+	//
+	// +optional
+	WaitDuration *metav1.Duration `json:"waitDuration,omitempty"`
+
 	// Conditions are the possible observable conditions for the checkout.
 	//
 	// +optional
@@ -112,6 +209,26 @@ type CheckoutStatus struct {
 	Conditions []CheckoutCondition `json:"conditions,omitempty"`
 }
 
+// CheckoutSource identifies the pool replica a checked out volume was taken
+// from.
+type CheckoutSource struct {
+	// PoolRef is the pool the volume was taken from.
+	PoolRef PoolReference `json:"poolRef"`
+
+	// PoolGeneration is the generation of the pool spec that the replica was
+	// created from.
+	//
+	// +optional
+	PoolGeneration int64 `json:"poolGeneration,omitempty"`
+
+	// PersistentVolumeClaimName is the name of the replica's PVC in the pool.
+	// The replica's init job had the same name.
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName"`
+
+	// PersistentVolumeName is the name of the replica's PV in the pool.
+	PersistentVolumeName string `json:"persistentVolumeName"`
+}
+
 // CheckoutList enumerates many Checkout resources.
 //
 // +kubebuilder:object:root=true
@@ -20,4 +20,10 @@ type Condition struct {
 	//
 	// +optional
 	Message string `json:"message,omitempty"`
+
+	// ObservedGeneration is the generation of the object that the condition
+	// was set based on. It is only set by clients of newer API versions.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
@@ -0,0 +1,20 @@
+package v1alpha1
+
+import "sigs.k8s.io/controller-runtime/pkg/conversion"
+
+// This version is the hub for conversion between API versions. Every other
+// version converts to and from these types.
+
+var (
+	_ conversion.Hub = &Checkout{}
+	_ conversion.Hub = &Pool{}
+	_ conversion.Hub = &ClusterPool{}
+	_ conversion.Hub = &PoolPolicy{}
+	_ conversion.Hub = &PoolTemplate{}
+)
+
+func (*Checkout) Hub()     {}
+func (*Pool) Hub()         {}
+func (*ClusterPool) Hub()  {}
+func (*PoolPolicy) Hub()   {}
+func (*PoolTemplate) Hub() {}
@@ -0,0 +1,101 @@
+// Package defaults sets the default values of optional fields in the
+// v1alpha1 API. The mutating webhook applies these defaults when objects are
+// admitted, and the controller applies them again to objects that were stored
+// before the webhook existed.
+package defaults
+
+import (
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	pvpoolv1alpha1validation "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/validation"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	PoolReplicas          = 1
+	PoolDeletionPolicy    = pvpoolv1alpha1.PoolDeletionPolicyDelete
+	MountJobVolumeName    = "workspace"
+	MountJobRestartPolicy = pvpoolv1alpha1validation.MountJobSpecBackoffPolicy
+	CheckoutAccessMode    = corev1.ReadWriteOnce
+
+	// MountJobBackoffLimit is the default backoff limit for a job in
+	// Kubernetes.
+	MountJobBackoffLimit = 6
+)
+
+// InitJobSpec is the job the controller runs against a new replica when its
+// pool does not have an init job. It is not stored in the pool, so it is not
+// subject to the allowed images of any pool policy. Its backoff limit is the
+// largest one permitted.
+var InitJobSpec = batchv1.JobSpec{
+	Template: corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "init",
+					// https://hub.docker.com/layers/busybox/library/busybox/stable-musl/images/sha256-8d0c42425011ea3fb5b4ec5a121dde4ce986c2efea46be9d981a478fe1d206ec?context=explore
+					Image: "busybox@sha256:8d0c42425011ea3fb5b4ec5a121dde4ce986c2efea46be9d981a478fe1d206ec",
+				},
+			},
+		},
+	},
+}
+
+// DefaultPoolSpec sets the default values of a pool spec. Mount job deadlines
+// default to the largest value permitted by the given limits.
+func DefaultPoolSpec(spec *pvpoolv1alpha1.PoolSpec, limits pvpoolv1alpha1validation.MountJobLimits) {
+	if spec.Replicas == nil {
+		spec.Replicas = pointer.Int32Ptr(PoolReplicas)
+	}
+
+	if spec.DeletionPolicy == "" {
+		spec.DeletionPolicy = PoolDeletionPolicy
+	}
+
+	if spec.InitJob != nil {
+		DefaultMountJob(spec.InitJob, limits)
+	}
+
+	if spec.HealthCheck != nil {
+		DefaultMountJob(&spec.HealthCheck.Job, limits)
+	}
+}
+
+// DefaultMountJob sets the default values of a mount job.
+func DefaultMountJob(j *pvpoolv1alpha1.MountJob, limits pvpoolv1alpha1validation.MountJobLimits) {
+	if j.VolumeName == "" {
+		j.VolumeName = MountJobVolumeName
+	}
+
+	DefaultMountJobSpec(&j.Template.Spec, limits)
+}
+
+// DefaultMountJobSpec sets the default values of the job spec for a mount job.
+// The backoff limit defaults to the Kubernetes default unless that is larger
+// than the limits allow.
+func DefaultMountJobSpec(spec *batchv1.JobSpec, limits pvpoolv1alpha1validation.MountJobLimits) {
+	if spec.Template.Spec.RestartPolicy == "" {
+		spec.Template.Spec.RestartPolicy = MountJobRestartPolicy
+	}
+
+	if spec.ActiveDeadlineSeconds == nil {
+		spec.ActiveDeadlineSeconds = pointer.Int64Ptr(limits.MaxActiveDeadlineSeconds)
+	}
+
+	if spec.BackoffLimit == nil {
+		backoffLimit := int32(MountJobBackoffLimit)
+		if limits.MaxBackoffLimit < backoffLimit {
+			backoffLimit = limits.MaxBackoffLimit
+		}
+
+		spec.BackoffLimit = pointer.Int32Ptr(backoffLimit)
+	}
+}
+
+// DefaultCheckoutSpec sets the default values of a checkout spec.
+func DefaultCheckoutSpec(spec *pvpoolv1alpha1.CheckoutSpec) {
+	if len(spec.AccessModes) == 0 {
+		spec.AccessModes = []corev1.PersistentVolumeAccessMode{CheckoutAccessMode}
+	}
+}
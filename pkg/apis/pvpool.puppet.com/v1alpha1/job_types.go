@@ -11,5 +11,11 @@ type JobTemplate struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
+	// Spec is the specification of the job. Its schema is omitted from the CRD
+	// to keep the CRD small enough for kubectl apply.
+	//
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
 	Spec batchv1.JobSpec `json:"spec"`
 }
@@ -16,20 +16,45 @@ type Checkout struct {
 
 	Key    client.ObjectKey
 	Object *pvpoolv1alpha1.Checkout
+
+	// status is the status of the object when it was last loaded or
+	// persisted. PersistStatus only writes the fields that differ from it.
+	status *pvpoolv1alpha1.CheckoutStatus
 }
 
 func makeCheckout(key client.ObjectKey, obj *pvpoolv1alpha1.Checkout) *Checkout {
 	c := &Checkout{Key: key, Object: obj}
+	c.status = obj.Status.DeepCopy()
 	c.NamespaceScopedAPIObject = helper.ForNamespaceScopedAPIObject(&c.Key, lifecycle.TypedObject{GVK: CheckoutKind, Object: c.Object})
 	return c
 }
 
 func (c *Checkout) Copy() *Checkout {
-	return makeCheckout(c.Key, c.Object.DeepCopy())
+	out := makeCheckout(c.Key, c.Object.DeepCopy())
+	out.status = c.status.DeepCopy()
+	return out
+}
+
+func (c *Checkout) Load(ctx context.Context, cl client.Client) (bool, error) {
+	ok, err := c.NamespaceScopedAPIObject.Load(ctx, cl)
+	if ok {
+		c.status = c.Object.Status.DeepCopy()
+	}
+	return ok, err
 }
 
+// PersistStatus writes the changes to the status of the checkout since it was
+// loaded using merge patches.
 func (c *Checkout) PersistStatus(ctx context.Context, cl client.Client) error {
-	return cl.Status().Update(ctx, c.Object)
+	if ok, err := patchStatus(ctx, cl, c.Object,
+		&pvpoolv1alpha1.Checkout{Status: *c.status},
+		&pvpoolv1alpha1.Checkout{Status: c.Object.Status},
+	); err != nil || !ok {
+		return err
+	}
+
+	c.status = c.Object.Status.DeepCopy()
+	return nil
 }
 
 func (c *Checkout) Condition(typ pvpoolv1alpha1.CheckoutConditionType) (pvpoolv1alpha1.CheckoutCondition, bool) {
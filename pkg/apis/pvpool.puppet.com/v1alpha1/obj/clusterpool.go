@@ -0,0 +1,66 @@
+package obj
+
+import (
+	"context"
+
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/helper"
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var ClusterPoolKind = pvpoolv1alpha1.ClusterPoolKind
+
+type ClusterPool struct {
+	*helper.ClusterScopedAPIObject
+
+	Name   string
+	Object *pvpoolv1alpha1.ClusterPool
+
+	// status is the status of the object when it was last loaded or
+	// persisted. PersistStatus only writes the fields that differ from it.
+	status *pvpoolv1alpha1.ClusterPoolStatus
+}
+
+func makeClusterPool(name string, obj *pvpoolv1alpha1.ClusterPool) *ClusterPool {
+	cp := &ClusterPool{Name: name, Object: obj}
+	cp.status = obj.Status.DeepCopy()
+	cp.ClusterScopedAPIObject = helper.ForClusterScopedAPIObject(&cp.Name, lifecycle.TypedObject{GVK: ClusterPoolKind, Object: cp.Object})
+	return cp
+}
+
+func (cp *ClusterPool) Copy() *ClusterPool {
+	c := makeClusterPool(cp.Name, cp.Object.DeepCopy())
+	c.status = cp.status.DeepCopy()
+	return c
+}
+
+func (cp *ClusterPool) Load(ctx context.Context, cl client.Client) (bool, error) {
+	ok, err := cp.ClusterScopedAPIObject.Load(ctx, cl)
+	if ok {
+		cp.status = cp.Object.Status.DeepCopy()
+	}
+	return ok, err
+}
+
+// PersistStatus writes the changes to the status of the cluster pool since it was
+// loaded using merge patches.
+func (cp *ClusterPool) PersistStatus(ctx context.Context, cl client.Client) error {
+	if ok, err := patchStatus(ctx, cl, cp.Object,
+		&pvpoolv1alpha1.ClusterPool{Status: *cp.status},
+		&pvpoolv1alpha1.ClusterPool{Status: cp.Object.Status},
+	); err != nil || !ok {
+		return err
+	}
+
+	cp.status = cp.Object.Status.DeepCopy()
+	return nil
+}
+
+func NewClusterPool(name string) *ClusterPool {
+	return makeClusterPool(name, &pvpoolv1alpha1.ClusterPool{})
+}
+
+func NewClusterPoolFromObject(obj *pvpoolv1alpha1.ClusterPool) *ClusterPool {
+	return makeClusterPool(obj.GetName(), obj)
+}
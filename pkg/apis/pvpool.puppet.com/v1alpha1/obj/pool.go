@@ -16,20 +16,45 @@ type Pool struct {
 
 	Key    client.ObjectKey
 	Object *pvpoolv1alpha1.Pool
+
+	// status is the status of the object when it was last loaded or
+	// persisted. PersistStatus only writes the fields that differ from it.
+	status *pvpoolv1alpha1.PoolStatus
 }
 
 func makePool(key client.ObjectKey, obj *pvpoolv1alpha1.Pool) *Pool {
 	p := &Pool{Key: key, Object: obj}
+	p.status = obj.Status.DeepCopy()
 	p.NamespaceScopedAPIObject = helper.ForNamespaceScopedAPIObject(&p.Key, lifecycle.TypedObject{GVK: PoolKind, Object: p.Object})
 	return p
 }
 
 func (p *Pool) Copy() *Pool {
-	return makePool(p.Key, p.Object.DeepCopy())
+	c := makePool(p.Key, p.Object.DeepCopy())
+	c.status = p.status.DeepCopy()
+	return c
+}
+
+func (p *Pool) Load(ctx context.Context, cl client.Client) (bool, error) {
+	ok, err := p.NamespaceScopedAPIObject.Load(ctx, cl)
+	if ok {
+		p.status = p.Object.Status.DeepCopy()
+	}
+	return ok, err
 }
 
+// PersistStatus writes the changes to the status of the pool since it was
+// loaded using merge patches.
 func (p *Pool) PersistStatus(ctx context.Context, cl client.Client) error {
-	return cl.Status().Update(ctx, p.Object)
+	if ok, err := patchStatus(ctx, cl, p.Object,
+		&pvpoolv1alpha1.Pool{Status: *p.status},
+		&pvpoolv1alpha1.Pool{Status: p.Object.Status},
+	); err != nil || !ok {
+		return err
+	}
+
+	p.status = p.Object.Status.DeepCopy()
+	return nil
 }
 
 func (p *Pool) Condition(typ pvpoolv1alpha1.PoolConditionType) (pvpoolv1alpha1.PoolCondition, bool) {
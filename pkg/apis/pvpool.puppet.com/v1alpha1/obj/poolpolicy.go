@@ -0,0 +1,34 @@
+package obj
+
+import (
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/helper"
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+)
+
+var PoolPolicyKind = pvpoolv1alpha1.PoolPolicyKind
+
+type PoolPolicy struct {
+	*helper.ClusterScopedAPIObject
+
+	Name   string
+	Object *pvpoolv1alpha1.PoolPolicy
+}
+
+func makePoolPolicy(name string, obj *pvpoolv1alpha1.PoolPolicy) *PoolPolicy {
+	pp := &PoolPolicy{Name: name, Object: obj}
+	pp.ClusterScopedAPIObject = helper.ForClusterScopedAPIObject(&pp.Name, lifecycle.TypedObject{GVK: PoolPolicyKind, Object: pp.Object})
+	return pp
+}
+
+func (pp *PoolPolicy) Copy() *PoolPolicy {
+	return makePoolPolicy(pp.Name, pp.Object.DeepCopy())
+}
+
+func NewPoolPolicy(name string) *PoolPolicy {
+	return makePoolPolicy(name, &pvpoolv1alpha1.PoolPolicy{})
+}
+
+func NewPoolPolicyFromObject(obj *pvpoolv1alpha1.PoolPolicy) *PoolPolicy {
+	return makePoolPolicy(obj.GetName(), obj)
+}
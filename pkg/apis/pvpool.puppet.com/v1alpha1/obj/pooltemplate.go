@@ -0,0 +1,34 @@
+package obj
+
+import (
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/helper"
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+)
+
+var PoolTemplateKind = pvpoolv1alpha1.PoolTemplateKind
+
+type PoolTemplate struct {
+	*helper.ClusterScopedAPIObject
+
+	Name   string
+	Object *pvpoolv1alpha1.PoolTemplate
+}
+
+func makePoolTemplate(name string, obj *pvpoolv1alpha1.PoolTemplate) *PoolTemplate {
+	pt := &PoolTemplate{Name: name, Object: obj}
+	pt.ClusterScopedAPIObject = helper.ForClusterScopedAPIObject(&pt.Name, lifecycle.TypedObject{GVK: PoolTemplateKind, Object: pt.Object})
+	return pt
+}
+
+func (pt *PoolTemplate) Copy() *PoolTemplate {
+	return makePoolTemplate(pt.Name, pt.Object.DeepCopy())
+}
+
+func NewPoolTemplate(name string) *PoolTemplate {
+	return makePoolTemplate(name, &pvpoolv1alpha1.PoolTemplate{})
+}
+
+func NewPoolTemplateFromObject(obj *pvpoolv1alpha1.PoolTemplate) *PoolTemplate {
+	return makePoolTemplate(obj.GetName(), obj)
+}
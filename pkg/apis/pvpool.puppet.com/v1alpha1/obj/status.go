@@ -0,0 +1,172 @@
+package obj
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// patchStatus writes the difference between two copies of an object's status
+// to the status subresource of obj. The copies should only have their status
+// set.
+//
+// A JSON merge patch replaces lists wholesale, so the conditions are written
+// separately from the rest of the status. The other fields are written using a
+// merge patch without a resource version, so it can't conflict with writes to
+// other fields. The conditions that changed, keyed by their type, are applied
+// to the latest copy of the object and written using a merge patch that
+// carries its resource version, retrying on conflict. Conditions set by other
+// writers are left alone.
+//
+// It returns false if there is nothing to write.
+func patchStatus(ctx context.Context, cl client.Client, obj, orig, upd client.Object) (bool, error) {
+	origStatus, origConds, err := splitStatusConditions(orig)
+	if err != nil {
+		return false, err
+	}
+
+	updStatus, updConds, err := splitStatusConditions(upd)
+	if err != nil {
+		return false, err
+	}
+
+	data, err := client.MergeFrom(origStatus).Data(updStatus)
+	if err != nil {
+		return false, err
+	}
+
+	changed := string(data) != "{}"
+	if changed {
+		if err := cl.Status().Patch(ctx, obj, client.RawPatch(types.MergePatchType, data)); err != nil {
+			return false, err
+		}
+	}
+
+	set, unset := diffConditions(origConds, updConds)
+	if len(set) == 0 && len(unset) == 0 {
+		return changed, nil
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		// Decoding into a copy of obj would keep any fields that are unset in
+		// the latest copy, so we start from an empty object.
+		latest, ok := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(client.Object)
+		if !ok {
+			return fmt.Errorf("obj: %T is not a client object", obj)
+		}
+
+		if err := cl.Get(ctx, client.ObjectKeyFromObject(obj), latest); err != nil {
+			return err
+		}
+
+		_, latestConds, err := splitStatusConditions(latest)
+		if err != nil {
+			return err
+		}
+
+		from := &unstructured.Unstructured{Object: map[string]interface{}{}}
+		from.SetResourceVersion(latest.GetResourceVersion())
+		if err := unstructured.SetNestedSlice(from.Object, latestConds, "status", "conditions"); err != nil {
+			return err
+		}
+
+		to := from.DeepCopy()
+		if err := unstructured.SetNestedSlice(to.Object, applyConditions(latestConds, set, unset), "status", "conditions"); err != nil {
+			return err
+		}
+
+		data, err := client.MergeFromWithOptions(from, client.MergeFromWithOptimisticLock{}).Data(to)
+		if err != nil {
+			return err
+		}
+
+		return cl.Status().Patch(ctx, obj, client.RawPatch(types.MergePatchType, data))
+	})
+	return err == nil, err
+}
+
+// splitStatusConditions converts obj to an unstructured object and removes the
+// status conditions from it, returning them separately.
+func splitStatusConditions(obj client.Object) (*unstructured.Unstructured, []interface{}, error) {
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conds, _, err := unstructured.NestedSlice(m, "status", "conditions")
+	if err != nil {
+		return nil, nil, err
+	}
+	unstructured.RemoveNestedField(m, "status", "conditions")
+
+	return &unstructured.Unstructured{Object: m}, conds, nil
+}
+
+// conditionType returns the type of an unstructured condition.
+func conditionType(cond interface{}) string {
+	m, _ := cond.(map[string]interface{})
+	typ, _ := m["type"].(string)
+	return typ
+}
+
+// diffConditions returns the conditions in upd that were added or changed
+// since orig and the types of the conditions in orig that upd no longer has.
+func diffConditions(orig, upd []interface{}) (set []interface{}, unset map[string]struct{}) {
+	byType := make(map[string]interface{}, len(orig))
+	for _, cond := range orig {
+		byType[conditionType(cond)] = cond
+	}
+
+	for _, cond := range upd {
+		typ := conditionType(cond)
+		if prev, found := byType[typ]; !found || !reflect.DeepEqual(prev, cond) {
+			set = append(set, cond)
+		}
+		delete(byType, typ)
+	}
+
+	if len(byType) > 0 {
+		unset = make(map[string]struct{}, len(byType))
+		for typ := range byType {
+			unset[typ] = struct{}{}
+		}
+	}
+
+	return
+}
+
+// applyConditions replaces or adds the conditions in set and removes the
+// conditions with the types in unset, keeping the order of conds.
+func applyConditions(conds, set []interface{}, unset map[string]struct{}) []interface{} {
+	byType := make(map[string]interface{}, len(set))
+	for _, cond := range set {
+		byType[conditionType(cond)] = cond
+	}
+
+	var r []interface{}
+	for _, cond := range conds {
+		typ := conditionType(cond)
+		if _, found := unset[typ]; found {
+			continue
+		} else if repl, found := byType[typ]; found {
+			r = append(r, repl)
+			delete(byType, typ)
+		} else {
+			r = append(r, cond)
+		}
+	}
+
+	for _, cond := range set {
+		if _, found := byType[conditionType(cond)]; found {
+			r = append(r, cond)
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,136 @@
+package obj_test
+
+import (
+	"context"
+	"testing"
+
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPoolPersistStatusFromStaleCopies(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, pvpoolv1alpha1.AddToScheme(scheme))
+
+	key := client.ObjectKey{Namespace: "default", Name: "test"}
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&pvpoolv1alpha1.Pool{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Status: pvpoolv1alpha1.PoolStatus{
+				Replicas:     3,
+				TemplateHash: "abc",
+			},
+		}).
+		Build()
+
+	a := pvpoolv1alpha1obj.NewPool(key)
+	_, err := a.Load(ctx, cl)
+	require.NoError(t, err)
+
+	b := pvpoolv1alpha1obj.NewPool(key)
+	_, err = b.Load(ctx, cl)
+	require.NoError(t, err)
+
+	// Both copies have the same resource version, so an update from the
+	// second one would conflict.
+	a.Object.Status.AvailableReplicas = 2
+	require.NoError(t, a.PersistStatus(ctx, cl))
+
+	b.Object.Status.Conditions = []pvpoolv1alpha1.PoolCondition{
+		{
+			Condition: pvpoolv1alpha1.Condition{Status: corev1.ConditionTrue},
+			Type:      pvpoolv1alpha1.PoolAvailable,
+		},
+	}
+	require.NoError(t, b.PersistStatus(ctx, cl))
+
+	p := pvpoolv1alpha1obj.NewPool(key)
+	_, err = p.Load(ctx, cl)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), p.Object.Status.Replicas)
+	assert.Equal(t, int32(2), p.Object.Status.AvailableReplicas)
+	assert.Equal(t, "abc", p.Object.Status.TemplateHash)
+	require.Len(t, p.Object.Status.Conditions, 1)
+	assert.Equal(t, pvpoolv1alpha1.PoolAvailable, p.Object.Status.Conditions[0].Type)
+
+	// Persisting an unchanged status doesn't write anything.
+	rv := p.Object.GetResourceVersion()
+	require.NoError(t, p.PersistStatus(ctx, cl))
+	assert.Equal(t, rv, p.Object.GetResourceVersion())
+}
+
+func TestPoolPersistStatusConditionsFromStaleCopies(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, pvpoolv1alpha1.AddToScheme(scheme))
+
+	key := client.ObjectKey{Namespace: "default", Name: "test"}
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&pvpoolv1alpha1.Pool{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Status: pvpoolv1alpha1.PoolStatus{
+				Conditions: []pvpoolv1alpha1.PoolCondition{
+					{
+						Condition: pvpoolv1alpha1.Condition{Status: corev1.ConditionFalse},
+						Type:      pvpoolv1alpha1.PoolAvailable,
+					},
+					{
+						Condition: pvpoolv1alpha1.Condition{Status: corev1.ConditionFalse},
+						Type:      pvpoolv1alpha1.PoolSettlement,
+					},
+				},
+			},
+		}).
+		Build()
+
+	a := pvpoolv1alpha1obj.NewPool(key)
+	_, err := a.Load(ctx, cl)
+	require.NoError(t, err)
+
+	b := pvpoolv1alpha1obj.NewPool(key)
+	_, err = b.Load(ctx, cl)
+	require.NoError(t, err)
+
+	a.Object.Status.Conditions[0].Status = corev1.ConditionTrue
+	require.NoError(t, a.PersistStatus(ctx, cl))
+
+	// The second copy still has the old Available condition, but it only
+	// changes the Settlement condition, so the first write survives.
+	b.Object.Status.Conditions[1].Status = corev1.ConditionTrue
+	require.NoError(t, b.PersistStatus(ctx, cl))
+
+	p := pvpoolv1alpha1obj.NewPool(key)
+	_, err = p.Load(ctx, cl)
+	require.NoError(t, err)
+
+	cond, ok := p.Condition(pvpoolv1alpha1.PoolAvailable)
+	require.True(t, ok)
+	assert.Equal(t, corev1.ConditionTrue, cond.Status)
+
+	cond, ok = p.Condition(pvpoolv1alpha1.PoolSettlement)
+	require.True(t, ok)
+	assert.Equal(t, corev1.ConditionTrue, cond.Status)
+
+	// Removing a condition from a stale copy only removes that condition.
+	b.Object.Status.Conditions = b.Object.Status.Conditions[1:]
+	require.NoError(t, b.PersistStatus(ctx, cl))
+
+	p = pvpoolv1alpha1obj.NewPool(key)
+	_, err = p.Load(ctx, cl)
+	require.NoError(t, err)
+	require.Len(t, p.Object.Status.Conditions, 1)
+	assert.Equal(t, pvpoolv1alpha1.PoolSettlement, p.Object.Status.Conditions[0].Type)
+	assert.Equal(t, corev1.ConditionTrue, p.Object.Status.Conditions[0].Status)
+}
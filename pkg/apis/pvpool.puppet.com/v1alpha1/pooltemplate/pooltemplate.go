@@ -0,0 +1,107 @@
+// Package pooltemplate copies the configuration in a PoolTemplate into the
+// pools that refer to it. The mutating webhook applies templates when pools
+// are admitted, and the controller applies them again when a template
+// changes.
+package pooltemplate
+
+import (
+	"encoding/json"
+
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+// Apply sets the PVC template and init job of a pool spec from the given
+// template with the overrides in the spec's template reference merged in. The
+// spec must refer to the template.
+func Apply(spec *pvpoolv1alpha1.PoolSpec, tpl *pvpoolv1alpha1.PoolTemplate) error {
+	template := tpl.Spec.Template.DeepCopy()
+	initJob := tpl.Spec.InitJob.DeepCopy()
+
+	if o := spec.TemplateRef.Overrides; o != nil {
+		if o.Template != nil {
+			merged := &pvpoolv1alpha1.PersistentVolumeClaimTemplate{}
+			if err := merge(template, o.Template, merged); err != nil {
+				return err
+			}
+			template = merged
+		}
+
+		if o.InitJob != nil {
+			if initJob == nil {
+				initJob = o.InitJob.DeepCopy()
+			} else {
+				merged := &pvpoolv1alpha1.MountJob{}
+				if err := merge(initJob, o.InitJob, merged); err != nil {
+					return err
+				}
+				initJob = merged
+			}
+		}
+	}
+
+	spec.Template = *template
+	spec.InitJob = initJob
+	return nil
+}
+
+// merge applies an override to an object using a strategic merge patch and
+// stores the result in out. Null values in the override come from fields that
+// were not set, so they are ignored instead of deleting the corresponding
+// fields from the object.
+func merge(obj, override, out interface{}) error {
+	original, err := toMap(obj)
+	if err != nil {
+		return err
+	}
+
+	patch, err := toMap(override)
+	if err != nil {
+		return err
+	}
+	removeNulls(patch)
+
+	merged, err := strategicpatch.StrategicMergeMapPatch(original, patch, out)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(merged)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, out)
+}
+
+func toMap(obj interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func removeNulls(v interface{}) {
+	switch vt := v.(type) {
+	case map[string]interface{}:
+		for key, value := range vt {
+			if value == nil {
+				delete(vt, key)
+				continue
+			}
+
+			removeNulls(value)
+		}
+	case []interface{}:
+		for _, value := range vt {
+			removeNulls(value)
+		}
+	}
+}
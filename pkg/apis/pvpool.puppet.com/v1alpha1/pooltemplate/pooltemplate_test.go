@@ -0,0 +1,150 @@
+package pooltemplate_test
+
+import (
+	"testing"
+
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	"github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/pooltemplate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func newPoolTemplate() *pvpoolv1alpha1.PoolTemplate {
+	return &pvpoolv1alpha1.PoolTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: pvpoolv1alpha1.PoolTemplateSpec{
+			Template: pvpoolv1alpha1.PersistentVolumeClaimTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app": "test",
+					},
+				},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					StorageClassName: pointer.StringPtr("local-path"),
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse("1Gi"),
+						},
+					},
+				},
+			},
+			InitJob: &pvpoolv1alpha1.MountJob{
+				Template: pvpoolv1alpha1.JobTemplate{
+					Spec: batchv1.JobSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:    "init",
+										Image:   "busybox",
+										Command: []string{"true"},
+									},
+									{
+										Name:  "sidecar",
+										Image: "busybox",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestApply(t *testing.T) {
+	tpl := newPoolTemplate()
+
+	spec := &pvpoolv1alpha1.PoolSpec{
+		TemplateRef: &pvpoolv1alpha1.PoolTemplateReference{Name: tpl.GetName()},
+	}
+	require.NoError(t, pooltemplate.Apply(spec, tpl))
+	assert.Equal(t, tpl.Spec.Template, spec.Template)
+	assert.Equal(t, tpl.Spec.InitJob, spec.InitJob)
+
+	// The spec must not share memory with the template.
+	spec.Template.Labels["app"] = "changed"
+	assert.Equal(t, "test", tpl.Spec.Template.Labels["app"])
+}
+
+func TestApplyOverrides(t *testing.T) {
+	tpl := newPoolTemplate()
+
+	spec := &pvpoolv1alpha1.PoolSpec{
+		TemplateRef: &pvpoolv1alpha1.PoolTemplateReference{
+			Name: tpl.GetName(),
+			Overrides: &pvpoolv1alpha1.PoolTemplateOverrides{
+				Template: &pvpoolv1alpha1.PersistentVolumeClaimTemplate{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							"tier": "fast",
+						},
+					},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceStorage: resource.MustParse("5Gi"),
+							},
+						},
+					},
+				},
+				InitJob: &pvpoolv1alpha1.MountJob{
+					Template: pvpoolv1alpha1.JobTemplate{
+						Spec: batchv1.JobSpec{
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{
+										{
+											Name:  "init",
+											Image: "registry.example.com/init",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, pooltemplate.Apply(spec, tpl))
+
+	assert.Equal(t, map[string]string{"app": "test", "tier": "fast"}, spec.Template.Labels)
+	assert.Equal(t, "local-path", *spec.Template.Spec.StorageClassName)
+	assert.True(t, resource.MustParse("5Gi").Equal(spec.Template.Spec.Resources.Requests[corev1.ResourceStorage]))
+
+	containers := spec.InitJob.Template.Spec.Template.Spec.Containers
+	require.Len(t, containers, 2)
+	assert.Equal(t, "init", containers[0].Name)
+	assert.Equal(t, "registry.example.com/init", containers[0].Image)
+	assert.Equal(t, []string{"true"}, containers[0].Command)
+	assert.Equal(t, "sidecar", containers[1].Name)
+
+	// The template itself is unchanged.
+	assert.Equal(t, newPoolTemplate(), tpl)
+}
+
+func TestApplyInitJobOverrideWithoutTemplateInitJob(t *testing.T) {
+	tpl := newPoolTemplate()
+	tpl.Spec.InitJob = nil
+
+	initJob := newPoolTemplate().Spec.InitJob
+	spec := &pvpoolv1alpha1.PoolSpec{
+		TemplateRef: &pvpoolv1alpha1.PoolTemplateReference{
+			Name: tpl.GetName(),
+			Overrides: &pvpoolv1alpha1.PoolTemplateOverrides{
+				InitJob: initJob,
+			},
+		},
+	}
+	require.NoError(t, pooltemplate.Apply(spec, tpl))
+	assert.Equal(t, initJob, spec.InitJob)
+}
@@ -0,0 +1,75 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PoolTemplateKind is the public Kubernetes group-version-kind triple for the
+// PoolTemplate type.
+var PoolTemplateKind = SchemeGroupVersion.WithKind("PoolTemplate")
+
+// PoolTemplate is a PVC template and init job shared by many pools.
+//
+// Pools refer to a template by name in their templateRef field. When a
+// template changes, every pool that refers to it is updated.
+//
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PoolTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              PoolTemplateSpec `json:"spec"`
+}
+
+// PoolTemplateSpec is the configuration shared by pools that use a template.
+type PoolTemplateSpec struct {
+	// Template describes the configuration of the dynamic PVCs of each pool
+	// that uses this template.
+	Template PersistentVolumeClaimTemplate `json:"template"`
+
+	// InitJob configures a job to process newly created PVs before they are
+	// made available as part of each pool.
+	//
+	// +optional
+	InitJob *MountJob `json:"initJob,omitempty"`
+}
+
+// PoolTemplateReference identifies a pool template.
+type PoolTemplateReference struct {
+	// Name is the name of the pool template.
+	Name string `json:"name"`
+
+	// Overrides are changes to the template for this pool only.
+	//
+	// +optional
+	Overrides *PoolTemplateOverrides `json:"overrides,omitempty"`
+}
+
+// PoolTemplateOverrides are merged into a pool template using the same
+// strategic merge rules as kubectl patch. For example, a container in an
+// overridden init job replaces only the fields it sets in the container with
+// the same name.
+type PoolTemplateOverrides struct {
+	// Template overrides the PVC template.
+	//
+	// +optional
+	Template *PersistentVolumeClaimTemplate `json:"template,omitempty"`
+
+	// InitJob overrides the init job.
+	//
+	// +optional
+	InitJob *MountJob `json:"initJob,omitempty"`
+}
+
+// PoolTemplateList enumerates many PoolTemplate resources.
+//
+// +kubebuilder:object:root=true
+type PoolTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PoolTemplate `json:"items"`
+}
@@ -10,10 +10,10 @@ import (
 // package.
 var SchemeGroupVersion = schema.GroupVersion{Group: "pvpool.puppet.com", Version: "v1alpha1"}
 
-// Resource returns the public Kubernetes group-version-resource triple for a
-// given resource in this package.
-func Resource(resource string) schema.GroupVersionResource {
-	return SchemeGroupVersion.WithResource(resource)
+// Resource returns the public Kubernetes group-resource pair for a given
+// resource in this package.
+func Resource(resource string) schema.GroupResource {
+	return SchemeGroupVersion.WithResource(resource).GroupResource()
 }
 
 var (
@@ -29,8 +29,14 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Checkout{},
 		&CheckoutList{},
+		&ClusterPool{},
+		&ClusterPoolList{},
 		&Pool{},
 		&PoolList{},
+		&PoolPolicy{},
+		&PoolPolicyList{},
+		&PoolTemplate{},
+		&PoolTemplateList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -0,0 +1,240 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// MountJobLimits are the upper bounds placed on the configuration of a mount
+// job.
+type MountJobLimits struct {
+	MaxActiveDeadlineSeconds int64
+	MaxBackoffLimit          int32
+}
+
+// DefaultMountJobLimits are the limits used when no PoolPolicy overrides them.
+var DefaultMountJobLimits = MountJobLimits{
+	MaxActiveDeadlineSeconds: MountJobMaxActiveDeadlineSeconds,
+	MaxBackoffLimit:          MountJobMaxBackoffLimit,
+}
+
+// MountJobLimitsForPolicies computes the effective mount job limits for the
+// given policies. If more than one policy sets a limit, the most restrictive
+// value is used. Limits that no policy sets use their default values.
+func MountJobLimitsForPolicies(policies []pvpoolv1alpha1.PoolPolicy) MountJobLimits {
+	var activeDeadlineSeconds *int64
+	var backoffLimit *int32
+
+	for _, policy := range policies {
+		mj := policy.Spec.MountJob
+		if mj == nil {
+			continue
+		}
+
+		if n := mj.MaxActiveDeadlineSeconds; n != nil && (activeDeadlineSeconds == nil || *n < *activeDeadlineSeconds) {
+			activeDeadlineSeconds = n
+		}
+
+		if n := mj.MaxBackoffLimit; n != nil && (backoffLimit == nil || *n < *backoffLimit) {
+			backoffLimit = n
+		}
+	}
+
+	limits := DefaultMountJobLimits
+	if activeDeadlineSeconds != nil {
+		limits.MaxActiveDeadlineSeconds = *activeDeadlineSeconds
+	}
+	if backoffLimit != nil {
+		limits.MaxBackoffLimit = *backoffLimit
+	}
+
+	return limits
+}
+
+// PoolStorageRequest computes the total storage requested by a pool across all
+// of its replicas.
+func PoolStorageRequest(spec *pvpoolv1alpha1.PoolSpec) resource.Quantity {
+	var replicas int64 = 1
+	if spec.Replicas != nil {
+		replicas = int64(*spec.Replicas)
+	}
+
+	storage := spec.Template.Spec.Resources.Requests.Storage()
+	return *resource.NewQuantity(storage.Value()*replicas, storage.Format)
+}
+
+// PoliciesLimitNamespaceStorage determines whether any of the given policies
+// limits the storage requested by all pools in a namespace.
+func PoliciesLimitNamespaceStorage(policies []pvpoolv1alpha1.PoolPolicy) bool {
+	for _, policy := range policies {
+		if policy.Spec.MaxStoragePerNamespace != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// NamespaceStorageRequest computes the total storage requested by the given
+// pools in a namespace, excluding the pool with the given name. This is the
+// storage that counts against a policy's namespace limit when that pool is
+// checked.
+//
+// Pools are excluded by name rather than UID because a pool being created
+// does not have a UID yet.
+func NamespaceStorageRequest(pools []pvpoolv1alpha1.Pool, name string) resource.Quantity {
+	var storage resource.Quantity
+	for i := range pools {
+		if pools[i].GetName() == name {
+			continue
+		}
+
+		storage.Add(PoolStorageRequest(&pools[i].Spec))
+	}
+	return storage
+}
+
+// ImageAllowed determines whether the given image matches any of the patterns
+// in a policy's list of allowed images.
+func ImageAllowed(image string, allowed []string) bool {
+	for _, candidate := range allowed {
+		if prefix := strings.TrimSuffix(candidate, "*"); prefix != candidate {
+			if strings.HasPrefix(image, prefix) {
+				return true
+			}
+		} else if image == candidate {
+			return true
+		}
+	}
+
+	return false
+}
+
+func ValidateMountJobLimits(j *pvpoolv1alpha1.MountJob, limits MountJobLimits, p *field.Path) (errs field.ErrorList) {
+	if j.Template.Spec.ActiveDeadlineSeconds != nil && *j.Template.Spec.ActiveDeadlineSeconds > limits.MaxActiveDeadlineSeconds {
+		errs = append(errs, field.Invalid(
+			p.Child("template", "spec", "activeDeadlineSeconds"),
+			*j.Template.Spec.ActiveDeadlineSeconds,
+			fmt.Sprintf("must be at most %d", limits.MaxActiveDeadlineSeconds),
+		))
+	}
+
+	if j.Template.Spec.BackoffLimit != nil && *j.Template.Spec.BackoffLimit > limits.MaxBackoffLimit {
+		errs = append(errs, field.Invalid(
+			p.Child("template", "spec", "backoffLimit"),
+			*j.Template.Spec.BackoffLimit,
+			fmt.Sprintf("must be at most %d", limits.MaxBackoffLimit),
+		))
+	}
+
+	return
+}
+
+func validateMountJobImages(j *pvpoolv1alpha1.MountJob, policy *pvpoolv1alpha1.PoolPolicy, p *field.Path) (errs field.ErrorList) {
+	podSpecPath := p.Child("template", "spec", "template", "spec")
+	for _, containers := range []struct {
+		Path       *field.Path
+		Containers []corev1.Container
+	}{
+		{Path: podSpecPath.Child("initContainers"), Containers: j.Template.Spec.Template.Spec.InitContainers},
+		{Path: podSpecPath.Child("containers"), Containers: j.Template.Spec.Template.Spec.Containers},
+	} {
+		for i, container := range containers.Containers {
+			if !ImageAllowed(container.Image, policy.Spec.AllowedImages) {
+				errs = append(errs, field.Forbidden(
+					containers.Path.Index(i).Child("image"),
+					fmt.Sprintf("image %q is not allowed by pool policy %q", container.Image, policy.GetName()),
+				))
+			}
+		}
+	}
+
+	return
+}
+
+// ValidatePoolSpecForPolicy checks a pool against a single policy. The
+// namespaceStorage argument is the total storage requested by every other pool
+// in the same namespace.
+func ValidatePoolSpecForPolicy(spec *pvpoolv1alpha1.PoolSpec, policy *pvpoolv1alpha1.PoolPolicy, namespaceStorage resource.Quantity, p *field.Path) (errs field.ErrorList) {
+	if n := policy.Spec.MaxReplicas; n != nil && spec.Replicas != nil && *spec.Replicas > *n {
+		errs = append(errs, field.Forbidden(
+			p.Child("replicas"),
+			fmt.Sprintf("must be at most %d as required by pool policy %q", *n, policy.GetName()),
+		))
+	}
+
+	storage := spec.Template.Spec.Resources.Requests.Storage()
+	if q := policy.Spec.MaxStoragePerReplica; q != nil && storage.Cmp(*q) > 0 {
+		errs = append(errs, field.Forbidden(
+			p.Child("template", "spec", "resources", "requests").Key(string(corev1.ResourceStorage)),
+			fmt.Sprintf("must be at most %s as required by pool policy %q", q, policy.GetName()),
+		))
+	}
+
+	if q := policy.Spec.MaxStoragePerNamespace; q != nil {
+		total := PoolStorageRequest(spec)
+		total.Add(namespaceStorage)
+		if total.Cmp(*q) > 0 {
+			errs = append(errs, field.Forbidden(
+				p.Child("replicas"),
+				fmt.Sprintf("pools in this namespace would request %s of storage, but pool policy %q allows at most %s", total.String(), policy.GetName(), q),
+			))
+		}
+	}
+
+	if len(policy.Spec.AllowedStorageClassNames) > 0 {
+		var storageClassName string
+		if spec.Template.Spec.StorageClassName != nil {
+			storageClassName = *spec.Template.Spec.StorageClassName
+		}
+
+		var found bool
+		for _, candidate := range policy.Spec.AllowedStorageClassNames {
+			if candidate == storageClassName {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, field.NotSupported(
+				p.Child("template", "spec", "storageClassName"),
+				storageClassName,
+				policy.Spec.AllowedStorageClassNames,
+			))
+		}
+	}
+
+	if len(policy.Spec.AllowedImages) > 0 {
+		if spec.InitJob != nil {
+			errs = append(errs, validateMountJobImages(spec.InitJob, policy, p.Child("initJob"))...)
+		}
+
+		if spec.HealthCheck != nil {
+			errs = append(errs, validateMountJobImages(&spec.HealthCheck.Job, policy, p.Child("healthCheck", "job"))...)
+		}
+	}
+
+	return
+}
+
+// ValidatePoolSpecForPolicies checks a pool against every policy in the
+// cluster, including the mount job limits derived from the policies.
+func ValidatePoolSpecForPolicies(spec *pvpoolv1alpha1.PoolSpec, policies []pvpoolv1alpha1.PoolPolicy, namespaceStorage resource.Quantity, p *field.Path) (errs field.ErrorList) {
+	for i := range policies {
+		errs = append(errs, ValidatePoolSpecForPolicy(spec, &policies[i], namespaceStorage, p)...)
+	}
+
+	limits := MountJobLimitsForPolicies(policies)
+	if spec.InitJob != nil {
+		errs = append(errs, ValidateMountJobLimits(spec.InitJob, limits, p.Child("initJob"))...)
+	}
+	if spec.HealthCheck != nil {
+		errs = append(errs, ValidateMountJobLimits(&spec.HealthCheck.Job, limits, p.Child("healthCheck", "job"))...)
+	}
+
+	return
+}
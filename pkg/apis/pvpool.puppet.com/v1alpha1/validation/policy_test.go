@@ -0,0 +1,85 @@
+package validation_test
+
+import (
+	"testing"
+
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	"github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/validation"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+)
+
+func testPool(name string, uid types.UID, replicas *int32, storage string) pvpoolv1alpha1.Pool {
+	return pvpoolv1alpha1.Pool{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      name,
+			UID:       uid,
+		},
+		Spec: pvpoolv1alpha1.PoolSpec{
+			Replicas: replicas,
+			Template: pvpoolv1alpha1.PersistentVolumeClaimTemplate{
+				Spec: corev1.PersistentVolumeClaimSpec{
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse(storage),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestNamespaceStorageRequest(t *testing.T) {
+	pools := []pvpoolv1alpha1.Pool{
+		testPool("a", "uid-a", pointer.Int32Ptr(2), "1Gi"),
+		testPool("b", "uid-b", nil, "512Mi"),
+		testPool("c", "uid-c", pointer.Int32Ptr(0), "10Gi"),
+	}
+
+	tests := []struct {
+		Name     string
+		Exclude  string
+		Expected resource.Quantity
+	}{
+		{
+			Name:     "New pool",
+			Exclude:  "d",
+			Expected: resource.MustParse("2560Mi"),
+		},
+		{
+			Name:     "Existing pool",
+			Exclude:  "a",
+			Expected: resource.MustParse("512Mi"),
+		},
+		{
+			Name:     "Existing pool with default replicas",
+			Exclude:  "b",
+			Expected: resource.MustParse("2Gi"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			actual := validation.NamespaceStorageRequest(pools, test.Exclude)
+			assert.Equal(t, 0, test.Expected.Cmp(actual), "expected %s, got %s", test.Expected.String(), actual.String())
+		})
+	}
+}
+
+func TestPoliciesLimitNamespaceStorage(t *testing.T) {
+	limit := resource.MustParse("1Gi")
+
+	assert.False(t, validation.PoliciesLimitNamespaceStorage(nil))
+	assert.False(t, validation.PoliciesLimitNamespaceStorage([]pvpoolv1alpha1.PoolPolicy{
+		{Spec: pvpoolv1alpha1.PoolPolicySpec{MaxReplicas: pointer.Int32Ptr(1)}},
+	}))
+	assert.True(t, validation.PoliciesLimitNamespaceStorage([]pvpoolv1alpha1.PoolPolicy{
+		{Spec: pvpoolv1alpha1.PoolPolicySpec{MaxReplicas: pointer.Int32Ptr(1)}},
+		{Spec: pvpoolv1alpha1.PoolPolicySpec{MaxStoragePerNamespace: &limit}},
+	}))
+}
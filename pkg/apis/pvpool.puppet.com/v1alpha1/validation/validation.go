@@ -10,6 +10,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -19,9 +20,42 @@ const (
 	MountJobMaxBackoffLimit          = 10
 )
 
+var (
+	supportedAccessModes = []string{
+		string(corev1.ReadWriteOnce),
+		string(corev1.ReadOnlyMany),
+		string(corev1.ReadWriteMany),
+	}
+	supportedVolumeModes = []string{
+		string(corev1.PersistentVolumeBlock),
+		string(corev1.PersistentVolumeFilesystem),
+	}
+)
+
 func ValidatePersistentVolumeClaimTemplate(tpl *pvpoolv1alpha1.PersistentVolumeClaimTemplate, selector labels.Selector, p *field.Path) (errs field.ErrorList) {
+	errs = append(errs, validatePersistentVolumeClaimTemplateMetadata(tpl, selector, p)...)
+	errs = append(errs, ValidatePersistentVolumeClaimSpec(&tpl.Spec, p.Child("spec"))...)
+	return
+}
+
+func validateAccessModes(modes []corev1.PersistentVolumeAccessMode, p *field.Path) (errs field.ErrorList) {
+	seen := make(map[corev1.PersistentVolumeAccessMode]bool, len(modes))
+	for i, mode := range modes {
+		switch {
+		case seen[mode]:
+			errs = append(errs, field.Duplicate(p.Index(i), mode))
+		case mode != corev1.ReadWriteOnce && mode != corev1.ReadOnlyMany && mode != corev1.ReadWriteMany:
+			errs = append(errs, field.NotSupported(p.Index(i), mode, supportedAccessModes))
+		}
+		seen[mode] = true
+	}
+
+	return
+}
+
+func validatePersistentVolumeClaimTemplateMetadata(tpl *pvpoolv1alpha1.PersistentVolumeClaimTemplate, selector labels.Selector, p *field.Path) (errs field.ErrorList) {
 	errs = append(errs, metav1validation.ValidateLabels(tpl.Labels, p.Child("metadata", "labels"))...)
-	errs = append(errs, apimachineryvalidation.ValidateAnnotations(tpl.Annotations, p.Child("metadata", "labels"))...)
+	errs = append(errs, apimachineryvalidation.ValidateAnnotations(tpl.Annotations, p.Child("metadata", "annotations"))...)
 
 	if !selector.Empty() {
 		ls := labels.Set(tpl.Labels)
@@ -33,7 +67,50 @@ func ValidatePersistentVolumeClaimTemplate(tpl *pvpoolv1alpha1.PersistentVolumeC
 	return
 }
 
+// ValidatePersistentVolumeClaimSpec checks the spec of a pool's PVC template.
+// Pool replicas are always dynamically provisioned, so fields that would
+// prevent that are rejected instead of being ignored.
+func ValidatePersistentVolumeClaimSpec(spec *corev1.PersistentVolumeClaimSpec, p *field.Path) (errs field.ErrorList) {
+	errs = append(errs, validateAccessModes(spec.AccessModes, p.Child("accessModes"))...)
+
+	if spec.Selector != nil {
+		errs = append(errs, field.Forbidden(p.Child("selector"), "must not be set because pool replicas are dynamically provisioned"))
+	}
+
+	if storage, ok := spec.Resources.Requests[corev1.ResourceStorage]; !ok {
+		errs = append(errs, field.Required(p.Child("resources", "requests", string(corev1.ResourceStorage)), "a storage request is required"))
+	} else if storage.Sign() <= 0 {
+		errs = append(errs, field.Invalid(p.Child("resources", "requests", string(corev1.ResourceStorage)), storage.String(), "must be greater than zero"))
+	}
+
+	if spec.VolumeName != "" {
+		errs = append(errs, field.Forbidden(p.Child("volumeName"), "must not be set because pool replicas are dynamically provisioned"))
+	}
+
+	if spec.StorageClassName != nil && *spec.StorageClassName == "" {
+		errs = append(errs, field.Invalid(p.Child("storageClassName"), "", "must not be empty; omit the field to use the default storage class"))
+	}
+
+	if spec.VolumeMode != nil && *spec.VolumeMode != corev1.PersistentVolumeBlock && *spec.VolumeMode != corev1.PersistentVolumeFilesystem {
+		errs = append(errs, field.NotSupported(p.Child("volumeMode"), *spec.VolumeMode, supportedVolumeModes))
+	}
+
+	return
+}
+
 func ValidateMountJob(j *pvpoolv1alpha1.MountJob, p *field.Path) (errs field.ErrorList) {
+	// The CRD does not include a schema for the job spec, so we check the
+	// fields the API server would otherwise require here.
+	containersPath := p.Child("template", "spec", "template", "spec", "containers")
+	if len(j.Template.Spec.Template.Spec.Containers) == 0 {
+		errs = append(errs, field.Required(containersPath, ""))
+	}
+	for i, c := range j.Template.Spec.Template.Spec.Containers {
+		if c.Name == "" {
+			errs = append(errs, field.Required(containersPath.Index(i).Child("name"), ""))
+		}
+	}
+
 	if j.Template.Spec.Template.Spec.RestartPolicy != "" && j.Template.Spec.Template.Spec.RestartPolicy != MountJobSpecBackoffPolicy {
 		errs = append(errs, field.NotSupported(
 			p.Child("template", "spec", "template", "spec", "restartPolicy"),
@@ -42,26 +119,51 @@ func ValidateMountJob(j *pvpoolv1alpha1.MountJob, p *field.Path) (errs field.Err
 		))
 	}
 
-	if j.Template.Spec.ActiveDeadlineSeconds != nil && *j.Template.Spec.ActiveDeadlineSeconds > MountJobMaxActiveDeadlineSeconds {
-		errs = append(errs, field.Invalid(
-			p.Child("template", "spec", "activeDeadlineSeconds"),
-			*j.Template.Spec.ActiveDeadlineSeconds,
-			fmt.Sprintf("must be at most %d", MountJobMaxActiveDeadlineSeconds),
-		))
+	return
+}
+
+func ValidatePoolHealthCheck(hc *pvpoolv1alpha1.PoolHealthCheck, p *field.Path) (errs field.ErrorList) {
+	errs = append(errs, ValidateMountJob(&hc.Job, p.Child("job"))...)
+
+	if hc.Interval != nil && hc.Interval.Duration <= 0 {
+		errs = append(errs, field.Invalid(p.Child("interval"), hc.Interval.Duration.String(), "must be greater than zero"))
+	} else if hc.Interval == nil && !hc.BeforeCheckout {
+		errs = append(errs, field.Required(p.Child("interval"), "one of `interval` or `beforeCheckout` must be set"))
 	}
 
-	if j.Template.Spec.BackoffLimit != nil && *j.Template.Spec.BackoffLimit > MountJobMaxBackoffLimit {
-		errs = append(errs, field.Invalid(
-			p.Child("template", "spec", "backoffLimit"),
-			*j.Template.Spec.BackoffLimit,
-			fmt.Sprintf("must be at most %d", MountJobMaxBackoffLimit),
-		))
+	return
+}
+
+func ValidatePoolProvisioning(pp *pvpoolv1alpha1.PoolProvisioning, p *field.Path) (errs field.ErrorList) {
+	for _, d := range []struct {
+		Name     string
+		Duration *metav1.Duration
+	}{
+		{Name: "stalledAfter", Duration: pp.StalledAfter},
+		{Name: "replaceAfter", Duration: pp.ReplaceAfter},
+	} {
+		if d.Duration != nil && d.Duration.Duration <= 0 {
+			errs = append(errs, field.Invalid(p.Child(d.Name), d.Duration.Duration.String(), "must be greater than zero"))
+		}
+	}
+
+	return
+}
+
+func ValidatePoolAdoption(pa *pvpoolv1alpha1.PoolAdoption, p *field.Path) (errs field.ErrorList) {
+	errs = append(errs, metav1validation.ValidateLabelSelector(&pa.Selector, p.Child("selector"))...)
+	if len(pa.Selector.MatchLabels)+len(pa.Selector.MatchExpressions) == 0 {
+		errs = append(errs, field.Invalid(p.Child("selector"), pa.Selector, "empty selector would adopt every PVC in the namespace"))
 	}
 
 	return
 }
 
 func ValidatePoolSpec(spec *pvpoolv1alpha1.PoolSpec, p *field.Path) (errs field.ErrorList) {
+	return validatePoolSpec(spec, true, p)
+}
+
+func validatePoolSpec(spec *pvpoolv1alpha1.PoolSpec, validateClaimSpec bool, p *field.Path) (errs field.ErrorList) {
 	errs = append(errs, metav1validation.ValidateLabelSelector(&spec.Selector, p.Child("selector"))...)
 	if len(spec.Selector.MatchLabels)+len(spec.Selector.MatchExpressions) == 0 {
 		errs = append(errs, field.Invalid(p.Child("selector"), spec.Selector, "empty selector is invalid for deployment"))
@@ -71,23 +173,119 @@ func ValidatePoolSpec(spec *pvpoolv1alpha1.PoolSpec, p *field.Path) (errs field.
 	if err != nil {
 		errs = append(errs, field.Invalid(p.Child("selector"), spec.Selector, "invalid label selector"))
 	} else {
-		errs = append(errs, ValidatePersistentVolumeClaimTemplate(&spec.Template, selector, p.Child("template"))...)
+		errs = append(errs, validatePersistentVolumeClaimTemplateMetadata(&spec.Template, selector, p.Child("template"))...)
+	}
+
+	if validateClaimSpec {
+		errs = append(errs, ValidatePersistentVolumeClaimSpec(&spec.Template.Spec, p.Child("template", "spec"))...)
 	}
 
 	if spec.InitJob != nil {
 		errs = append(errs, ValidateMountJob(spec.InitJob, p.Child("initJob"))...)
 	}
 
+	if spec.HealthCheck != nil {
+		errs = append(errs, ValidatePoolHealthCheck(spec.HealthCheck, p.Child("healthCheck"))...)
+	}
+
+	if spec.Provisioning != nil {
+		errs = append(errs, ValidatePoolProvisioning(spec.Provisioning, p.Child("provisioning"))...)
+	}
+
+	if spec.Adoption != nil {
+		errs = append(errs, ValidatePoolAdoption(spec.Adoption, p.Child("adoption"))...)
+	}
+
+	return
+}
+
+func ValidatePoolTemplateSpec(spec *pvpoolv1alpha1.PoolTemplateSpec, p *field.Path) (errs field.ErrorList) {
+	// The selector is checked against the template when a pool uses it.
+	errs = append(errs, ValidatePersistentVolumeClaimTemplate(&spec.Template, labels.Everything(), p.Child("template"))...)
+
+	if spec.InitJob != nil {
+		errs = append(errs, ValidateMountJob(spec.InitJob, p.Child("initJob"))...)
+	}
+
 	return
 }
 
 func ValidatePoolSpecUpdate(newSpec, oldSpec *pvpoolv1alpha1.PoolSpec, p *field.Path) (errs field.ErrorList) {
-	errs = append(errs, ValidatePoolSpec(newSpec, p)...)
+	// Pools admitted before the PVC spec was checked may not pass, so we only
+	// check it when it changes. Otherwise such a pool could not even have its
+	// finalizers removed.
+	errs = append(errs, validatePoolSpec(newSpec, !equality.Semantic.DeepEqual(newSpec.Template.Spec, oldSpec.Template.Spec), p)...)
 	errs = append(errs, apimachineryvalidation.ValidateImmutableField(newSpec.Selector, oldSpec.Selector, p.Child("selector"))...)
 	return
 }
 
+func ValidatePoolReference(ref *pvpoolv1alpha1.PoolReference, p *field.Path) (errs field.ErrorList) {
+	switch ref.Kind {
+	case "", pvpoolv1alpha1.PoolKind.Kind:
+	case pvpoolv1alpha1.ClusterPoolKind.Kind:
+		if ref.Namespace != "" {
+			errs = append(errs, field.Invalid(p.Child("namespace"), ref.Namespace, "must not be set for a cluster pool"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(p.Child("kind"), ref.Kind, []string{pvpoolv1alpha1.PoolKind.Kind, pvpoolv1alpha1.ClusterPoolKind.Kind}))
+	}
+
+	return
+}
+
+func ValidateCheckoutSpec(spec *pvpoolv1alpha1.CheckoutSpec, p *field.Path) (errs field.ErrorList) {
+	errs = append(errs, ValidatePoolReference(&spec.PoolRef, p.Child("poolRef"))...)
+
+	if spec.ClaimName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(spec.ClaimName) {
+			errs = append(errs, field.Invalid(p.Child("claimName"), spec.ClaimName, msg))
+		}
+	}
+
+	errs = append(errs, validateAccessModes(spec.AccessModes, p.Child("accessModes"))...)
+
+	return
+}
+
+// ValidateCheckoutAccessModesForPool checks that the storage of a pool's
+// replicas can be used with the access modes a checkout requests. Any volume
+// can be used read-only, but a checkout can only write to a volume from more
+// than one node if the pool's storage supports it.
+func ValidateCheckoutAccessModesForPool(spec *pvpoolv1alpha1.CheckoutSpec, poolSpec *pvpoolv1alpha1.PoolSpec, p *field.Path) (errs field.ErrorList) {
+	poolModes := poolSpec.Template.Spec.AccessModes
+	if len(poolModes) == 0 {
+		// The controller requests RWO for replicas if the template doesn't say.
+		poolModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	}
+
+	supported := map[corev1.PersistentVolumeAccessMode]bool{
+		corev1.ReadOnlyMany: true,
+	}
+	for _, mode := range poolModes {
+		switch mode {
+		case corev1.ReadWriteMany:
+			supported[corev1.ReadWriteMany] = true
+			supported[corev1.ReadWriteOnce] = true
+		case corev1.ReadWriteOnce:
+			supported[corev1.ReadWriteOnce] = true
+		}
+	}
+
+	for i, mode := range spec.AccessModes {
+		if !supported[mode] {
+			errs = append(errs, field.Invalid(p.Index(i), mode, fmt.Sprintf("the pool's PVC template does not request a compatible access mode (it requests %v)", poolModes)))
+		}
+	}
+
+	return
+}
+
 func ValidateCheckoutUpdate(newCheckout, oldCheckout *pvpoolv1alpha1.Checkout) (errs field.ErrorList) {
+	// Checkouts admitted before the spec was fully checked may not pass, so we
+	// only check the spec when it changes.
+	if !equality.Semantic.DeepEqual(oldCheckout.Spec, newCheckout.Spec) {
+		errs = append(errs, ValidateCheckoutSpec(&newCheckout.Spec, field.NewPath("spec"))...)
+	}
 	if oldCheckout.Status.VolumeName != "" {
 		if !equality.Semantic.DeepEqual(oldCheckout.Spec, newCheckout.Spec) {
 			errs = append(errs, field.Invalid(field.NewPath("spec"), newCheckout.Spec, "field is immutable once a volume has been selected"))
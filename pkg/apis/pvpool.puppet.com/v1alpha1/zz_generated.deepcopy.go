@@ -6,6 +6,7 @@ package v1alpha1
 
 import (
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -84,6 +85,22 @@ func (in *CheckoutList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckoutSource) DeepCopyInto(out *CheckoutSource) {
+	*out = *in
+	out.PoolRef = in.PoolRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckoutSource.
+func (in *CheckoutSource) DeepCopy() *CheckoutSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckoutSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CheckoutSpec) DeepCopyInto(out *CheckoutSpec) {
 	*out = *in
@@ -109,6 +126,28 @@ func (in *CheckoutSpec) DeepCopy() *CheckoutSpec {
 func (in *CheckoutStatus) DeepCopyInto(out *CheckoutStatus) {
 	*out = *in
 	out.VolumeClaimRef = in.VolumeClaimRef
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(CheckoutSource)
+		**out = **in
+	}
+	if in.RequestedAt != nil {
+		in, out := &in.RequestedAt, &out.RequestedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.VolumeSelectedAt != nil {
+		in, out := &in.VolumeSelectedAt, &out.VolumeSelectedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ClaimBoundAt != nil {
+		in, out := &in.ClaimBoundAt, &out.ClaimBoundAt
+		*out = (*in).DeepCopy()
+	}
+	if in.WaitDuration != nil {
+		in, out := &in.WaitDuration, &out.WaitDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]CheckoutCondition, len(*in))
@@ -128,6 +167,86 @@ func (in *CheckoutStatus) DeepCopy() *CheckoutStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPool) DeepCopyInto(out *ClusterPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPool.
+func (in *ClusterPool) DeepCopy() *ClusterPool {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolList) DeepCopyInto(out *ClusterPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolList.
+func (in *ClusterPoolList) DeepCopy() *ClusterPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolStatus) DeepCopyInto(out *ClusterPoolStatus) {
+	*out = *in
+	in.PoolStatus.DeepCopyInto(&out.PoolStatus)
+	if in.PoolRef != nil {
+		in, out := &in.PoolRef, &out.PoolRef
+		*out = new(PoolReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolStatus.
+func (in *ClusterPoolStatus) DeepCopy() *ClusterPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
@@ -221,6 +340,22 @@ func (in *Pool) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolAdoption) DeepCopyInto(out *PoolAdoption) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolAdoption.
+func (in *PoolAdoption) DeepCopy() *PoolAdoption {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolAdoption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PoolCondition) DeepCopyInto(out *PoolCondition) {
 	*out = *in
@@ -237,6 +372,27 @@ func (in *PoolCondition) DeepCopy() *PoolCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolHealthCheck) DeepCopyInto(out *PoolHealthCheck) {
+	*out = *in
+	in.Job.DeepCopyInto(&out.Job)
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolHealthCheck.
+func (in *PoolHealthCheck) DeepCopy() *PoolHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PoolList) DeepCopyInto(out *PoolList) {
 	*out = *in
@@ -269,6 +425,159 @@ func (in *PoolList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolPolicy) DeepCopyInto(out *PoolPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolPolicy.
+func (in *PoolPolicy) DeepCopy() *PoolPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PoolPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolPolicyList) DeepCopyInto(out *PoolPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PoolPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolPolicyList.
+func (in *PoolPolicyList) DeepCopy() *PoolPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PoolPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolPolicyMountJob) DeepCopyInto(out *PoolPolicyMountJob) {
+	*out = *in
+	if in.MaxActiveDeadlineSeconds != nil {
+		in, out := &in.MaxActiveDeadlineSeconds, &out.MaxActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxBackoffLimit != nil {
+		in, out := &in.MaxBackoffLimit, &out.MaxBackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolPolicyMountJob.
+func (in *PoolPolicyMountJob) DeepCopy() *PoolPolicyMountJob {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolPolicyMountJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolPolicySpec) DeepCopyInto(out *PoolPolicySpec) {
+	*out = *in
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxStoragePerReplica != nil {
+		in, out := &in.MaxStoragePerReplica, &out.MaxStoragePerReplica
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxStoragePerNamespace != nil {
+		in, out := &in.MaxStoragePerNamespace, &out.MaxStoragePerNamespace
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.AllowedStorageClassNames != nil {
+		in, out := &in.AllowedStorageClassNames, &out.AllowedStorageClassNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedImages != nil {
+		in, out := &in.AllowedImages, &out.AllowedImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MountJob != nil {
+		in, out := &in.MountJob, &out.MountJob
+		*out = new(PoolPolicyMountJob)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolPolicySpec.
+func (in *PoolPolicySpec) DeepCopy() *PoolPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolProvisioning) DeepCopyInto(out *PoolProvisioning) {
+	*out = *in
+	if in.StalledAfter != nil {
+		in, out := &in.StalledAfter, &out.StalledAfter
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ReplaceAfter != nil {
+		in, out := &in.ReplaceAfter, &out.ReplaceAfter
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolProvisioning.
+func (in *PoolProvisioning) DeepCopy() *PoolProvisioning {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolProvisioning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PoolReference) DeepCopyInto(out *PoolReference) {
 	*out = *in
@@ -284,6 +593,47 @@ func (in *PoolReference) DeepCopy() *PoolReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolReplicaJobStatus) DeepCopyInto(out *PoolReplicaJobStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolReplicaJobStatus.
+func (in *PoolReplicaJobStatus) DeepCopy() *PoolReplicaJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolReplicaJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolReplicaStatus) DeepCopyInto(out *PoolReplicaStatus) {
+	*out = *in
+	in.CreationTimestamp.DeepCopyInto(&out.CreationTimestamp)
+	if in.InitJob != nil {
+		in, out := &in.InitJob, &out.InitJob
+		*out = new(PoolReplicaJobStatus)
+		**out = **in
+	}
+	if in.HealthCheckJob != nil {
+		in, out := &in.HealthCheckJob, &out.HealthCheckJob
+		*out = new(PoolReplicaJobStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolReplicaStatus.
+func (in *PoolReplicaStatus) DeepCopy() *PoolReplicaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolReplicaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PoolSpec) DeepCopyInto(out *PoolSpec) {
 	*out = *in
@@ -293,12 +643,32 @@ func (in *PoolSpec) DeepCopyInto(out *PoolSpec) {
 		**out = **in
 	}
 	in.Selector.DeepCopyInto(&out.Selector)
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(PoolTemplateReference)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Template.DeepCopyInto(&out.Template)
 	if in.InitJob != nil {
 		in, out := &in.InitJob, &out.InitJob
 		*out = new(MountJob)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(PoolHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Provisioning != nil {
+		in, out := &in.Provisioning, &out.Provisioning
+		*out = new(PoolProvisioning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(PoolAdoption)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolSpec.
@@ -314,6 +684,13 @@ func (in *PoolSpec) DeepCopy() *PoolSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PoolStatus) DeepCopyInto(out *PoolStatus) {
 	*out = *in
+	if in.ReplicaStatuses != nil {
+		in, out := &in.ReplicaStatuses, &out.ReplicaStatuses
+		*out = make([]PoolReplicaStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]PoolCondition, len(*in))
@@ -332,3 +709,127 @@ func (in *PoolStatus) DeepCopy() *PoolStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolTemplate) DeepCopyInto(out *PoolTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolTemplate.
+func (in *PoolTemplate) DeepCopy() *PoolTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PoolTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolTemplateList) DeepCopyInto(out *PoolTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PoolTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolTemplateList.
+func (in *PoolTemplateList) DeepCopy() *PoolTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PoolTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolTemplateOverrides) DeepCopyInto(out *PoolTemplateOverrides) {
+	*out = *in
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(PersistentVolumeClaimTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InitJob != nil {
+		in, out := &in.InitJob, &out.InitJob
+		*out = new(MountJob)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolTemplateOverrides.
+func (in *PoolTemplateOverrides) DeepCopy() *PoolTemplateOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolTemplateOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolTemplateReference) DeepCopyInto(out *PoolTemplateReference) {
+	*out = *in
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = new(PoolTemplateOverrides)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolTemplateReference.
+func (in *PoolTemplateReference) DeepCopy() *PoolTemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolTemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolTemplateSpec) DeepCopyInto(out *PoolTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	if in.InitJob != nil {
+		in, out := &in.InitJob, &out.InitJob
+		*out = new(MountJob)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolTemplateSpec.
+func (in *PoolTemplateSpec) DeepCopy() *PoolTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,225 @@
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CheckoutKind is the public Kubernetes group-version-kind triple for the
+// Checkout type.
+var CheckoutKind = SchemeGroupVersion.WithKind("Checkout")
+
+// Checkout requests a PVC from a Pool.
+//
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Claim",type="string",JSONPath=".status.volumeClaimRef.name"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type Checkout struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              CheckoutSpec `json:"spec"`
+
+	// +optional
+	Status CheckoutStatus `json:"status"`
+}
+
+// CheckoutSpec is the configuration to request a particular PV from a Pool.
+type CheckoutSpec struct {
+	// PoolRef is the pool to check out a PVC from.
+	PoolRef PoolReference `json:"poolRef"`
+
+	// VolumeClaimName is the name of the PVC to allocate.
+	//
+	// If not specified, the name of the checkout is used.
+	//
+	// +optional
+	VolumeClaimName string `json:"volumeClaimName,omitempty"`
+
+	// AccessModes are the access modes to assign to the checked out PVC.
+	// Defaults to ReadWriteOnce.
+	//
+	// +optional
+	// +kubebuilder:default={"ReadWriteOnce"}
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+}
+
+const (
+	// CheckoutAcquired indicates whether a Checkout has successfully taken a
+	// PVC from the pool.
+	CheckoutAcquired = "Acquired"
+
+	// CheckoutAcquiredReasonPoolDoesNotExist is used to indicate that the
+	// poolRef points to a nonexistent pool.
+	CheckoutAcquiredReasonPoolDoesNotExist = "PoolDoesNotExist"
+
+	// CheckoutAcquiredReasonNotAvailable is used to indicate that the pool does
+	// not have any available PVCs.
+	CheckoutAcquiredReasonNotAvailable = "NotAvailable"
+
+	// CheckoutAcquiredReasonHealthCheckPending is used to indicate that the
+	// pool requires a PVC to be verified before it can be checked out and the
+	// verification has not yet completed.
+	CheckoutAcquiredReasonHealthCheckPending = "HealthCheckPending"
+
+	// CheckoutAcquiredReasonInvalid is used to indicate that the PVC template
+	// for this checkout is invalid.
+	CheckoutAcquiredReasonInvalid = "Invalid"
+
+	// CheckoutAcquiredReasonConflict is used to indicate that another PVC that
+	// isn't owned by this checkout already exists with the name this checkout
+	// wants to use.
+	CheckoutAcquiredReasonConflict = "Conflict"
+
+	// CheckoutAcquiredReasonCheckedOut is used to indicate that a PVC was
+	// successfully taken and is now available.
+	CheckoutAcquiredReasonCheckedOut = "CheckedOut"
+
+	// CheckoutVolumeSelected indicates whether the controller has chosen a PV
+	// from the pool for a Checkout. When it is false, its reason is one of
+	// the PoolDoesNotExist, NotAvailable, or HealthCheckPending reasons of the
+	// Acquired condition.
+	CheckoutVolumeSelected = "VolumeSelected"
+
+	// CheckoutVolumeSelectedReasonSelected is used to indicate that a PV was
+	// chosen from the pool.
+	CheckoutVolumeSelectedReasonSelected = "Selected"
+
+	// CheckoutVolumeSelectedReasonPending is used to indicate that the
+	// controller has not yet tried to choose a PV.
+	CheckoutVolumeSelectedReasonPending = "Pending"
+
+	// CheckoutTransferring indicates whether the selected PV is being handed
+	// off from the pool to the checked out PVC. When it is false because the
+	// handoff failed, its reason is the Conflict or Invalid reason of the
+	// Acquired condition.
+	CheckoutTransferring = "Transferring"
+
+	// CheckoutTransferringReasonWaitingForVolume is used to indicate that the
+	// handoff has not started because no PV has been selected yet.
+	CheckoutTransferringReasonWaitingForVolume = "WaitingForVolume"
+
+	// CheckoutTransferringReasonInProgress is used to indicate that the PV is
+	// being handed off to the checked out PVC.
+	CheckoutTransferringReasonInProgress = "InProgress"
+
+	// CheckoutTransferringReasonComplete is used to indicate that the handoff
+	// finished.
+	CheckoutTransferringReasonComplete = "Complete"
+
+	// CheckoutBound indicates whether the checked out PVC is bound to its PV.
+	CheckoutBound = "Bound"
+
+	// CheckoutBoundReasonClaimPending is used to indicate that the checked out
+	// PVC does not exist or is not bound yet.
+	CheckoutBoundReasonClaimPending = "ClaimPending"
+
+	// CheckoutBoundReasonClaimBound is used to indicate that the checked out
+	// PVC is bound.
+	CheckoutBoundReasonClaimBound = "ClaimBound"
+
+	// CheckoutReady indicates whether the checked out PVC is ready to use.
+	// When it is false, its reason is the reason of the first stage that has
+	// not completed.
+	CheckoutReady = "Ready"
+
+	// CheckoutReadyReasonCheckedOut is used to indicate that every stage of
+	// the checkout has completed and the PVC can be used.
+	CheckoutReadyReasonCheckedOut = "CheckedOut"
+
+	// CheckoutReadyReasonTransferring is used to indicate that the checkout
+	// is waiting for the PV handoff to finish.
+	CheckoutReadyReasonTransferring = "Transferring"
+
+	// CheckoutReadyReasonPending is used to indicate that the controller has
+	// not yet determined why the checkout is not ready.
+	CheckoutReadyReasonPending = "Pending"
+)
+
+// CheckoutStatus is the runtime state of a checkout.
+type CheckoutStatus struct {
+	// VolumeName is the name of the volume being configured for the checkout.
+	// It will track a volume from the upstream pool until its configuration is
+	// copied to a new volume, at which point it will be permanently set to that
+	// new volume.
+	//
+	// This field will be set as soon as a PVC is available in the pool.
+	//
+	// +optional
+	VolumeName string `json:"volumeName,omitempty"`
+
+	// VolumeClaimRef is a reference to the PVC checked out from the pool.
+	//
+	// This field will only be set when the checked out PVC is ready to be used.
+	//
+	// +optional
+	VolumeClaimRef corev1.LocalObjectReference `json:"volumeClaimRef,omitempty"`
+
+	// Source identifies the pool replica that the checked out volume was taken
+	// from.
+	//
+	// This field will be set as soon as a PVC is selected from the pool.
+	//
+	// +optional
+	Source *CheckoutSource `json:"source,omitempty"`
+
+	// RequestedAt is the time the checkout was created.
+	//
+	// +optional
+	RequestedAt *metav1.Time `json:"requestedAt,omitempty"`
+
+	// VolumeSelectedAt is the time the controller selected a volume from the
+	// pool for this checkout.
+	//
+	// +optional
+	VolumeSelectedAt *metav1.Time `json:"volumeSelectedAt,omitempty"`
+
+	// ClaimBoundAt is the time the controller first observed the checked out
+	// PVC bound and ready to use.
+	//
+	// +optional
+	ClaimBoundAt *metav1.Time `json:"claimBoundAt,omitempty"`
+
+	// WaitDuration is the amount of time between the checkout being requested
+	// and its PVC being bound.
+	//
+	// +optional
+	WaitDuration *metav1.Duration `json:"waitDuration,omitempty"`
+
+	// Conditions are the possible observable conditions for the checkout.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// CheckoutSource identifies the pool replica a checked out volume was taken
+// from.
+type CheckoutSource struct {
+	// PoolRef is the pool the volume was taken from.
+	PoolRef PoolReference `json:"poolRef"`
+
+	// PoolGeneration is the generation of the pool spec that the replica was
+	// created from.
+	//
+	// +optional
+	PoolGeneration int64 `json:"poolGeneration,omitempty"`
+
+	// VolumeClaimName is the name of the replica's PVC in the pool. The
+	// replica's init job had the same name.
+	VolumeClaimName string `json:"volumeClaimName"`
+
+	// VolumeName is the name of the replica's PV in the pool.
+	VolumeName string `json:"volumeName"`
+}
+
+// CheckoutList enumerates many Checkout resources.
+//
+// +kubebuilder:object:root=true
+type CheckoutList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Checkout `json:"items"`
+}
@@ -0,0 +1,52 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterPoolKind is the public Kubernetes group-version-kind triple for the
+// ClusterPool type.
+var ClusterPoolKind = SchemeGroupVersion.WithKind("ClusterPool")
+
+// ClusterPool is a pool that is not part of any tenant namespace. Its replicas
+// are kept in a storage namespace managed by the controller, and checkouts in
+// any namespace may use it if they are permitted to.
+//
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Available",type="string",JSONPath=".status.availableReplicas"
+// +kubebuilder:printcolumn:name="Pool",type="string",JSONPath=".status.poolRef.name",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ClusterPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              PoolSpec `json:"spec"`
+
+	// +optional
+	Status ClusterPoolStatus `json:"status"`
+}
+
+// ClusterPoolStatus is the runtime state of a cluster pool.
+type ClusterPoolStatus struct {
+	// PoolStatus is the status of the pool that holds the replicas for this
+	// cluster pool.
+	PoolStatus `json:",inline"`
+
+	// PoolRef is the pool in the storage namespace that holds the replicas
+	// for this cluster pool. It is set once the pool has been created.
+	//
+	// +optional
+	PoolRef *PoolReference `json:"poolRef,omitempty"`
+}
+
+// ClusterPoolList enumerates many ClusterPool resources.
+//
+// +kubebuilder:object:root=true
+type ClusterPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterPool `json:"items"`
+}
@@ -0,0 +1,443 @@
+package v1beta1
+
+import (
+	"fmt"
+
+	"github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+var (
+	_ conversion.Convertible = &Checkout{}
+	_ conversion.Convertible = &Pool{}
+	_ conversion.Convertible = &ClusterPool{}
+	_ conversion.Convertible = &PoolPolicy{}
+	_ conversion.Convertible = &PoolTemplate{}
+)
+
+// ConvertTo converts this checkout to the hub version.
+func (in *Checkout) ConvertTo(hub conversion.Hub) error {
+	out, ok := hub.(*v1alpha1.Checkout)
+	if !ok {
+		return fmt.Errorf("unexpected hub type %T", hub)
+	}
+
+	in = in.DeepCopy()
+
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = v1alpha1.CheckoutSpec{
+		PoolRef:     v1alpha1.PoolReference(in.Spec.PoolRef),
+		ClaimName:   in.Spec.VolumeClaimName,
+		AccessModes: in.Spec.AccessModes,
+	}
+	out.Status = v1alpha1.CheckoutStatus{
+		VolumeName:       in.Status.VolumeName,
+		VolumeClaimRef:   in.Status.VolumeClaimRef,
+		RequestedAt:      in.Status.RequestedAt,
+		VolumeSelectedAt: in.Status.VolumeSelectedAt,
+		ClaimBoundAt:     in.Status.ClaimBoundAt,
+		WaitDuration:     in.Status.WaitDuration,
+	}
+	if src := in.Status.Source; src != nil {
+		out.Status.Source = &v1alpha1.CheckoutSource{
+			PoolRef:                   v1alpha1.PoolReference(src.PoolRef),
+			PoolGeneration:            src.PoolGeneration,
+			PersistentVolumeClaimName: src.VolumeClaimName,
+			PersistentVolumeName:      src.VolumeName,
+		}
+	}
+	for _, cond := range in.Status.Conditions {
+		out.Status.Conditions = append(out.Status.Conditions, v1alpha1.CheckoutCondition{
+			Condition: convertConditionToHub(cond),
+			Type:      v1alpha1.CheckoutConditionType(cond.Type),
+		})
+	}
+
+	return nil
+}
+
+// ConvertFrom converts a checkout from the hub version to this version.
+func (in *Checkout) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1alpha1.Checkout)
+	if !ok {
+		return fmt.Errorf("unexpected hub type %T", hub)
+	}
+
+	src = src.DeepCopy()
+
+	in.ObjectMeta = src.ObjectMeta
+	in.Spec = CheckoutSpec{
+		PoolRef:         PoolReference(src.Spec.PoolRef),
+		VolumeClaimName: src.Spec.ClaimName,
+		AccessModes:     src.Spec.AccessModes,
+	}
+	in.Status = CheckoutStatus{
+		VolumeName:       src.Status.VolumeName,
+		VolumeClaimRef:   src.Status.VolumeClaimRef,
+		RequestedAt:      src.Status.RequestedAt,
+		VolumeSelectedAt: src.Status.VolumeSelectedAt,
+		ClaimBoundAt:     src.Status.ClaimBoundAt,
+		WaitDuration:     src.Status.WaitDuration,
+	}
+	if source := src.Status.Source; source != nil {
+		in.Status.Source = &CheckoutSource{
+			PoolRef:         PoolReference(source.PoolRef),
+			PoolGeneration:  source.PoolGeneration,
+			VolumeClaimName: source.PersistentVolumeClaimName,
+			VolumeName:      source.PersistentVolumeName,
+		}
+	}
+	for _, cond := range src.Status.Conditions {
+		in.Status.Conditions = append(in.Status.Conditions, convertConditionFromHub(string(cond.Type), cond.Condition))
+	}
+
+	return nil
+}
+
+// ConvertTo converts this pool to the hub version.
+func (in *Pool) ConvertTo(hub conversion.Hub) error {
+	out, ok := hub.(*v1alpha1.Pool)
+	if !ok {
+		return fmt.Errorf("unexpected hub type %T", hub)
+	}
+
+	in = in.DeepCopy()
+
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = convertPoolSpecToHub(in.Spec)
+	out.Status = convertPoolStatusToHub(in.Status)
+
+	return nil
+}
+
+// ConvertFrom converts a pool from the hub version to this version.
+func (in *Pool) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1alpha1.Pool)
+	if !ok {
+		return fmt.Errorf("unexpected hub type %T", hub)
+	}
+
+	src = src.DeepCopy()
+
+	in.ObjectMeta = src.ObjectMeta
+	in.Spec = convertPoolSpecFromHub(src.Spec)
+	in.Status = convertPoolStatusFromHub(src.Status)
+
+	return nil
+}
+
+// ConvertTo converts this cluster pool to the hub version.
+func (in *ClusterPool) ConvertTo(hub conversion.Hub) error {
+	out, ok := hub.(*v1alpha1.ClusterPool)
+	if !ok {
+		return fmt.Errorf("unexpected hub type %T", hub)
+	}
+
+	in = in.DeepCopy()
+
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = convertPoolSpecToHub(in.Spec)
+	out.Status = v1alpha1.ClusterPoolStatus{
+		PoolStatus: convertPoolStatusToHub(in.Status.PoolStatus),
+		PoolRef:    (*v1alpha1.PoolReference)(in.Status.PoolRef),
+	}
+
+	return nil
+}
+
+// ConvertFrom converts a cluster pool from the hub version to this version.
+func (in *ClusterPool) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1alpha1.ClusterPool)
+	if !ok {
+		return fmt.Errorf("unexpected hub type %T", hub)
+	}
+
+	src = src.DeepCopy()
+
+	in.ObjectMeta = src.ObjectMeta
+	in.Spec = convertPoolSpecFromHub(src.Spec)
+	in.Status = ClusterPoolStatus{
+		PoolStatus: convertPoolStatusFromHub(src.Status.PoolStatus),
+		PoolRef:    (*PoolReference)(src.Status.PoolRef),
+	}
+
+	return nil
+}
+
+// ConvertTo converts this pool policy to the hub version.
+func (in *PoolPolicy) ConvertTo(hub conversion.Hub) error {
+	out, ok := hub.(*v1alpha1.PoolPolicy)
+	if !ok {
+		return fmt.Errorf("unexpected hub type %T", hub)
+	}
+
+	in = in.DeepCopy()
+
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = v1alpha1.PoolPolicySpec{
+		MaxReplicas:              in.Spec.MaxReplicas,
+		MaxStoragePerReplica:     in.Spec.MaxStoragePerReplica,
+		MaxStoragePerNamespace:   in.Spec.MaxStoragePerNamespace,
+		AllowedStorageClassNames: in.Spec.AllowedStorageClassNames,
+		AllowedImages:            in.Spec.AllowedImages,
+		MountJob:                 (*v1alpha1.PoolPolicyMountJob)(in.Spec.MountJob),
+	}
+
+	return nil
+}
+
+// ConvertFrom converts a pool policy from the hub version to this version.
+func (in *PoolPolicy) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1alpha1.PoolPolicy)
+	if !ok {
+		return fmt.Errorf("unexpected hub type %T", hub)
+	}
+
+	src = src.DeepCopy()
+
+	in.ObjectMeta = src.ObjectMeta
+	in.Spec = PoolPolicySpec{
+		MaxReplicas:              src.Spec.MaxReplicas,
+		MaxStoragePerReplica:     src.Spec.MaxStoragePerReplica,
+		MaxStoragePerNamespace:   src.Spec.MaxStoragePerNamespace,
+		AllowedStorageClassNames: src.Spec.AllowedStorageClassNames,
+		AllowedImages:            src.Spec.AllowedImages,
+		MountJob:                 (*PoolPolicyMountJob)(src.Spec.MountJob),
+	}
+
+	return nil
+}
+
+// ConvertTo converts this pool template to the hub version.
+func (in *PoolTemplate) ConvertTo(hub conversion.Hub) error {
+	out, ok := hub.(*v1alpha1.PoolTemplate)
+	if !ok {
+		return fmt.Errorf("unexpected hub type %T", hub)
+	}
+
+	in = in.DeepCopy()
+
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = v1alpha1.PoolTemplateSpec{
+		Template: v1alpha1.PersistentVolumeClaimTemplate(in.Spec.VolumeClaimTemplate),
+		InitJob:  convertMountJobToHub(in.Spec.InitJob),
+	}
+
+	return nil
+}
+
+// ConvertFrom converts a pool template from the hub version to this version.
+func (in *PoolTemplate) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1alpha1.PoolTemplate)
+	if !ok {
+		return fmt.Errorf("unexpected hub type %T", hub)
+	}
+
+	src = src.DeepCopy()
+
+	in.ObjectMeta = src.ObjectMeta
+	in.Spec = PoolTemplateSpec{
+		VolumeClaimTemplate: PersistentVolumeClaimTemplate(src.Spec.Template),
+		InitJob:             convertMountJobFromHub(src.Spec.InitJob),
+	}
+
+	return nil
+}
+
+func convertPoolSpecToHub(in PoolSpec) v1alpha1.PoolSpec {
+	out := v1alpha1.PoolSpec{
+		Replicas:       in.Replicas,
+		Selector:       in.Selector,
+		TemplateRef:    convertPoolTemplateReferenceToHub(in.TemplateRef),
+		Template:       v1alpha1.PersistentVolumeClaimTemplate(in.VolumeClaimTemplate),
+		InitJob:        convertMountJobToHub(in.InitJob),
+		Provisioning:   (*v1alpha1.PoolProvisioning)(in.Provisioning),
+		DeletionPolicy: v1alpha1.PoolDeletionPolicy(in.DeletionPolicy),
+		Adoption:       (*v1alpha1.PoolAdoption)(in.Adoption),
+	}
+	if hc := in.HealthCheck; hc != nil {
+		out.HealthCheck = &v1alpha1.PoolHealthCheck{
+			Job:            *convertMountJobToHub(&hc.Job),
+			Interval:       hc.Interval,
+			BeforeCheckout: hc.BeforeCheckout,
+		}
+	}
+	return out
+}
+
+func convertPoolSpecFromHub(src v1alpha1.PoolSpec) PoolSpec {
+	in := PoolSpec{
+		Replicas:            src.Replicas,
+		Selector:            src.Selector,
+		TemplateRef:         convertPoolTemplateReferenceFromHub(src.TemplateRef),
+		VolumeClaimTemplate: PersistentVolumeClaimTemplate(src.Template),
+		InitJob:             convertMountJobFromHub(src.InitJob),
+		Provisioning:        (*PoolProvisioning)(src.Provisioning),
+		DeletionPolicy:      PoolDeletionPolicy(src.DeletionPolicy),
+		Adoption:            (*PoolAdoption)(src.Adoption),
+	}
+	if hc := src.HealthCheck; hc != nil {
+		in.HealthCheck = &PoolHealthCheck{
+			Job:            *convertMountJobFromHub(&hc.Job),
+			Interval:       hc.Interval,
+			BeforeCheckout: hc.BeforeCheckout,
+		}
+	}
+	return in
+}
+
+func convertPoolStatusToHub(in PoolStatus) v1alpha1.PoolStatus {
+	out := v1alpha1.PoolStatus{
+		ObservedGeneration: in.ObservedGeneration,
+		Replicas:           in.Replicas,
+		AvailableReplicas:  in.AvailableReplicas,
+		TemplateHash:       in.TemplateHash,
+	}
+	for _, rs := range in.ReplicaStatuses {
+		out.ReplicaStatuses = append(out.ReplicaStatuses, v1alpha1.PoolReplicaStatus{
+			ClaimName:         rs.VolumeClaimName,
+			VolumeName:        rs.VolumeName,
+			Phase:             v1alpha1.PoolReplicaPhase(rs.Phase),
+			CreationTimestamp: rs.CreationTimestamp,
+			NodeName:          rs.NodeName,
+			Zone:              rs.Zone,
+			TemplateHash:      rs.TemplateHash,
+			InitJob:           convertPoolReplicaJobStatusToHub(rs.InitJob),
+			HealthCheckJob:    convertPoolReplicaJobStatusToHub(rs.HealthCheckJob),
+		})
+	}
+	for _, cond := range in.Conditions {
+		out.Conditions = append(out.Conditions, v1alpha1.PoolCondition{
+			Condition: convertConditionToHub(cond),
+			Type:      v1alpha1.PoolConditionType(cond.Type),
+		})
+	}
+	return out
+}
+
+func convertPoolStatusFromHub(src v1alpha1.PoolStatus) PoolStatus {
+	in := PoolStatus{
+		ObservedGeneration: src.ObservedGeneration,
+		Replicas:           src.Replicas,
+		AvailableReplicas:  src.AvailableReplicas,
+		TemplateHash:       src.TemplateHash,
+	}
+	for _, rs := range src.ReplicaStatuses {
+		in.ReplicaStatuses = append(in.ReplicaStatuses, PoolReplicaStatus{
+			VolumeClaimName:   rs.ClaimName,
+			VolumeName:        rs.VolumeName,
+			Phase:             PoolReplicaPhase(rs.Phase),
+			CreationTimestamp: rs.CreationTimestamp,
+			NodeName:          rs.NodeName,
+			Zone:              rs.Zone,
+			TemplateHash:      rs.TemplateHash,
+			InitJob:           convertPoolReplicaJobStatusFromHub(rs.InitJob),
+			HealthCheckJob:    convertPoolReplicaJobStatusFromHub(rs.HealthCheckJob),
+		})
+	}
+	for _, cond := range src.Conditions {
+		in.Conditions = append(in.Conditions, convertConditionFromHub(string(cond.Type), cond.Condition))
+	}
+	return in
+}
+
+func convertPoolTemplateReferenceToHub(in *PoolTemplateReference) *v1alpha1.PoolTemplateReference {
+	if in == nil {
+		return nil
+	}
+
+	out := &v1alpha1.PoolTemplateReference{Name: in.Name}
+	if o := in.Overrides; o != nil {
+		out.Overrides = &v1alpha1.PoolTemplateOverrides{
+			Template: (*v1alpha1.PersistentVolumeClaimTemplate)(o.VolumeClaimTemplate),
+			InitJob:  convertMountJobToHub(o.InitJob),
+		}
+	}
+	return out
+}
+
+func convertPoolTemplateReferenceFromHub(src *v1alpha1.PoolTemplateReference) *PoolTemplateReference {
+	if src == nil {
+		return nil
+	}
+
+	in := &PoolTemplateReference{Name: src.Name}
+	if o := src.Overrides; o != nil {
+		in.Overrides = &PoolTemplateOverrides{
+			VolumeClaimTemplate: (*PersistentVolumeClaimTemplate)(o.Template),
+			InitJob:             convertMountJobFromHub(o.InitJob),
+		}
+	}
+	return in
+}
+
+func convertMountJobToHub(in *MountJob) *v1alpha1.MountJob {
+	if in == nil {
+		return nil
+	}
+
+	return &v1alpha1.MountJob{
+		Template:   v1alpha1.JobTemplate(in.Template),
+		VolumeName: in.PodVolumeName,
+	}
+}
+
+func convertMountJobFromHub(src *v1alpha1.MountJob) *MountJob {
+	if src == nil {
+		return nil
+	}
+
+	return &MountJob{
+		Template:      JobTemplate(src.Template),
+		PodVolumeName: src.VolumeName,
+	}
+}
+
+func convertPoolReplicaJobStatusToHub(in *PoolReplicaJobStatus) *v1alpha1.PoolReplicaJobStatus {
+	if in == nil {
+		return nil
+	}
+
+	return &v1alpha1.PoolReplicaJobStatus{
+		Name:    in.Name,
+		State:   v1alpha1.PoolReplicaJobState(in.State),
+		Reason:  in.Reason,
+		Message: in.Message,
+	}
+}
+
+func convertPoolReplicaJobStatusFromHub(src *v1alpha1.PoolReplicaJobStatus) *PoolReplicaJobStatus {
+	if src == nil {
+		return nil
+	}
+
+	return &PoolReplicaJobStatus{
+		Name:    src.Name,
+		State:   PoolReplicaJobState(src.State),
+		Reason:  src.Reason,
+		Message: src.Message,
+	}
+}
+
+func convertConditionToHub(in metav1.Condition) v1alpha1.Condition {
+	return v1alpha1.Condition{
+		Status:             corev1.ConditionStatus(in.Status),
+		LastTransitionTime: in.LastTransitionTime,
+		Reason:             in.Reason,
+		Message:            in.Message,
+		ObservedGeneration: in.ObservedGeneration,
+	}
+}
+
+func convertConditionFromHub(typ string, src v1alpha1.Condition) metav1.Condition {
+	return metav1.Condition{
+		Type:               typ,
+		Status:             metav1.ConditionStatus(src.Status),
+		ObservedGeneration: src.ObservedGeneration,
+		LastTransitionTime: src.LastTransitionTime,
+		Reason:             src.Reason,
+		Message:            src.Message,
+	}
+}
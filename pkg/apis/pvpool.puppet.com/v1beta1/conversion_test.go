@@ -0,0 +1,121 @@
+package v1beta1_test
+
+import (
+	"math/rand"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	"github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1beta1"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	runtimeserializer "k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/diff"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+const fuzzIterations = 100
+
+func newFuzzer(t *testing.T) *fuzz.Fuzzer {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	funcs := fuzzer.MergeFuzzerFuncs(
+		metafuzzer.Funcs,
+		func(_ runtimeserializer.CodecFactory) []interface{} {
+			return []interface{}{
+				func(q *resource.Quantity, c fuzz.Continue) {
+					*q = *resource.NewQuantity(c.Int63n(1000), resource.DecimalSI)
+				},
+			}
+		},
+	)
+
+	return fuzzer.FuzzerFor(funcs, rand.NewSource(rand.Int63()), runtimeserializer.NewCodecFactory(scheme)).
+		NilChance(0.2).
+		NumElements(0, 2).
+		MaxDepth(12)
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Hub   func() conversion.Hub
+		Spoke func() conversion.Convertible
+	}{
+		{
+			Name:  "Checkout",
+			Hub:   func() conversion.Hub { return &v1alpha1.Checkout{} },
+			Spoke: func() conversion.Convertible { return &v1beta1.Checkout{} },
+		},
+		{
+			Name:  "Pool",
+			Hub:   func() conversion.Hub { return &v1alpha1.Pool{} },
+			Spoke: func() conversion.Convertible { return &v1beta1.Pool{} },
+		},
+		{
+			Name:  "ClusterPool",
+			Hub:   func() conversion.Hub { return &v1alpha1.ClusterPool{} },
+			Spoke: func() conversion.Convertible { return &v1beta1.ClusterPool{} },
+		},
+		{
+			Name:  "PoolPolicy",
+			Hub:   func() conversion.Hub { return &v1alpha1.PoolPolicy{} },
+			Spoke: func() conversion.Convertible { return &v1beta1.PoolPolicy{} },
+		},
+		{
+			Name:  "PoolTemplate",
+			Hub:   func() conversion.Hub { return &v1alpha1.PoolTemplate{} },
+			Spoke: func() conversion.Convertible { return &v1beta1.PoolTemplate{} },
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			f := newFuzzer(t)
+
+			t.Run("hub to spoke to hub", func(t *testing.T) {
+				for i := 0; i < fuzzIterations; i++ {
+					original := test.Hub()
+					f.Fuzz(original)
+					clearTypeMeta(original)
+
+					spoke := test.Spoke()
+					require.NoError(t, spoke.ConvertFrom(original))
+
+					converted := test.Hub()
+					require.NoError(t, spoke.ConvertTo(converted))
+
+					require.True(t, equality.Semantic.DeepEqual(original, converted), diff.ObjectReflectDiff(original, converted))
+				}
+			})
+
+			t.Run("spoke to hub to spoke", func(t *testing.T) {
+				for i := 0; i < fuzzIterations; i++ {
+					original := test.Spoke()
+					f.Fuzz(original)
+					clearTypeMeta(original)
+
+					hub := test.Hub()
+					require.NoError(t, original.ConvertTo(hub))
+
+					converted := test.Spoke()
+					require.NoError(t, converted.ConvertFrom(hub))
+
+					require.True(t, equality.Semantic.DeepEqual(original, converted), diff.ObjectReflectDiff(original, converted))
+				}
+			})
+		})
+	}
+}
+
+// clearTypeMeta removes the kind and API version from a fuzzed object. These
+// are set by the conversion webhook, not by the conversion functions.
+func clearTypeMeta(obj runtime.Object) {
+	obj.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+}
@@ -0,0 +1,18 @@
+// Package v1beta1 is the v1 beta 1 version of the API.
+//
+// It differs from v1alpha1 as follows:
+//
+//   - Pool spec.template is renamed to spec.volumeClaimTemplate.
+//   - Mount job volumeName is renamed to podVolumeName.
+//   - Checkout spec.claimName is renamed to spec.volumeClaimName.
+//   - Checkout status.source persistentVolumeClaimName and persistentVolumeName
+//     are renamed to volumeClaimName and volumeName.
+//   - Pool status.replicaStatuses[].claimName is renamed to volumeClaimName.
+//   - Conditions use the standard metav1.Condition type and are not restricted
+//     to a fixed set of types.
+//
+// Objects are stored as v1alpha1, which is the hub for conversion.
+//
+// +groupName=pvpool.puppet.com
+// +kubebuilder:object:generate=true
+package v1beta1
@@ -0,0 +1,407 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PoolKind is the public Kubernetes group-version-kind triple for the Pool
+// type.
+var PoolKind = SchemeGroupVersion.WithKind("Pool")
+
+// Pool is a collection of preconfigured persistent volumes that can be taken
+// and recycled as needed.
+//
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Available",type="string",JSONPath=".status.availableReplicas"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type Pool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              PoolSpec `json:"spec"`
+
+	// +optional
+	Status PoolStatus `json:"status"`
+}
+
+// PoolSpec is the configuration for a pool.
+type PoolSpec struct {
+	// Replicas are the number of PVs to make available in the pool.
+	//
+	// Once a PV is checked out from the pool, it no longer counts toward the
+	// number replicas. Setting this field to 0 will make the pool unusable.
+	//
+	// +optional
+	// +kubebuilder:default=1
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Selector is the label selector for PVCs maintained in the pool.
+	//
+	// The selector must match a subset of the labels in the template.
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// TemplateRef refers to a pool template to copy the PVC template and init
+	// job of this pool from. When it is set, the volume claim template and
+	// init job fields of this pool are managed by PVPool and should not be
+	// changed directly.
+	//
+	// +optional
+	TemplateRef *PoolTemplateReference `json:"templateRef,omitempty"`
+
+	// VolumeClaimTemplate describes the configuration of the dynamic PVCs
+	// that this controller should manage. It is required unless TemplateRef
+	// is set.
+	//
+	// +optional
+	VolumeClaimTemplate PersistentVolumeClaimTemplate `json:"volumeClaimTemplate"`
+
+	// InitJob configures a job to process newly created PVs before they are
+	// made available as part of the pool.
+	//
+	// +optional
+	InitJob *MountJob `json:"initJob,omitempty"`
+
+	// HealthCheck configures a job to periodically verify that available PVs
+	// are still usable. PVs that fail verification are removed from the pool
+	// and replaced.
+	//
+	// +optional
+	HealthCheck *PoolHealthCheck `json:"healthCheck,omitempty"`
+
+	// Provisioning configures how the pool handles PVCs that the storage
+	// provisioner does not bind.
+	//
+	// +optional
+	Provisioning *PoolProvisioning `json:"provisioning,omitempty"`
+
+	// DeletionPolicy determines what happens to the replicas in this pool
+	// when the pool is deleted.
+	//
+	// +optional
+	// +kubebuilder:default="Delete"
+	DeletionPolicy PoolDeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Adoption configures the pool to take control of existing PVCs in its
+	// namespace, such as volumes that were provisioned by hand, instead of
+	// creating new ones.
+	//
+	// +optional
+	Adoption *PoolAdoption `json:"adoption,omitempty"`
+}
+
+// PoolAdoption configures how a pool adopts existing PVCs.
+type PoolAdoption struct {
+	// Selector is the label selector for existing PVCs to adopt.
+	//
+	// When the pool needs another replica, it adopts a bound PVC matching
+	// this selector that is not controlled by another object in preference to
+	// creating a new one. Adopted PVCs are labeled and annotated from the
+	// template and are owned by the pool like any other replica. PVCs are
+	// never adopted in excess of the requested number of replicas.
+	//
+	// A PVC is skipped unless its storage class, access modes, and volume
+	// mode match those set in the template and its capacity is at least the
+	// template's storage request.
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// RunInitJob runs the pool's init job against each adopted PVC before
+	// making it available. Otherwise, adopted PVCs are available immediately.
+	//
+	// +optional
+	RunInitJob bool `json:"runInitJob,omitempty"`
+}
+
+// PoolDeletionPolicy is the action to take on a pool's replicas when the pool
+// is deleted.
+//
+// +kubebuilder:validation:Enum=Delete;Orphan;Retain
+type PoolDeletionPolicy string
+
+const (
+	// PoolDeletionPolicyDelete deletes every replica along with the pool.
+	PoolDeletionPolicyDelete PoolDeletionPolicy = "Delete"
+
+	// PoolDeletionPolicyOrphan removes the pool's ownership from each replica
+	// and leaves the PVCs in place. A new pool with a matching selector will
+	// adopt them.
+	PoolDeletionPolicyOrphan PoolDeletionPolicy = "Orphan"
+
+	// PoolDeletionPolicyRetain deletes each replica's PVC but sets the
+	// reclaim policy of its PV to Retain so the underlying storage is kept.
+	PoolDeletionPolicyRetain PoolDeletionPolicy = "Retain"
+)
+
+// PoolProvisioning configures how the pool handles PVCs that remain pending.
+type PoolProvisioning struct {
+	// StalledAfter is the amount of time a PVC may remain pending before the
+	// pool reports that provisioning has stalled. Defaults to 5 minutes.
+	//
+	// +optional
+	StalledAfter *metav1.Duration `json:"stalledAfter,omitempty"`
+
+	// ReplaceAfter is the amount of time a PVC may remain pending before the
+	// pool deletes it and tries again with a new PVC. If not specified,
+	// pending PVCs are never replaced.
+	//
+	// +optional
+	ReplaceAfter *metav1.Duration `json:"replaceAfter,omitempty"`
+}
+
+// PoolHealthCheck configures verification of available replicas in a pool.
+//
+// At least one of Interval or BeforeCheckout must be set.
+type PoolHealthCheck struct {
+	// Job is the job to run against each replica. The replica remains in the
+	// pool only if the job succeeds.
+	Job MountJob `json:"job"`
+
+	// Interval is the amount of time after a replica was last verified that it
+	// should be verified again.
+	//
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// BeforeCheckout requires that a replica be verified after a checkout is
+	// created before the checkout may take it.
+	//
+	// +optional
+	BeforeCheckout bool `json:"beforeCheckout,omitempty"`
+}
+
+// MountJob is a job that has a persistent volume attached to it with a
+// configured name.
+type MountJob struct {
+	// Template is the configuration for the job.
+	Template JobTemplate `json:"template"`
+
+	// PodVolumeName is the name of the pod volume to be added to the
+	// template to access the persistent volume. The volume must either not
+	// exist in the template or must have a persistent volume claim source.
+	//
+	// +optional
+	// +kubebuilder:default="workspace"
+	PodVolumeName string `json:"podVolumeName,omitempty"`
+}
+
+const (
+	// PoolAvailable indicates whether a Pool contains one or more usable
+	// replicas.
+	PoolAvailable = "Available"
+
+	// PoolAvailableReasonNoReplicasRequested is used to indicate that this pool
+	// has no replicas in its spec.
+	PoolAvailableReasonNoReplicasRequested = "NoReplicasRequested"
+
+	// PoolAvailableReasonMinimumReplicasAvailable is used to indicate
+	// successful binding and initialization of one or more PVCs in the pool.
+	PoolAvailableReasonMinimumReplicasAvailable = "MinimumReplicasAvailable"
+
+	// PoolSettlement indicates whether all of the desired replicas for the Pool
+	// are now set up and ready to use.
+	PoolSettlement = "Settlement"
+
+	// PoolSettlementReasonInvalid is used when user-specified configuration
+	// that could not be statically checked is invalid.
+	PoolSettlementReasonInvalid = "Invalid"
+
+	// PoolSettlementReasonInitJobFailed is used when the job used to initialize
+	// the PVC has failed, either temporarily or permanently.
+	PoolSettlementReasonInitJobFailed = "InitJobFailed"
+
+	// PoolSettlementReasonHealthCheckFailed is used when the job used to
+	// verify an available PVC has failed, causing the PVC to be replaced.
+	PoolSettlementReasonHealthCheckFailed = "HealthCheckFailed"
+
+	// PoolSettlementReasonPolicyViolation is used when the pool does not
+	// satisfy one or more of the PoolPolicy objects in the cluster. The pool
+	// will not be scaled up until the violation is resolved.
+	PoolSettlementReasonPolicyViolation = "PolicyViolation"
+
+	// PoolSettlementReasonProvisioningStalled is used when a pending PVC was
+	// replaced because the storage provisioner did not bind it in time.
+	PoolSettlementReasonProvisioningStalled = "ProvisioningStalled"
+
+	// PoolSettlementReasonSettled is used to indicate that the observed
+	// generation matches the object generation and exactly the number of
+	// desired replicas are in place.
+	PoolSettlementReasonSettled = "Settled"
+
+	// PoolProvisioningStalled indicates whether one or more PVCs in the pool
+	// have remained pending for longer than expected.
+	PoolProvisioningStalled = "ProvisioningStalled"
+
+	// PoolProvisioningStalledReasonClaimPending is used when a PVC in the pool
+	// has been pending for longer than the pool's stalled threshold.
+	PoolProvisioningStalledReasonClaimPending = "ClaimPending"
+
+	// PoolProvisioningStalledReasonNotStalled is used when no PVC in the pool
+	// has been pending for longer than the pool's stalled threshold.
+	PoolProvisioningStalledReasonNotStalled = "NotStalled"
+)
+
+// PoolStatus is the runtime state of an existing pool.
+type PoolStatus struct {
+	// ObservedGeneration is the generation of the resource specification that
+	// this status matches.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Replicas are the number of PVCs that currently exist that match this
+	// pool's selector.
+	//
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// AvailableReplicas are the number of PVs from this pool that are ready to
+	// be checked out.
+	//
+	// +optional
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+
+	// TemplateHash is a hash of the PVC template in the current pool spec.
+	// Replicas with a different hash were created from an earlier version of
+	// the template.
+	//
+	// +optional
+	TemplateHash string `json:"templateHash,omitempty"`
+
+	// ReplicaStatuses describe the individual replicas in this pool. Replicas
+	// that are not available are listed first. If the pool has more replicas
+	// than the maximum size of this list, the newest available replicas are
+	// omitted.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=100
+	ReplicaStatuses []PoolReplicaStatus `json:"replicaStatuses,omitempty"`
+
+	// Conditions are the possible observable conditions for this pool.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// PoolReplicaPhase is the lifecycle phase of a single replica in a pool.
+type PoolReplicaPhase string
+
+const (
+	// PoolReplicaPhaseInitializing is used for replicas whose PVC is being
+	// provisioned or whose init job has not yet succeeded.
+	PoolReplicaPhaseInitializing PoolReplicaPhase = "Initializing"
+
+	// PoolReplicaPhaseVerifying is used for replicas whose health check is
+	// running.
+	PoolReplicaPhaseVerifying PoolReplicaPhase = "Verifying"
+
+	// PoolReplicaPhaseAvailable is used for replicas that can be checked out.
+	PoolReplicaPhaseAvailable PoolReplicaPhase = "Available"
+
+	// PoolReplicaPhaseStale is used for replicas that will be deleted and
+	// replaced.
+	PoolReplicaPhaseStale PoolReplicaPhase = "Stale"
+)
+
+// PoolReplicaStatus is the observed state of a single replica in a pool.
+type PoolReplicaStatus struct {
+	// VolumeClaimName is the name of the replica's PVC.
+	VolumeClaimName string `json:"volumeClaimName"`
+
+	// VolumeName is the name of the PV bound to the replica's PVC, if any.
+	//
+	// +optional
+	VolumeName string `json:"volumeName,omitempty"`
+
+	// Phase is the lifecycle phase of the replica.
+	Phase PoolReplicaPhase `json:"phase"`
+
+	// CreationTimestamp is the time the replica's PVC was created.
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+
+	// NodeName is the node the replica's PV is bound to, if its storage is
+	// local to a node.
+	//
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+
+	// Zone is the topology zone of the replica's PV, if known.
+	//
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
+	// TemplateHash is the hash of the pool's PVC template at the time the
+	// replica was created.
+	//
+	// +optional
+	TemplateHash string `json:"templateHash,omitempty"`
+
+	// InitJob is the state of the replica's init job, if it still exists.
+	//
+	// +optional
+	InitJob *PoolReplicaJobStatus `json:"initJob,omitempty"`
+
+	// HealthCheckJob is the state of the replica's health check job, if one is
+	// running or has failed.
+	//
+	// +optional
+	HealthCheckJob *PoolReplicaJobStatus `json:"healthCheckJob,omitempty"`
+}
+
+// PoolReplicaJobState is the state of a job run against a replica.
+type PoolReplicaJobState string
+
+const (
+	PoolReplicaJobStateRunning   PoolReplicaJobState = "Running"
+	PoolReplicaJobStateSucceeded PoolReplicaJobState = "Succeeded"
+	PoolReplicaJobStateFailed    PoolReplicaJobState = "Failed"
+)
+
+// PoolReplicaJobStatus is the observed state of a job run against a replica.
+type PoolReplicaJobStatus struct {
+	// Name is the name of the job.
+	Name string `json:"name"`
+
+	// State is the state of the job.
+	State PoolReplicaJobState `json:"state"`
+
+	// Reason is the reason the job failed, if it failed.
+	//
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human-readable explanation of the job's failure, if it
+	// failed.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// PoolList enumerates many Pool resources.
+//
+// +kubebuilder:object:root=true
+type PoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Pool `json:"items"`
+}
+
+// PoolReference is a reference to a Pool or ClusterPool.
+type PoolReference struct {
+	// Kind is the kind of pool to reference, either Pool or ClusterPool.
+	// Defaults to Pool.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=Pool;ClusterPool
+	Kind string `json:"kind,omitempty"`
+
+	// Namespace identifies the Kubernetes namespace of the pool. It must not
+	// be set for a ClusterPool.
+	//
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name identifies the name of the pool within the namespace.
+	Name string `json:"name"`
+}
@@ -0,0 +1,99 @@
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PoolPolicyKind is the public Kubernetes group-version-kind triple for the
+// PoolPolicy type.
+var PoolPolicyKind = SchemeGroupVersion.WithKind("PoolPolicy")
+
+// PoolPolicy restricts the configuration of every Pool in the cluster.
+//
+// When more than one policy exists, a pool must satisfy all of them.
+//
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Max Replicas",type="integer",JSONPath=".spec.maxReplicas"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PoolPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              PoolPolicySpec `json:"spec"`
+}
+
+// PoolPolicySpec is the set of restrictions a policy places on pools.
+type PoolPolicySpec struct {
+	// MaxReplicas is the largest number of replicas any single pool may
+	// request.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+
+	// MaxStoragePerReplica is the largest storage request any single replica
+	// may make.
+	//
+	// +optional
+	MaxStoragePerReplica *resource.Quantity `json:"maxStoragePerReplica,omitempty"`
+
+	// MaxStoragePerNamespace is the largest total storage that all of the
+	// pools in a namespace may request together, computed as the sum of each
+	// pool's replicas multiplied by its storage request.
+	//
+	// +optional
+	MaxStoragePerNamespace *resource.Quantity `json:"maxStoragePerNamespace,omitempty"`
+
+	// AllowedStorageClassNames are the storage classes that pools may use. A
+	// pool that does not specify a storage class uses the cluster default and
+	// is only permitted if the empty string is in this list.
+	//
+	// If not specified, any storage class is permitted.
+	//
+	// +optional
+	AllowedStorageClassNames []string `json:"allowedStorageClassNames,omitempty"`
+
+	// AllowedImages are the container images that init and health check jobs
+	// may use. Each entry is either an exact image reference or a prefix
+	// followed by "*".
+	//
+	// If not specified, any image is permitted.
+	//
+	// +optional
+	AllowedImages []string `json:"allowedImages,omitempty"`
+
+	// MountJob overrides the built-in limits placed on jobs that mount a
+	// pool's volumes.
+	//
+	// +optional
+	MountJob *PoolPolicyMountJob `json:"mountJob,omitempty"`
+}
+
+// PoolPolicyMountJob configures the limits placed on jobs that mount a pool's
+// volumes.
+type PoolPolicyMountJob struct {
+	// MaxActiveDeadlineSeconds is the largest active deadline a job may
+	// request. Jobs that do not specify a deadline are given this value.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxActiveDeadlineSeconds *int64 `json:"maxActiveDeadlineSeconds,omitempty"`
+
+	// MaxBackoffLimit is the largest backoff limit a job may request.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxBackoffLimit *int32 `json:"maxBackoffLimit,omitempty"`
+}
+
+// PoolPolicyList enumerates many PoolPolicy resources.
+//
+// +kubebuilder:object:root=true
+type PoolPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PoolPolicy `json:"items"`
+}
@@ -0,0 +1,207 @@
+// Package checkout provides a client library for acquiring PVCs from pools
+// without hand-writing a watch loop against the Checkout API.
+package checkout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	"github.com/puppetlabs/pvpool/pkg/client/clientset/versioned"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+// DefaultCleanupTimeout is the amount of time Acquire waits for a checkout to
+// be deleted after it fails to acquire a PVC.
+const DefaultCleanupTimeout = 30 * time.Second
+
+// AcquireOptions configure the checkout created by Acquire.
+type AcquireOptions struct {
+	// Namespace is the namespace to create the checkout in. If not specified,
+	// the namespace of the pool reference is used.
+	Namespace string
+
+	// Name is the name of the checkout to create. If not specified, a name is
+	// generated from the name of the pool.
+	Name string
+
+	// ClaimName is the name of the PVC to check out. If not specified, the
+	// name of the checkout is used.
+	ClaimName string
+
+	// AccessModes are the access modes to request for the PVC. If not
+	// specified, the controller defaults to ReadWriteOnce.
+	AccessModes []corev1.PersistentVolumeAccessMode
+
+	// Labels and Annotations are copied to the checkout.
+	Labels      map[string]string
+	Annotations map[string]string
+
+	// CleanupTimeout is the amount of time to wait for the checkout to be
+	// deleted if it cannot be acquired. Defaults to DefaultCleanupTimeout.
+	CleanupTimeout time.Duration
+}
+
+// Acquire creates a checkout for the given pool and blocks until the
+// controller reports that its PVC is ready to use.
+//
+// If the controller reports that the checkout is invalid or conflicts with an
+// existing PVC, Acquire returns an *InvalidError or *ConflictError
+// respectively. If the context is canceled or any other error occurs while
+// waiting, the checkout is deleted before Acquire returns.
+func Acquire(ctx context.Context, cs versioned.Interface, poolRef pvpoolv1alpha1.PoolReference, opts AcquireOptions) (*pvpoolv1alpha1.Checkout, error) {
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = poolRef.Namespace
+	}
+	if namespace == "" {
+		return nil, fmt.Errorf("checkout: namespace is required when the pool reference does not specify one")
+	}
+
+	checkout := &pvpoolv1alpha1.Checkout{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        opts.Name,
+			Labels:      opts.Labels,
+			Annotations: opts.Annotations,
+		},
+		Spec: pvpoolv1alpha1.CheckoutSpec{
+			PoolRef:     poolRef,
+			ClaimName:   opts.ClaimName,
+			AccessModes: opts.AccessModes,
+		},
+	}
+	if opts.Name == "" {
+		checkout.SetGenerateName(poolRef.Name + "-")
+	}
+
+	checkout, err := cs.PvpoolV1alpha1().Checkouts(namespace).Create(ctx, checkout, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("checkout: failed to create checkout: %w", err)
+	}
+
+	acquired, err := wait(ctx, cs, checkout)
+	if err != nil {
+		cleanupTimeout := opts.CleanupTimeout
+		if cleanupTimeout <= 0 {
+			cleanupTimeout = DefaultCleanupTimeout
+		}
+
+		// The caller's context may already be done, so we need a fresh one to
+		// clean up.
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+		defer cancel()
+
+		if rerr := Release(cleanupCtx, cs, checkout); rerr != nil {
+			return nil, fmt.Errorf("%w (additionally, failed to clean up: %v)", err, rerr)
+		}
+
+		return nil, err
+	}
+
+	return acquired, nil
+}
+
+// Release deletes a checkout, returning its PVC to the cluster. It is not an
+// error to release a checkout that no longer exists.
+func Release(ctx context.Context, cs versioned.Interface, checkout *pvpoolv1alpha1.Checkout) error {
+	err := cs.PvpoolV1alpha1().Checkouts(checkout.GetNamespace()).Delete(ctx, checkout.GetName(), metav1.DeleteOptions{
+		Preconditions: metav1.NewUIDPreconditions(string(checkout.GetUID())),
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("checkout: failed to delete checkout %s/%s: %w", checkout.GetNamespace(), checkout.GetName(), err)
+	}
+
+	return nil
+}
+
+// ClaimName returns the name of the PVC for an acquired checkout.
+func ClaimName(checkout *pvpoolv1alpha1.Checkout) string {
+	return checkout.Status.VolumeClaimRef.Name
+}
+
+func wait(ctx context.Context, cs versioned.Interface, checkout *pvpoolv1alpha1.Checkout) (*pvpoolv1alpha1.Checkout, error) {
+	selector := fields.OneTermEqualSelector("metadata.name", checkout.GetName()).String()
+	checkouts := cs.PvpoolV1alpha1().Checkouts(checkout.GetNamespace())
+
+	lw := &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			opts.FieldSelector = selector
+			return checkouts.List(ctx, opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			opts.FieldSelector = selector
+			return checkouts.Watch(ctx, opts)
+		},
+	}
+
+	var last *pvpoolv1alpha1.Checkout
+	ev, err := watchtools.UntilWithSync(ctx, lw, &pvpoolv1alpha1.Checkout{}, nil, func(ev watch.Event) (bool, error) {
+		switch ev.Type {
+		case watch.Deleted:
+			return false, &DeletedError{Namespace: checkout.GetNamespace(), Name: checkout.GetName()}
+		case watch.Added, watch.Modified:
+		default:
+			return false, nil
+		}
+
+		obj, ok := ev.Object.(*pvpoolv1alpha1.Checkout)
+		if !ok || obj.GetUID() != checkout.GetUID() {
+			return false, nil
+		}
+		last = obj
+
+		for _, cond := range obj.Status.Conditions {
+			if cond.Type != pvpoolv1alpha1.CheckoutAcquired {
+				continue
+			}
+
+			if cond.Status == corev1.ConditionTrue {
+				return ClaimName(obj) != "", nil
+			}
+
+			return false, conditionError(obj, cond)
+		}
+
+		return false, nil
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("checkout %s/%s was not acquired%s: %w", checkout.GetNamespace(), checkout.GetName(), lastStatus(last), ctx.Err())
+		}
+
+		return nil, err
+	}
+
+	return ev.Object.(*pvpoolv1alpha1.Checkout), nil
+}
+
+func lastStatus(checkout *pvpoolv1alpha1.Checkout) string {
+	if checkout == nil {
+		return ""
+	}
+
+	for _, cond := range checkout.Status.Conditions {
+		if cond.Type == pvpoolv1alpha1.CheckoutAcquired && cond.Reason != "" {
+			return fmt.Sprintf(" (last reason: %s)", cond.Reason)
+		}
+	}
+
+	return ""
+}
+
+func claimName(checkout *pvpoolv1alpha1.Checkout) string {
+	if checkout.Spec.ClaimName != "" {
+		return checkout.Spec.ClaimName
+	}
+
+	return checkout.GetName()
+}
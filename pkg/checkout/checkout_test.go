@@ -0,0 +1,166 @@
+package checkout_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	"github.com/puppetlabs/pvpool/pkg/checkout"
+	"github.com/puppetlabs/pvpool/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	testNamespace = "test"
+	testName      = "test-checkout"
+)
+
+var testPoolRef = pvpoolv1alpha1.PoolReference{Namespace: testNamespace, Name: "test-pool"}
+
+// waitCreated waits for Acquire to create the checkout.
+func waitCreated(t *testing.T, cs *fake.Clientset) *pvpoolv1alpha1.Checkout {
+	var co *pvpoolv1alpha1.Checkout
+	require.NoError(t, wait.PollImmediate(10*time.Millisecond, 10*time.Second, func() (bool, error) {
+		var err error
+		co, err = cs.PvpoolV1alpha1().Checkouts(testNamespace).Get(context.Background(), testName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return err == nil, err
+	}))
+	return co
+}
+
+func setAcquired(t *testing.T, cs *fake.Clientset, co *pvpoolv1alpha1.Checkout, status corev1.ConditionStatus, reason, claimName string) {
+	co.Status.VolumeClaimRef.Name = claimName
+	co.Status.Conditions = []pvpoolv1alpha1.CheckoutCondition{
+		{
+			Condition: pvpoolv1alpha1.Condition{
+				Status:  status,
+				Reason:  reason,
+				Message: "test",
+			},
+			Type: pvpoolv1alpha1.CheckoutAcquired,
+		},
+	}
+
+	_, err := cs.PvpoolV1alpha1().Checkouts(testNamespace).UpdateStatus(context.Background(), co, metav1.UpdateOptions{})
+	require.NoError(t, err)
+}
+
+func requireDeleted(t *testing.T, cs *fake.Clientset) {
+	_, err := cs.PvpoolV1alpha1().Checkouts(testNamespace).Get(context.Background(), testName, metav1.GetOptions{})
+	require.True(t, apierrors.IsNotFound(err), "expected checkout to be deleted, got %v", err)
+}
+
+type acquireResult struct {
+	Checkout *pvpoolv1alpha1.Checkout
+	Err      error
+}
+
+func startAcquire(ctx context.Context, cs *fake.Clientset) <-chan acquireResult {
+	ch := make(chan acquireResult, 1)
+	go func() {
+		co, err := checkout.Acquire(ctx, cs, testPoolRef, checkout.AcquireOptions{Name: testName})
+		ch <- acquireResult{Checkout: co, Err: err}
+	}()
+	return ch
+}
+
+func requireResult(t *testing.T, ch <-chan acquireResult) acquireResult {
+	select {
+	case r := <-ch:
+		return r
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "timed out waiting for Acquire to return")
+		return acquireResult{}
+	}
+}
+
+func TestAcquire(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cs := fake.NewSimpleClientset()
+	ch := startAcquire(ctx, cs)
+
+	co := waitCreated(t, cs)
+	assert.Equal(t, testPoolRef, co.Spec.PoolRef)
+
+	// The checkout is not acquired until its condition is true.
+	setAcquired(t, cs, co, corev1.ConditionUnknown, pvpoolv1alpha1.CheckoutAcquiredReasonNotAvailable, "")
+	setAcquired(t, cs, co, corev1.ConditionTrue, pvpoolv1alpha1.CheckoutAcquiredReasonCheckedOut, testName)
+
+	r := requireResult(t, ch)
+	require.NoError(t, r.Err)
+	assert.Equal(t, testName, checkout.ClaimName(r.Checkout))
+}
+
+func TestAcquireInvalid(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cs := fake.NewSimpleClientset()
+	ch := startAcquire(ctx, cs)
+
+	setAcquired(t, cs, waitCreated(t, cs), corev1.ConditionFalse, pvpoolv1alpha1.CheckoutAcquiredReasonInvalid, "")
+
+	r := requireResult(t, ch)
+	var ierr *checkout.InvalidError
+	require.True(t, errors.As(r.Err, &ierr), "unexpected error: %v", r.Err)
+	assert.Equal(t, testName, ierr.Name)
+	requireDeleted(t, cs)
+}
+
+func TestAcquireConflict(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cs := fake.NewSimpleClientset()
+	ch := startAcquire(ctx, cs)
+
+	setAcquired(t, cs, waitCreated(t, cs), corev1.ConditionUnknown, pvpoolv1alpha1.CheckoutAcquiredReasonConflict, "")
+
+	r := requireResult(t, ch)
+	var cerr *checkout.ConflictError
+	require.True(t, errors.As(r.Err, &cerr), "unexpected error: %v", r.Err)
+	assert.Equal(t, testName, cerr.ClaimName)
+	requireDeleted(t, cs)
+}
+
+func TestAcquireDeleted(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cs := fake.NewSimpleClientset()
+	ch := startAcquire(ctx, cs)
+
+	waitCreated(t, cs)
+	require.NoError(t, cs.PvpoolV1alpha1().Checkouts(testNamespace).Delete(ctx, testName, metav1.DeleteOptions{}))
+
+	r := requireResult(t, ch)
+	var derr *checkout.DeletedError
+	require.True(t, errors.As(r.Err, &derr), "unexpected error: %v", r.Err)
+}
+
+func TestAcquireContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cs := fake.NewSimpleClientset()
+	ch := startAcquire(ctx, cs)
+
+	setAcquired(t, cs, waitCreated(t, cs), corev1.ConditionUnknown, pvpoolv1alpha1.CheckoutAcquiredReasonNotAvailable, "")
+	cancel()
+
+	r := requireResult(t, ch)
+	require.True(t, errors.Is(r.Err, context.Canceled), "unexpected error: %v", r.Err)
+	requireDeleted(t, cs)
+}
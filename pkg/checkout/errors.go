@@ -0,0 +1,63 @@
+package checkout
+
+import (
+	"fmt"
+
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+)
+
+// InvalidError is returned by Acquire when the controller reports that the
+// PVC template for a checkout is invalid.
+type InvalidError struct {
+	Namespace string
+	Name      string
+	Message   string
+}
+
+func (e *InvalidError) Error() string {
+	return fmt.Sprintf("checkout %s/%s is invalid: %s", e.Namespace, e.Name, e.Message)
+}
+
+// ConflictError is returned by Acquire when the controller reports that a PVC
+// not owned by the checkout already exists with the requested claim name.
+type ConflictError struct {
+	Namespace string
+	Name      string
+	ClaimName string
+	Message   string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("checkout %s/%s conflicts with existing PVC %q: %s", e.Namespace, e.Name, e.ClaimName, e.Message)
+}
+
+// DeletedError is returned by Acquire when the checkout is deleted by another
+// party before it is acquired.
+type DeletedError struct {
+	Namespace string
+	Name      string
+}
+
+func (e *DeletedError) Error() string {
+	return fmt.Sprintf("checkout %s/%s was deleted before it was acquired", e.Namespace, e.Name)
+}
+
+func conditionError(checkout *pvpoolv1alpha1.Checkout, cond pvpoolv1alpha1.CheckoutCondition) error {
+	switch cond.Reason {
+	case pvpoolv1alpha1.CheckoutAcquiredReasonInvalid:
+		return &InvalidError{
+			Namespace: checkout.GetNamespace(),
+			Name:      checkout.GetName(),
+			Message:   cond.Message,
+		}
+	case pvpoolv1alpha1.CheckoutAcquiredReasonConflict:
+		return &ConflictError{
+			Namespace: checkout.GetNamespace(),
+			Name:      checkout.GetName(),
+			ClaimName: claimName(checkout),
+			Message:   cond.Message,
+		}
+	default:
+		return nil
+	}
+}
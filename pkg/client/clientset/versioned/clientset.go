@@ -0,0 +1,95 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package versioned
+
+import (
+	"fmt"
+
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/client/clientset/versioned/typed/pvpool.puppet.com/v1alpha1"
+	pvpoolv1beta1 "github.com/puppetlabs/pvpool/pkg/client/clientset/versioned/typed/pvpool.puppet.com/v1beta1"
+	discovery "k8s.io/client-go/discovery"
+	rest "k8s.io/client-go/rest"
+	flowcontrol "k8s.io/client-go/util/flowcontrol"
+)
+
+type Interface interface {
+	Discovery() discovery.DiscoveryInterface
+	PvpoolV1alpha1() pvpoolv1alpha1.PvpoolV1alpha1Interface
+	PvpoolV1beta1() pvpoolv1beta1.PvpoolV1beta1Interface
+}
+
+// Clientset contains the clients for groups. Each group has exactly one
+// version included in a Clientset.
+type Clientset struct {
+	*discovery.DiscoveryClient
+	pvpoolV1alpha1 *pvpoolv1alpha1.PvpoolV1alpha1Client
+	pvpoolV1beta1  *pvpoolv1beta1.PvpoolV1beta1Client
+}
+
+// PvpoolV1alpha1 retrieves the PvpoolV1alpha1Client
+func (c *Clientset) PvpoolV1alpha1() pvpoolv1alpha1.PvpoolV1alpha1Interface {
+	return c.pvpoolV1alpha1
+}
+
+// PvpoolV1beta1 retrieves the PvpoolV1beta1Client
+func (c *Clientset) PvpoolV1beta1() pvpoolv1beta1.PvpoolV1beta1Interface {
+	return c.pvpoolV1beta1
+}
+
+// Discovery retrieves the DiscoveryClient
+func (c *Clientset) Discovery() discovery.DiscoveryInterface {
+	if c == nil {
+		return nil
+	}
+	return c.DiscoveryClient
+}
+
+// NewForConfig creates a new Clientset for the given config.
+// If config's RateLimiter is not set and QPS and Burst are acceptable,
+// NewForConfig will generate a rate-limiter in configShallowCopy.
+func NewForConfig(c *rest.Config) (*Clientset, error) {
+	configShallowCopy := *c
+	if configShallowCopy.RateLimiter == nil && configShallowCopy.QPS > 0 {
+		if configShallowCopy.Burst <= 0 {
+			return nil, fmt.Errorf("burst is required to be greater than 0 when RateLimiter is not set and QPS is set to greater than 0")
+		}
+		configShallowCopy.RateLimiter = flowcontrol.NewTokenBucketRateLimiter(configShallowCopy.QPS, configShallowCopy.Burst)
+	}
+	var cs Clientset
+	var err error
+	cs.pvpoolV1alpha1, err = pvpoolv1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.pvpoolV1beta1, err = pvpoolv1beta1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	return &cs, nil
+}
+
+// NewForConfigOrDie creates a new Clientset for the given config and
+// panics if there is an error in the config.
+func NewForConfigOrDie(c *rest.Config) *Clientset {
+	var cs Clientset
+	cs.pvpoolV1alpha1 = pvpoolv1alpha1.NewForConfigOrDie(c)
+	cs.pvpoolV1beta1 = pvpoolv1beta1.NewForConfigOrDie(c)
+
+	cs.DiscoveryClient = discovery.NewDiscoveryClientForConfigOrDie(c)
+	return &cs
+}
+
+// New creates a new Clientset for the given RESTClient.
+func New(c rest.Interface) *Clientset {
+	var cs Clientset
+	cs.pvpoolV1alpha1 = pvpoolv1alpha1.New(c)
+	cs.pvpoolV1beta1 = pvpoolv1beta1.New(c)
+
+	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
+	return &cs
+}
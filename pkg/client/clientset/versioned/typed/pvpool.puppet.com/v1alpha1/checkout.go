@@ -0,0 +1,179 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	scheme "github.com/puppetlabs/pvpool/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// CheckoutsGetter has a method to return a CheckoutInterface.
+// A group's client should implement this interface.
+type CheckoutsGetter interface {
+	Checkouts(namespace string) CheckoutInterface
+}
+
+// CheckoutInterface has methods to work with Checkout resources.
+type CheckoutInterface interface {
+	Create(ctx context.Context, checkout *v1alpha1.Checkout, opts v1.CreateOptions) (*v1alpha1.Checkout, error)
+	Update(ctx context.Context, checkout *v1alpha1.Checkout, opts v1.UpdateOptions) (*v1alpha1.Checkout, error)
+	UpdateStatus(ctx context.Context, checkout *v1alpha1.Checkout, opts v1.UpdateOptions) (*v1alpha1.Checkout, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.Checkout, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.CheckoutList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Checkout, err error)
+	CheckoutExpansion
+}
+
+// checkouts implements CheckoutInterface
+type checkouts struct {
+	client rest.Interface
+	ns     string
+}
+
+// newCheckouts returns a Checkouts
+func newCheckouts(c *PvpoolV1alpha1Client, namespace string) *checkouts {
+	return &checkouts{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the checkout, and returns the corresponding checkout object, and an error if there is any.
+func (c *checkouts) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.Checkout, err error) {
+	result = &v1alpha1.Checkout{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("checkouts").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of Checkouts that match those selectors.
+func (c *checkouts) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.CheckoutList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.CheckoutList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("checkouts").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested checkouts.
+func (c *checkouts) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("checkouts").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a checkout and creates it.  Returns the server's representation of the checkout, and an error, if there is any.
+func (c *checkouts) Create(ctx context.Context, checkout *v1alpha1.Checkout, opts v1.CreateOptions) (result *v1alpha1.Checkout, err error) {
+	result = &v1alpha1.Checkout{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("checkouts").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(checkout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a checkout and updates it. Returns the server's representation of the checkout, and an error, if there is any.
+func (c *checkouts) Update(ctx context.Context, checkout *v1alpha1.Checkout, opts v1.UpdateOptions) (result *v1alpha1.Checkout, err error) {
+	result = &v1alpha1.Checkout{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("checkouts").
+		Name(checkout.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(checkout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *checkouts) UpdateStatus(ctx context.Context, checkout *v1alpha1.Checkout, opts v1.UpdateOptions) (result *v1alpha1.Checkout, err error) {
+	result = &v1alpha1.Checkout{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("checkouts").
+		Name(checkout.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(checkout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the checkout and deletes it. Returns an error if one occurs.
+func (c *checkouts) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("checkouts").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *checkouts) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("checkouts").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched checkout.
+func (c *checkouts) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Checkout, err error) {
+	result = &v1alpha1.Checkout{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("checkouts").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,126 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeCheckouts implements CheckoutInterface
+type FakeCheckouts struct {
+	Fake *FakePvpoolV1alpha1
+	ns   string
+}
+
+var checkoutsResource = schema.GroupVersionResource{Group: "pvpool.puppet.com", Version: "v1alpha1", Resource: "checkouts"}
+
+var checkoutsKind = schema.GroupVersionKind{Group: "pvpool.puppet.com", Version: "v1alpha1", Kind: "Checkout"}
+
+// Get takes name of the checkout, and returns the corresponding checkout object, and an error if there is any.
+func (c *FakeCheckouts) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.Checkout, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(checkoutsResource, c.ns, name), &v1alpha1.Checkout{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Checkout), err
+}
+
+// List takes label and field selectors, and returns the list of Checkouts that match those selectors.
+func (c *FakeCheckouts) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.CheckoutList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(checkoutsResource, checkoutsKind, c.ns, opts), &v1alpha1.CheckoutList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.CheckoutList{ListMeta: obj.(*v1alpha1.CheckoutList).ListMeta}
+	for _, item := range obj.(*v1alpha1.CheckoutList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested checkouts.
+func (c *FakeCheckouts) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(checkoutsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a checkout and creates it.  Returns the server's representation of the checkout, and an error, if there is any.
+func (c *FakeCheckouts) Create(ctx context.Context, checkout *v1alpha1.Checkout, opts v1.CreateOptions) (result *v1alpha1.Checkout, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(checkoutsResource, c.ns, checkout), &v1alpha1.Checkout{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Checkout), err
+}
+
+// Update takes the representation of a checkout and updates it. Returns the server's representation of the checkout, and an error, if there is any.
+func (c *FakeCheckouts) Update(ctx context.Context, checkout *v1alpha1.Checkout, opts v1.UpdateOptions) (result *v1alpha1.Checkout, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(checkoutsResource, c.ns, checkout), &v1alpha1.Checkout{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Checkout), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeCheckouts) UpdateStatus(ctx context.Context, checkout *v1alpha1.Checkout, opts v1.UpdateOptions) (*v1alpha1.Checkout, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(checkoutsResource, "status", c.ns, checkout), &v1alpha1.Checkout{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Checkout), err
+}
+
+// Delete takes name of the checkout and deletes it. Returns an error if one occurs.
+func (c *FakeCheckouts) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(checkoutsResource, c.ns, name), &v1alpha1.Checkout{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeCheckouts) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(checkoutsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.CheckoutList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched checkout.
+func (c *FakeCheckouts) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Checkout, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(checkoutsResource, c.ns, name, pt, data, subresources...), &v1alpha1.Checkout{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Checkout), err
+}
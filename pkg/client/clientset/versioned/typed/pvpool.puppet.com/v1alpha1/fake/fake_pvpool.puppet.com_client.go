@@ -0,0 +1,40 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/puppetlabs/pvpool/pkg/client/clientset/versioned/typed/pvpool.puppet.com/v1alpha1"
+	rest "k8s.io/client-go/rest"
+	testing "k8s.io/client-go/testing"
+)
+
+type FakePvpoolV1alpha1 struct {
+	*testing.Fake
+}
+
+func (c *FakePvpoolV1alpha1) Checkouts(namespace string) v1alpha1.CheckoutInterface {
+	return &FakeCheckouts{c, namespace}
+}
+
+func (c *FakePvpoolV1alpha1) ClusterPools() v1alpha1.ClusterPoolInterface {
+	return &FakeClusterPools{c}
+}
+
+func (c *FakePvpoolV1alpha1) Pools(namespace string) v1alpha1.PoolInterface {
+	return &FakePools{c, namespace}
+}
+
+func (c *FakePvpoolV1alpha1) PoolPolicies() v1alpha1.PoolPolicyInterface {
+	return &FakePoolPolicies{c}
+}
+
+func (c *FakePvpoolV1alpha1) PoolTemplates() v1alpha1.PoolTemplateInterface {
+	return &FakePoolTemplates{c}
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *FakePvpoolV1alpha1) RESTClient() rest.Interface {
+	var ret *rest.RESTClient
+	return ret
+}
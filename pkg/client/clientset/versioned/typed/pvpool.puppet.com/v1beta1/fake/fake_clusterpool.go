@@ -0,0 +1,117 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1beta1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterPools implements ClusterPoolInterface
+type FakeClusterPools struct {
+	Fake *FakePvpoolV1beta1
+}
+
+var clusterpoolsResource = schema.GroupVersionResource{Group: "pvpool.puppet.com", Version: "v1beta1", Resource: "clusterpools"}
+
+var clusterpoolsKind = schema.GroupVersionKind{Group: "pvpool.puppet.com", Version: "v1beta1", Kind: "ClusterPool"}
+
+// Get takes name of the clusterPool, and returns the corresponding clusterPool object, and an error if there is any.
+func (c *FakeClusterPools) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.ClusterPool, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(clusterpoolsResource, name), &v1beta1.ClusterPool{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ClusterPool), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterPools that match those selectors.
+func (c *FakeClusterPools) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.ClusterPoolList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(clusterpoolsResource, clusterpoolsKind, opts), &v1beta1.ClusterPoolList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.ClusterPoolList{ListMeta: obj.(*v1beta1.ClusterPoolList).ListMeta}
+	for _, item := range obj.(*v1beta1.ClusterPoolList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterPools.
+func (c *FakeClusterPools) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(clusterpoolsResource, opts))
+}
+
+// Create takes the representation of a clusterPool and creates it.  Returns the server's representation of the clusterPool, and an error, if there is any.
+func (c *FakeClusterPools) Create(ctx context.Context, clusterPool *v1beta1.ClusterPool, opts v1.CreateOptions) (result *v1beta1.ClusterPool, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(clusterpoolsResource, clusterPool), &v1beta1.ClusterPool{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ClusterPool), err
+}
+
+// Update takes the representation of a clusterPool and updates it. Returns the server's representation of the clusterPool, and an error, if there is any.
+func (c *FakeClusterPools) Update(ctx context.Context, clusterPool *v1beta1.ClusterPool, opts v1.UpdateOptions) (result *v1beta1.ClusterPool, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(clusterpoolsResource, clusterPool), &v1beta1.ClusterPool{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ClusterPool), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeClusterPools) UpdateStatus(ctx context.Context, clusterPool *v1beta1.ClusterPool, opts v1.UpdateOptions) (*v1beta1.ClusterPool, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(clusterpoolsResource, "status", clusterPool), &v1beta1.ClusterPool{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ClusterPool), err
+}
+
+// Delete takes name of the clusterPool and deletes it. Returns an error if one occurs.
+func (c *FakeClusterPools) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(clusterpoolsResource, name), &v1beta1.ClusterPool{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterPools) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(clusterpoolsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.ClusterPoolList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterPool.
+func (c *FakeClusterPools) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.ClusterPool, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterpoolsResource, name, pt, data, subresources...), &v1beta1.ClusterPool{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ClusterPool), err
+}
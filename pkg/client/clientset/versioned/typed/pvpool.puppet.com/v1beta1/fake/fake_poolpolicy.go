@@ -0,0 +1,106 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1beta1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakePoolPolicies implements PoolPolicyInterface
+type FakePoolPolicies struct {
+	Fake *FakePvpoolV1beta1
+}
+
+var poolpoliciesResource = schema.GroupVersionResource{Group: "pvpool.puppet.com", Version: "v1beta1", Resource: "poolpolicies"}
+
+var poolpoliciesKind = schema.GroupVersionKind{Group: "pvpool.puppet.com", Version: "v1beta1", Kind: "PoolPolicy"}
+
+// Get takes name of the poolPolicy, and returns the corresponding poolPolicy object, and an error if there is any.
+func (c *FakePoolPolicies) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.PoolPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(poolpoliciesResource, name), &v1beta1.PoolPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.PoolPolicy), err
+}
+
+// List takes label and field selectors, and returns the list of PoolPolicies that match those selectors.
+func (c *FakePoolPolicies) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.PoolPolicyList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(poolpoliciesResource, poolpoliciesKind, opts), &v1beta1.PoolPolicyList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.PoolPolicyList{ListMeta: obj.(*v1beta1.PoolPolicyList).ListMeta}
+	for _, item := range obj.(*v1beta1.PoolPolicyList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested poolPolicies.
+func (c *FakePoolPolicies) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(poolpoliciesResource, opts))
+}
+
+// Create takes the representation of a poolPolicy and creates it.  Returns the server's representation of the poolPolicy, and an error, if there is any.
+func (c *FakePoolPolicies) Create(ctx context.Context, poolPolicy *v1beta1.PoolPolicy, opts v1.CreateOptions) (result *v1beta1.PoolPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(poolpoliciesResource, poolPolicy), &v1beta1.PoolPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.PoolPolicy), err
+}
+
+// Update takes the representation of a poolPolicy and updates it. Returns the server's representation of the poolPolicy, and an error, if there is any.
+func (c *FakePoolPolicies) Update(ctx context.Context, poolPolicy *v1beta1.PoolPolicy, opts v1.UpdateOptions) (result *v1beta1.PoolPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(poolpoliciesResource, poolPolicy), &v1beta1.PoolPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.PoolPolicy), err
+}
+
+// Delete takes name of the poolPolicy and deletes it. Returns an error if one occurs.
+func (c *FakePoolPolicies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(poolpoliciesResource, name), &v1beta1.PoolPolicy{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakePoolPolicies) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(poolpoliciesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.PoolPolicyList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched poolPolicy.
+func (c *FakePoolPolicies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.PoolPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(poolpoliciesResource, name, pt, data, subresources...), &v1beta1.PoolPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.PoolPolicy), err
+}
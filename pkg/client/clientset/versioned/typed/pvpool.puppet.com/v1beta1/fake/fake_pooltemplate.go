@@ -0,0 +1,106 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1beta1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakePoolTemplates implements PoolTemplateInterface
+type FakePoolTemplates struct {
+	Fake *FakePvpoolV1beta1
+}
+
+var pooltemplatesResource = schema.GroupVersionResource{Group: "pvpool.puppet.com", Version: "v1beta1", Resource: "pooltemplates"}
+
+var pooltemplatesKind = schema.GroupVersionKind{Group: "pvpool.puppet.com", Version: "v1beta1", Kind: "PoolTemplate"}
+
+// Get takes name of the poolTemplate, and returns the corresponding poolTemplate object, and an error if there is any.
+func (c *FakePoolTemplates) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.PoolTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(pooltemplatesResource, name), &v1beta1.PoolTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.PoolTemplate), err
+}
+
+// List takes label and field selectors, and returns the list of PoolTemplates that match those selectors.
+func (c *FakePoolTemplates) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.PoolTemplateList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(pooltemplatesResource, pooltemplatesKind, opts), &v1beta1.PoolTemplateList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.PoolTemplateList{ListMeta: obj.(*v1beta1.PoolTemplateList).ListMeta}
+	for _, item := range obj.(*v1beta1.PoolTemplateList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested poolTemplates.
+func (c *FakePoolTemplates) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(pooltemplatesResource, opts))
+}
+
+// Create takes the representation of a poolTemplate and creates it.  Returns the server's representation of the poolTemplate, and an error, if there is any.
+func (c *FakePoolTemplates) Create(ctx context.Context, poolTemplate *v1beta1.PoolTemplate, opts v1.CreateOptions) (result *v1beta1.PoolTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(pooltemplatesResource, poolTemplate), &v1beta1.PoolTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.PoolTemplate), err
+}
+
+// Update takes the representation of a poolTemplate and updates it. Returns the server's representation of the poolTemplate, and an error, if there is any.
+func (c *FakePoolTemplates) Update(ctx context.Context, poolTemplate *v1beta1.PoolTemplate, opts v1.UpdateOptions) (result *v1beta1.PoolTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(pooltemplatesResource, poolTemplate), &v1beta1.PoolTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.PoolTemplate), err
+}
+
+// Delete takes name of the poolTemplate and deletes it. Returns an error if one occurs.
+func (c *FakePoolTemplates) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(pooltemplatesResource, name), &v1beta1.PoolTemplate{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakePoolTemplates) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(pooltemplatesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.PoolTemplateList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched poolTemplate.
+func (c *FakePoolTemplates) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.PoolTemplate, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(pooltemplatesResource, name, pt, data, subresources...), &v1beta1.PoolTemplate{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.PoolTemplate), err
+}
@@ -0,0 +1,40 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1beta1 "github.com/puppetlabs/pvpool/pkg/client/clientset/versioned/typed/pvpool.puppet.com/v1beta1"
+	rest "k8s.io/client-go/rest"
+	testing "k8s.io/client-go/testing"
+)
+
+type FakePvpoolV1beta1 struct {
+	*testing.Fake
+}
+
+func (c *FakePvpoolV1beta1) Checkouts(namespace string) v1beta1.CheckoutInterface {
+	return &FakeCheckouts{c, namespace}
+}
+
+func (c *FakePvpoolV1beta1) ClusterPools() v1beta1.ClusterPoolInterface {
+	return &FakeClusterPools{c}
+}
+
+func (c *FakePvpoolV1beta1) Pools(namespace string) v1beta1.PoolInterface {
+	return &FakePools{c, namespace}
+}
+
+func (c *FakePvpoolV1beta1) PoolPolicies() v1beta1.PoolPolicyInterface {
+	return &FakePoolPolicies{c}
+}
+
+func (c *FakePvpoolV1beta1) PoolTemplates() v1beta1.PoolTemplateInterface {
+	return &FakePoolTemplates{c}
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *FakePvpoolV1beta1) RESTClient() rest.Interface {
+	var ret *rest.RESTClient
+	return ret
+}
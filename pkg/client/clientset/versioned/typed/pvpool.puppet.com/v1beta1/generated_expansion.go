@@ -0,0 +1,13 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+type CheckoutExpansion interface{}
+
+type ClusterPoolExpansion interface{}
+
+type PoolExpansion interface{}
+
+type PoolPolicyExpansion interface{}
+
+type PoolTemplateExpansion interface{}
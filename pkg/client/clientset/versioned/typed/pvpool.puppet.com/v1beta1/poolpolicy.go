@@ -0,0 +1,152 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"context"
+	"time"
+
+	v1beta1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1beta1"
+	scheme "github.com/puppetlabs/pvpool/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// PoolPoliciesGetter has a method to return a PoolPolicyInterface.
+// A group's client should implement this interface.
+type PoolPoliciesGetter interface {
+	PoolPolicies() PoolPolicyInterface
+}
+
+// PoolPolicyInterface has methods to work with PoolPolicy resources.
+type PoolPolicyInterface interface {
+	Create(ctx context.Context, poolPolicy *v1beta1.PoolPolicy, opts v1.CreateOptions) (*v1beta1.PoolPolicy, error)
+	Update(ctx context.Context, poolPolicy *v1beta1.PoolPolicy, opts v1.UpdateOptions) (*v1beta1.PoolPolicy, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.PoolPolicy, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1beta1.PoolPolicyList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.PoolPolicy, err error)
+	PoolPolicyExpansion
+}
+
+// poolPolicies implements PoolPolicyInterface
+type poolPolicies struct {
+	client rest.Interface
+}
+
+// newPoolPolicies returns a PoolPolicies
+func newPoolPolicies(c *PvpoolV1beta1Client) *poolPolicies {
+	return &poolPolicies{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the poolPolicy, and returns the corresponding poolPolicy object, and an error if there is any.
+func (c *poolPolicies) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.PoolPolicy, err error) {
+	result = &v1beta1.PoolPolicy{}
+	err = c.client.Get().
+		Resource("poolpolicies").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of PoolPolicies that match those selectors.
+func (c *poolPolicies) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.PoolPolicyList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1beta1.PoolPolicyList{}
+	err = c.client.Get().
+		Resource("poolpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested poolPolicies.
+func (c *poolPolicies) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("poolpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a poolPolicy and creates it.  Returns the server's representation of the poolPolicy, and an error, if there is any.
+func (c *poolPolicies) Create(ctx context.Context, poolPolicy *v1beta1.PoolPolicy, opts v1.CreateOptions) (result *v1beta1.PoolPolicy, err error) {
+	result = &v1beta1.PoolPolicy{}
+	err = c.client.Post().
+		Resource("poolpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(poolPolicy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a poolPolicy and updates it. Returns the server's representation of the poolPolicy, and an error, if there is any.
+func (c *poolPolicies) Update(ctx context.Context, poolPolicy *v1beta1.PoolPolicy, opts v1.UpdateOptions) (result *v1beta1.PoolPolicy, err error) {
+	result = &v1beta1.PoolPolicy{}
+	err = c.client.Put().
+		Resource("poolpolicies").
+		Name(poolPolicy.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(poolPolicy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the poolPolicy and deletes it. Returns an error if one occurs.
+func (c *poolPolicies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("poolpolicies").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *poolPolicies) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("poolpolicies").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched poolPolicy.
+func (c *poolPolicies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.PoolPolicy, err error) {
+	result = &v1beta1.PoolPolicy{}
+	err = c.client.Patch(pt).
+		Resource("poolpolicies").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,152 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"context"
+	"time"
+
+	v1beta1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1beta1"
+	scheme "github.com/puppetlabs/pvpool/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// PoolTemplatesGetter has a method to return a PoolTemplateInterface.
+// A group's client should implement this interface.
+type PoolTemplatesGetter interface {
+	PoolTemplates() PoolTemplateInterface
+}
+
+// PoolTemplateInterface has methods to work with PoolTemplate resources.
+type PoolTemplateInterface interface {
+	Create(ctx context.Context, poolTemplate *v1beta1.PoolTemplate, opts v1.CreateOptions) (*v1beta1.PoolTemplate, error)
+	Update(ctx context.Context, poolTemplate *v1beta1.PoolTemplate, opts v1.UpdateOptions) (*v1beta1.PoolTemplate, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.PoolTemplate, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1beta1.PoolTemplateList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.PoolTemplate, err error)
+	PoolTemplateExpansion
+}
+
+// poolTemplates implements PoolTemplateInterface
+type poolTemplates struct {
+	client rest.Interface
+}
+
+// newPoolTemplates returns a PoolTemplates
+func newPoolTemplates(c *PvpoolV1beta1Client) *poolTemplates {
+	return &poolTemplates{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the poolTemplate, and returns the corresponding poolTemplate object, and an error if there is any.
+func (c *poolTemplates) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.PoolTemplate, err error) {
+	result = &v1beta1.PoolTemplate{}
+	err = c.client.Get().
+		Resource("pooltemplates").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of PoolTemplates that match those selectors.
+func (c *poolTemplates) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.PoolTemplateList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1beta1.PoolTemplateList{}
+	err = c.client.Get().
+		Resource("pooltemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested poolTemplates.
+func (c *poolTemplates) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("pooltemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a poolTemplate and creates it.  Returns the server's representation of the poolTemplate, and an error, if there is any.
+func (c *poolTemplates) Create(ctx context.Context, poolTemplate *v1beta1.PoolTemplate, opts v1.CreateOptions) (result *v1beta1.PoolTemplate, err error) {
+	result = &v1beta1.PoolTemplate{}
+	err = c.client.Post().
+		Resource("pooltemplates").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(poolTemplate).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a poolTemplate and updates it. Returns the server's representation of the poolTemplate, and an error, if there is any.
+func (c *poolTemplates) Update(ctx context.Context, poolTemplate *v1beta1.PoolTemplate, opts v1.UpdateOptions) (result *v1beta1.PoolTemplate, err error) {
+	result = &v1beta1.PoolTemplate{}
+	err = c.client.Put().
+		Resource("pooltemplates").
+		Name(poolTemplate.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(poolTemplate).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the poolTemplate and deletes it. Returns an error if one occurs.
+func (c *poolTemplates) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("pooltemplates").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *poolTemplates) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("pooltemplates").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched poolTemplate.
+func (c *poolTemplates) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.PoolTemplate, err error) {
+	result = &v1beta1.PoolTemplate{}
+	err = c.client.Patch(pt).
+		Resource("pooltemplates").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,93 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1beta1"
+	"github.com/puppetlabs/pvpool/pkg/client/clientset/versioned/scheme"
+	rest "k8s.io/client-go/rest"
+)
+
+type PvpoolV1beta1Interface interface {
+	RESTClient() rest.Interface
+	CheckoutsGetter
+	ClusterPoolsGetter
+	PoolsGetter
+	PoolPoliciesGetter
+	PoolTemplatesGetter
+}
+
+// PvpoolV1beta1Client is used to interact with features provided by the pvpool.puppet.com group.
+type PvpoolV1beta1Client struct {
+	restClient rest.Interface
+}
+
+func (c *PvpoolV1beta1Client) Checkouts(namespace string) CheckoutInterface {
+	return newCheckouts(c, namespace)
+}
+
+func (c *PvpoolV1beta1Client) ClusterPools() ClusterPoolInterface {
+	return newClusterPools(c)
+}
+
+func (c *PvpoolV1beta1Client) Pools(namespace string) PoolInterface {
+	return newPools(c, namespace)
+}
+
+func (c *PvpoolV1beta1Client) PoolPolicies() PoolPolicyInterface {
+	return newPoolPolicies(c)
+}
+
+func (c *PvpoolV1beta1Client) PoolTemplates() PoolTemplateInterface {
+	return newPoolTemplates(c)
+}
+
+// NewForConfig creates a new PvpoolV1beta1Client for the given config.
+func NewForConfig(c *rest.Config) (*PvpoolV1beta1Client, error) {
+	config := *c
+	if err := setConfigDefaults(&config); err != nil {
+		return nil, err
+	}
+	client, err := rest.RESTClientFor(&config)
+	if err != nil {
+		return nil, err
+	}
+	return &PvpoolV1beta1Client{client}, nil
+}
+
+// NewForConfigOrDie creates a new PvpoolV1beta1Client for the given config and
+// panics if there is an error in the config.
+func NewForConfigOrDie(c *rest.Config) *PvpoolV1beta1Client {
+	client, err := NewForConfig(c)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// New creates a new PvpoolV1beta1Client for the given RESTClient.
+func New(c rest.Interface) *PvpoolV1beta1Client {
+	return &PvpoolV1beta1Client{c}
+}
+
+func setConfigDefaults(config *rest.Config) error {
+	gv := v1beta1.SchemeGroupVersion
+	config.GroupVersion = &gv
+	config.APIPath = "/apis"
+	config.NegotiatedSerializer = scheme.Codecs.WithoutConversion()
+
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+
+	return nil
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *PvpoolV1beta1Client) RESTClient() rest.Interface {
+	if c == nil {
+		return nil
+	}
+	return c.restClient
+}
@@ -0,0 +1,67 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package externalversions
+
+import (
+	"fmt"
+
+	v1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	v1beta1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1beta1"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// GenericInformer is type of SharedIndexInformer which will locate and delegate to other
+// sharedInformers based on type
+type GenericInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() cache.GenericLister
+}
+
+type genericInformer struct {
+	informer cache.SharedIndexInformer
+	resource schema.GroupResource
+}
+
+// Informer returns the SharedIndexInformer.
+func (f *genericInformer) Informer() cache.SharedIndexInformer {
+	return f.informer
+}
+
+// Lister returns the GenericLister.
+func (f *genericInformer) Lister() cache.GenericLister {
+	return cache.NewGenericLister(f.Informer().GetIndexer(), f.resource)
+}
+
+// ForResource gives generic access to a shared informer of the matching type
+// TODO extend this to unknown resources with a client pool
+func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
+	switch resource {
+	// Group=pvpool.puppet.com, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("checkouts"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Pvpool().V1alpha1().Checkouts().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("clusterpools"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Pvpool().V1alpha1().ClusterPools().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("pools"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Pvpool().V1alpha1().Pools().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("poolpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Pvpool().V1alpha1().PoolPolicies().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("pooltemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Pvpool().V1alpha1().PoolTemplates().Informer()}, nil
+
+		// Group=pvpool.puppet.com, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithResource("checkouts"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Pvpool().V1beta1().Checkouts().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("clusterpools"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Pvpool().V1beta1().ClusterPools().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("pools"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Pvpool().V1beta1().Pools().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("poolpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Pvpool().V1beta1().PoolPolicies().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("pooltemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Pvpool().V1beta1().PoolTemplates().Informer()}, nil
+
+	}
+
+	return nil, fmt.Errorf("no informer found for %v", resource)
+}
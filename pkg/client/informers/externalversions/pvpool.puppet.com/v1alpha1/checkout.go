@@ -0,0 +1,74 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	pvpoolpuppetcomv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	versioned "github.com/puppetlabs/pvpool/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/puppetlabs/pvpool/pkg/client/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/puppetlabs/pvpool/pkg/client/listers/pvpool.puppet.com/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// CheckoutInformer provides access to a shared informer and lister for
+// Checkouts.
+type CheckoutInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.CheckoutLister
+}
+
+type checkoutInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewCheckoutInformer constructs a new informer for Checkout type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewCheckoutInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredCheckoutInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredCheckoutInformer constructs a new informer for Checkout type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredCheckoutInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.PvpoolV1alpha1().Checkouts(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.PvpoolV1alpha1().Checkouts(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&pvpoolpuppetcomv1alpha1.Checkout{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *checkoutInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredCheckoutInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *checkoutInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&pvpoolpuppetcomv1alpha1.Checkout{}, f.defaultInformer)
+}
+
+func (f *checkoutInformer) Lister() v1alpha1.CheckoutLister {
+	return v1alpha1.NewCheckoutLister(f.Informer().GetIndexer())
+}
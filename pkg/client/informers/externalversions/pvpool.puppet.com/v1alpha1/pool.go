@@ -0,0 +1,74 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	pvpoolpuppetcomv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	versioned "github.com/puppetlabs/pvpool/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/puppetlabs/pvpool/pkg/client/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/puppetlabs/pvpool/pkg/client/listers/pvpool.puppet.com/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// PoolInformer provides access to a shared informer and lister for
+// Pools.
+type PoolInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.PoolLister
+}
+
+type poolInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewPoolInformer constructs a new informer for Pool type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewPoolInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredPoolInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredPoolInformer constructs a new informer for Pool type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredPoolInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.PvpoolV1alpha1().Pools(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.PvpoolV1alpha1().Pools(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&pvpoolpuppetcomv1alpha1.Pool{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *poolInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredPoolInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *poolInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&pvpoolpuppetcomv1alpha1.Pool{}, f.defaultInformer)
+}
+
+func (f *poolInformer) Lister() v1alpha1.PoolLister {
+	return v1alpha1.NewPoolLister(f.Informer().GetIndexer())
+}
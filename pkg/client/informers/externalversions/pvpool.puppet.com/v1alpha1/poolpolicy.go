@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	pvpoolpuppetcomv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	versioned "github.com/puppetlabs/pvpool/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/puppetlabs/pvpool/pkg/client/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/puppetlabs/pvpool/pkg/client/listers/pvpool.puppet.com/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// PoolPolicyInformer provides access to a shared informer and lister for
+// PoolPolicies.
+type PoolPolicyInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.PoolPolicyLister
+}
+
+type poolPolicyInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewPoolPolicyInformer constructs a new informer for PoolPolicy type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewPoolPolicyInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredPoolPolicyInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredPoolPolicyInformer constructs a new informer for PoolPolicy type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredPoolPolicyInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.PvpoolV1alpha1().PoolPolicies().List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.PvpoolV1alpha1().PoolPolicies().Watch(context.TODO(), options)
+			},
+		},
+		&pvpoolpuppetcomv1alpha1.PoolPolicy{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *poolPolicyInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredPoolPolicyInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *poolPolicyInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&pvpoolpuppetcomv1alpha1.PoolPolicy{}, f.defaultInformer)
+}
+
+func (f *poolPolicyInformer) Lister() v1alpha1.PoolPolicyLister {
+	return v1alpha1.NewPoolPolicyLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	pvpoolpuppetcomv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	versioned "github.com/puppetlabs/pvpool/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/puppetlabs/pvpool/pkg/client/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/puppetlabs/pvpool/pkg/client/listers/pvpool.puppet.com/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// PoolTemplateInformer provides access to a shared informer and lister for
+// PoolTemplates.
+type PoolTemplateInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.PoolTemplateLister
+}
+
+type poolTemplateInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewPoolTemplateInformer constructs a new informer for PoolTemplate type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewPoolTemplateInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredPoolTemplateInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredPoolTemplateInformer constructs a new informer for PoolTemplate type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredPoolTemplateInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.PvpoolV1alpha1().PoolTemplates().List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.PvpoolV1alpha1().PoolTemplates().Watch(context.TODO(), options)
+			},
+		},
+		&pvpoolpuppetcomv1alpha1.PoolTemplate{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *poolTemplateInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredPoolTemplateInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *poolTemplateInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&pvpoolpuppetcomv1alpha1.PoolTemplate{}, f.defaultInformer)
+}
+
+func (f *poolTemplateInformer) Lister() v1alpha1.PoolTemplateLister {
+	return v1alpha1.NewPoolTemplateLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,57 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	internalinterfaces "github.com/puppetlabs/pvpool/pkg/client/informers/externalversions/internalinterfaces"
+)
+
+// Interface provides access to all the informers in this group version.
+type Interface interface {
+	// Checkouts returns a CheckoutInformer.
+	Checkouts() CheckoutInformer
+	// ClusterPools returns a ClusterPoolInformer.
+	ClusterPools() ClusterPoolInformer
+	// Pools returns a PoolInformer.
+	Pools() PoolInformer
+	// PoolPolicies returns a PoolPolicyInformer.
+	PoolPolicies() PoolPolicyInformer
+	// PoolTemplates returns a PoolTemplateInformer.
+	PoolTemplates() PoolTemplateInformer
+}
+
+type version struct {
+	factory          internalinterfaces.SharedInformerFactory
+	namespace        string
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// New returns a new Interface.
+func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakListOptions internalinterfaces.TweakListOptionsFunc) Interface {
+	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
+}
+
+// Checkouts returns a CheckoutInformer.
+func (v *version) Checkouts() CheckoutInformer {
+	return &checkoutInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ClusterPools returns a ClusterPoolInformer.
+func (v *version) ClusterPools() ClusterPoolInformer {
+	return &clusterPoolInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// Pools returns a PoolInformer.
+func (v *version) Pools() PoolInformer {
+	return &poolInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// PoolPolicies returns a PoolPolicyInformer.
+func (v *version) PoolPolicies() PoolPolicyInformer {
+	return &poolPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// PoolTemplates returns a PoolTemplateInformer.
+func (v *version) PoolTemplates() PoolTemplateInformer {
+	return &poolTemplateInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
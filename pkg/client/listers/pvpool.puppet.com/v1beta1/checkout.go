@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// CheckoutLister helps list Checkouts.
+// All objects returned here must be treated as read-only.
+type CheckoutLister interface {
+	// List lists all Checkouts in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.Checkout, err error)
+	// Checkouts returns an object that can list and get Checkouts.
+	Checkouts(namespace string) CheckoutNamespaceLister
+	CheckoutListerExpansion
+}
+
+// checkoutLister implements the CheckoutLister interface.
+type checkoutLister struct {
+	indexer cache.Indexer
+}
+
+// NewCheckoutLister returns a new CheckoutLister.
+func NewCheckoutLister(indexer cache.Indexer) CheckoutLister {
+	return &checkoutLister{indexer: indexer}
+}
+
+// List lists all Checkouts in the indexer.
+func (s *checkoutLister) List(selector labels.Selector) (ret []*v1beta1.Checkout, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.Checkout))
+	})
+	return ret, err
+}
+
+// Checkouts returns an object that can list and get Checkouts.
+func (s *checkoutLister) Checkouts(namespace string) CheckoutNamespaceLister {
+	return checkoutNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// CheckoutNamespaceLister helps list and get Checkouts.
+// All objects returned here must be treated as read-only.
+type CheckoutNamespaceLister interface {
+	// List lists all Checkouts in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.Checkout, err error)
+	// Get retrieves the Checkout from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1beta1.Checkout, error)
+	CheckoutNamespaceListerExpansion
+}
+
+// checkoutNamespaceLister implements the CheckoutNamespaceLister
+// interface.
+type checkoutNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all Checkouts in the indexer for a given namespace.
+func (s checkoutNamespaceLister) List(selector labels.Selector) (ret []*v1beta1.Checkout, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.Checkout))
+	})
+	return ret, err
+}
+
+// Get retrieves the Checkout from the indexer for a given namespace and name.
+func (s checkoutNamespaceLister) Get(name string) (*v1beta1.Checkout, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1beta1.Resource("checkout"), name)
+	}
+	return obj.(*v1beta1.Checkout), nil
+}
@@ -0,0 +1,31 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1beta1
+
+// CheckoutListerExpansion allows custom methods to be added to
+// CheckoutLister.
+type CheckoutListerExpansion interface{}
+
+// CheckoutNamespaceListerExpansion allows custom methods to be added to
+// CheckoutNamespaceLister.
+type CheckoutNamespaceListerExpansion interface{}
+
+// ClusterPoolListerExpansion allows custom methods to be added to
+// ClusterPoolLister.
+type ClusterPoolListerExpansion interface{}
+
+// PoolListerExpansion allows custom methods to be added to
+// PoolLister.
+type PoolListerExpansion interface{}
+
+// PoolNamespaceListerExpansion allows custom methods to be added to
+// PoolNamespaceLister.
+type PoolNamespaceListerExpansion interface{}
+
+// PoolPolicyListerExpansion allows custom methods to be added to
+// PoolPolicyLister.
+type PoolPolicyListerExpansion interface{}
+
+// PoolTemplateListerExpansion allows custom methods to be added to
+// PoolTemplateLister.
+type PoolTemplateListerExpansion interface{}
@@ -1,9 +1,13 @@
 package app
 
 import (
+	"fmt"
+	"time"
+
 	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
 	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func ConfigureCheckout(cs *CheckoutState) *pvpoolv1alpha1obj.Checkout {
@@ -23,8 +27,17 @@ func ConfigureCheckout(cs *CheckoutState) *pvpoolv1alpha1obj.Checkout {
 		}
 	}
 
+	configureCheckoutTiming(cs, time.Now())
+	configureCheckoutStageConditions(cs)
+
 	var conds []pvpoolv1alpha1.CheckoutCondition
-	for _, typ := range []pvpoolv1alpha1.CheckoutConditionType{pvpoolv1alpha1.CheckoutAcquired} {
+	for _, typ := range []pvpoolv1alpha1.CheckoutConditionType{
+		pvpoolv1alpha1.CheckoutAcquired,
+		pvpoolv1alpha1.CheckoutVolumeSelected,
+		pvpoolv1alpha1.CheckoutTransferring,
+		pvpoolv1alpha1.CheckoutBound,
+		pvpoolv1alpha1.CheckoutReady,
+	} {
 		prev, _ := cs.Checkout.Condition(typ)
 		next := cs.Conds[typ]
 		conds = append(conds, pvpoolv1alpha1.CheckoutCondition{
@@ -36,3 +49,150 @@ func ConfigureCheckout(cs *CheckoutState) *pvpoolv1alpha1obj.Checkout {
 
 	return cs.Checkout
 }
+
+func configureCheckoutTiming(cs *CheckoutState, now time.Time) {
+	status := &cs.Checkout.Object.Status
+
+	// Lineage is only known at the moment we select a volume, so we never
+	// overwrite it afterward. If we missed that moment, for example because
+	// the status update failed, we can still recover it from the annotations
+	// on the locked or final PV.
+	if status.Source == nil {
+		status.Source = cs.Source
+	}
+	if status.Source == nil {
+		if source := checkoutSourceFromAnnotations(cs.LockedPersistentVolume); source != nil {
+			// The locked PV is the pool's PV, so it knows its own name even
+			// if it was annotated before we recorded it.
+			if source.PersistentVolumeName == "" {
+				source.PersistentVolumeName = cs.LockedPersistentVolume.Name
+			}
+			status.Source = source
+		}
+	}
+	if status.Source == nil {
+		status.Source = checkoutSourceFromAnnotations(cs.PersistentVolume)
+	}
+
+	if status.RequestedAt == nil {
+		requestedAt := cs.Checkout.Object.GetCreationTimestamp()
+		status.RequestedAt = &requestedAt
+	}
+
+	if status.VolumeSelectedAt == nil && (cs.LockedPersistentVolume != nil || cs.PersistentVolume != nil) {
+		status.VolumeSelectedAt = &metav1.Time{Time: now}
+	}
+
+	if status.ClaimBoundAt == nil && cs.PersistentVolumeClaim.Object.Status.Phase == corev1.ClaimBound {
+		status.ClaimBoundAt = &metav1.Time{Time: now}
+		status.WaitDuration = &metav1.Duration{Duration: status.ClaimBoundAt.Sub(status.RequestedAt.Time)}
+	}
+}
+
+// configureCheckoutStageConditions sets a condition for each stage of a
+// checkout from the loaded state and the Acquired condition, which holds the
+// reason the checkout failed, if any.
+func configureCheckoutStageConditions(cs *CheckoutState) {
+	acquired := cs.Conds[pvpoolv1alpha1.CheckoutAcquired]
+	bound := cs.PersistentVolumeClaim.Object.Status.Phase == corev1.ClaimBound
+
+	var volumeName string
+	switch {
+	case cs.LockedPersistentVolume != nil:
+		volumeName = cs.LockedPersistentVolume.Name
+	case cs.PersistentVolume != nil:
+		volumeName = cs.PersistentVolume.Name
+	}
+
+	// The condition that explains why the checkout is not ready, if it isn't.
+	var blocked pvpoolv1alpha1.Condition
+
+	switch {
+	case volumeName != "":
+		cs.Conds[pvpoolv1alpha1.CheckoutVolumeSelected] = pvpoolv1alpha1.Condition{
+			Status:  corev1.ConditionTrue,
+			Reason:  pvpoolv1alpha1.CheckoutVolumeSelectedReasonSelected,
+			Message: fmt.Sprintf("The PV %q was selected from the pool.", volumeName),
+		}
+	case acquired.Reason == pvpoolv1alpha1.CheckoutAcquiredReasonPoolDoesNotExist,
+		acquired.Reason == pvpoolv1alpha1.CheckoutAcquiredReasonNotAvailable,
+		acquired.Reason == pvpoolv1alpha1.CheckoutAcquiredReasonHealthCheckPending:
+		blocked = pvpoolv1alpha1.Condition{
+			Status:  corev1.ConditionFalse,
+			Reason:  acquired.Reason,
+			Message: acquired.Message,
+		}
+		cs.Conds[pvpoolv1alpha1.CheckoutVolumeSelected] = blocked
+	default:
+		cs.Conds[pvpoolv1alpha1.CheckoutVolumeSelected] = pvpoolv1alpha1.Condition{
+			Status:  corev1.ConditionUnknown,
+			Reason:  pvpoolv1alpha1.CheckoutVolumeSelectedReasonPending,
+			Message: "The controller has not selected a PV yet.",
+		}
+	}
+
+	switch {
+	case bound:
+		cs.Conds[pvpoolv1alpha1.CheckoutTransferring] = pvpoolv1alpha1.Condition{
+			Status:  corev1.ConditionFalse,
+			Reason:  pvpoolv1alpha1.CheckoutTransferringReasonComplete,
+			Message: fmt.Sprintf("The PV was transferred to the PVC %q.", cs.PersistentVolumeClaim.Key.Name),
+		}
+	case acquired.Reason == pvpoolv1alpha1.CheckoutAcquiredReasonConflict,
+		acquired.Reason == pvpoolv1alpha1.CheckoutAcquiredReasonInvalid:
+		blocked = pvpoolv1alpha1.Condition{
+			Status:  corev1.ConditionFalse,
+			Reason:  acquired.Reason,
+			Message: acquired.Message,
+		}
+		cs.Conds[pvpoolv1alpha1.CheckoutTransferring] = blocked
+	case volumeName != "":
+		cs.Conds[pvpoolv1alpha1.CheckoutTransferring] = pvpoolv1alpha1.Condition{
+			Status:  corev1.ConditionTrue,
+			Reason:  pvpoolv1alpha1.CheckoutTransferringReasonInProgress,
+			Message: fmt.Sprintf("The PV %q is being transferred to the PVC %q.", volumeName, cs.PersistentVolumeClaim.Key.Name),
+		}
+		if blocked.Reason == "" {
+			blocked = pvpoolv1alpha1.Condition{
+				Status:  corev1.ConditionFalse,
+				Reason:  pvpoolv1alpha1.CheckoutReadyReasonTransferring,
+				Message: fmt.Sprintf("Waiting for the PV %q to be transferred to the PVC %q.", volumeName, cs.PersistentVolumeClaim.Key.Name),
+			}
+		}
+	default:
+		cs.Conds[pvpoolv1alpha1.CheckoutTransferring] = pvpoolv1alpha1.Condition{
+			Status:  corev1.ConditionFalse,
+			Reason:  pvpoolv1alpha1.CheckoutTransferringReasonWaitingForVolume,
+			Message: "No PV has been selected yet.",
+		}
+	}
+
+	if bound {
+		cs.Conds[pvpoolv1alpha1.CheckoutBound] = pvpoolv1alpha1.Condition{
+			Status:  corev1.ConditionTrue,
+			Reason:  pvpoolv1alpha1.CheckoutBoundReasonClaimBound,
+			Message: fmt.Sprintf("The PVC %q is bound to the PV %q.", cs.PersistentVolumeClaim.Key.Name, cs.PersistentVolumeClaim.Object.Spec.VolumeName),
+		}
+		cs.Conds[pvpoolv1alpha1.CheckoutReady] = pvpoolv1alpha1.Condition{
+			Status:  corev1.ConditionTrue,
+			Reason:  pvpoolv1alpha1.CheckoutReadyReasonCheckedOut,
+			Message: "The PVC is ready to use.",
+		}
+		return
+	}
+
+	cs.Conds[pvpoolv1alpha1.CheckoutBound] = pvpoolv1alpha1.Condition{
+		Status:  corev1.ConditionFalse,
+		Reason:  pvpoolv1alpha1.CheckoutBoundReasonClaimPending,
+		Message: fmt.Sprintf("The PVC %q is not bound yet.", cs.PersistentVolumeClaim.Key.Name),
+	}
+
+	if blocked.Reason == "" {
+		blocked = pvpoolv1alpha1.Condition{
+			Status:  corev1.ConditionFalse,
+			Reason:  pvpoolv1alpha1.CheckoutReadyReasonPending,
+			Message: "The checkout is not ready yet.",
+		}
+	}
+	cs.Conds[pvpoolv1alpha1.CheckoutReady] = blocked
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 
 	"github.com/puppetlabs/leg/errmap/pkg/errmark"
 	"github.com/puppetlabs/leg/k8sutil/pkg/controller/eventctx"
@@ -13,6 +14,8 @@ import (
 	"github.com/puppetlabs/leg/k8sutil/pkg/norm"
 	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
 	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
+	"github.com/puppetlabs/pvpool/pkg/controller/metrics"
+	"github.com/puppetlabs/pvpool/pkg/tracing"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,8 +25,38 @@ import (
 
 const (
 	CheckoutReclaimPolicyAnnotationKey = "pvpool.puppet.com/checkout.reclaim-policy"
+
+	// CheckoutNamespaceAnnotationKey and CheckoutNameAnnotationKey identify
+	// the checkout that modified a PV so that the controller can tell when
+	// the PV has been left behind.
+	CheckoutNamespaceAnnotationKey = "pvpool.puppet.com/checkout.namespace"
+	CheckoutNameAnnotationKey      = "pvpool.puppet.com/checkout.name"
+)
+
+// Annotations set on the PV and PVC given to a checkout that describe where
+// the storage came from. External tools can use them to attribute volumes
+// without looking up pvpool objects.
+const (
+	CheckoutUIDAnnotationKey                   = "pvpool.puppet.com/checkout.uid"
+	CheckoutSourcePoolNamespaceAnnotationKey   = "pvpool.puppet.com/source.pool-namespace"
+	CheckoutSourcePoolNameAnnotationKey        = "pvpool.puppet.com/source.pool-name"
+	CheckoutSourcePoolGenerationAnnotationKey  = "pvpool.puppet.com/source.pool-generation"
+	CheckoutSourceClaimNameAnnotationKey       = "pvpool.puppet.com/source.claim-name"
+	CheckoutSourceVolumeNameAnnotationKey      = "pvpool.puppet.com/source.volume-name"
+	CheckoutSourceInitJobNameAnnotationKey     = "pvpool.puppet.com/source.init-job-name"
+	CheckoutSourceInitCompletedAtAnnotationKey = "pvpool.puppet.com/source.init-completed-at"
 )
 
+var checkoutSourceAnnotationKeys = []string{
+	CheckoutSourcePoolNamespaceAnnotationKey,
+	CheckoutSourcePoolNameAnnotationKey,
+	CheckoutSourcePoolGenerationAnnotationKey,
+	CheckoutSourceClaimNameAnnotationKey,
+	CheckoutSourceVolumeNameAnnotationKey,
+	CheckoutSourceInitJobNameAnnotationKey,
+	CheckoutSourceInitCompletedAtAnnotationKey,
+}
+
 type CheckoutState struct {
 	Checkout *pvpoolv1alpha1obj.Checkout
 
@@ -50,6 +83,10 @@ type CheckoutState struct {
 	// the original PV from the pool.
 	LockedPersistentVolume *corev1obj.PersistentVolume
 
+	// Source identifies the pool replica the locked PV was taken from. It is
+	// only set when this state selects a new PV from a pool.
+	Source *pvpoolv1alpha1.CheckoutSource
+
 	// Conds represent status updates for given conditions.
 	Conds map[pvpoolv1alpha1.CheckoutConditionType]pvpoolv1alpha1.Condition
 }
@@ -57,16 +94,54 @@ type CheckoutState struct {
 var _ lifecycle.Loader = &CheckoutState{}
 var _ lifecycle.Persister = &CheckoutState{}
 
+// poolKey determines the pool to take a volume from. A cluster pool keeps its
+// replicas in a pool in the storage namespace.
+func (cs *CheckoutState) poolKey(ctx context.Context, cl client.Client) (client.ObjectKey, error) {
+	ref := cs.Checkout.Object.Spec.PoolRef
+	if ref.Kind != pvpoolv1alpha1.ClusterPoolKind.Kind {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = cs.Checkout.Key.Namespace
+		}
+
+		return client.ObjectKey{Namespace: namespace, Name: ref.Name}, nil
+	}
+
+	cp := pvpoolv1alpha1obj.NewClusterPool(ref.Name)
+	if _, err := (lifecycle.RequiredLoader{Loader: cp}).Load(ctx, cl); err != nil {
+		eventctx.EventRecorder(ctx).Eventf(cs.Checkout.Object, "Warning", "PoolAvailability", "Cluster pool %s does not exist", cp.Name)
+		cs.Conds[pvpoolv1alpha1.CheckoutAcquired] = pvpoolv1alpha1.Condition{
+			Status:  corev1.ConditionUnknown,
+			Reason:  pvpoolv1alpha1.CheckoutAcquiredReasonPoolDoesNotExist,
+			Message: fmt.Sprintf("The cluster pool %q does not exist.", cp.Name),
+		}
+
+		return client.ObjectKey{}, err
+	}
+
+	if cp.Object.Status.PoolRef == nil {
+		cs.Conds[pvpoolv1alpha1.CheckoutAcquired] = pvpoolv1alpha1.Condition{
+			Status:  corev1.ConditionUnknown,
+			Reason:  pvpoolv1alpha1.CheckoutAcquiredReasonPoolDoesNotExist,
+			Message: fmt.Sprintf("The cluster pool %q has not been set up yet.", cp.Name),
+		}
+
+		return client.ObjectKey{}, errmark.MarkTransient(fmt.Errorf("cluster pool %s has no backing pool", cp.Name))
+	}
+
+	return client.ObjectKey{
+		Namespace: cp.Object.Status.PoolRef.Namespace,
+		Name:      cp.Object.Status.PoolRef.Name,
+	}, nil
+}
+
 func (cs *CheckoutState) loadFromPool(ctx context.Context, cl client.Client) (bool, error) {
-	namespace := cs.Checkout.Object.Spec.PoolRef.Namespace
-	if namespace == "" {
-		namespace = cs.Checkout.Key.Namespace
+	key, err := cs.poolKey(ctx, cl)
+	if err != nil {
+		return false, err
 	}
 
-	pool := pvpoolv1alpha1obj.NewPool(client.ObjectKey{
-		Namespace: namespace,
-		Name:      cs.Checkout.Object.Spec.PoolRef.Name,
-	})
+	pool := pvpoolv1alpha1obj.NewPool(key)
 	if _, err := (lifecycle.RequiredLoader{Loader: pool}).Load(ctx, cl); err != nil {
 		eventctx.EventRecorder(ctx).Eventf(cs.Checkout.Object, "Warning", "PoolAvailability", "Pool %s does not exist", pool.Key)
 		cs.Conds[pvpoolv1alpha1.CheckoutAcquired] = pvpoolv1alpha1.Condition{
@@ -96,19 +171,193 @@ func (cs *CheckoutState) loadFromPool(ctx context.Context, cl client.Client) (bo
 		}
 
 		klog.InfoS("checkout state: load: pool has no available PVCs", "checkout", cs.Checkout.Key, "pool", pool.Key)
+
+		// Only count the transition so that retries while waiting for the
+		// pool to refill are not counted again.
+		if cond, ok := cs.Checkout.Condition(pvpoolv1alpha1.CheckoutAcquired); !ok || cond.Reason != pvpoolv1alpha1.CheckoutAcquiredReasonNotAvailable {
+			metrics.CheckoutNotAvailableTotal.WithLabelValues(pool.Key.Namespace, pool.Key.Name).Inc()
+		}
 		return false, errmark.MarkTransient(fmt.Errorf("pool %s has no available PVCs", pool.Key))
+	}
+
+	sort.Sort(PoolReplicasSortByCreationTimestamp(ps.Available))
+
+	var pr *PoolReplica
+	if hc := pool.Object.Spec.HealthCheck; hc != nil && hc.BeforeCheckout {
+		// Only take a replica that has been verified since this checkout was
+		// created.
+		for _, candidate := range ps.Available {
+			if verifiedAt, ok := candidate.VerifiedAt(); ok && verifiedAt.After(cs.Checkout.Object.GetCreationTimestamp().Time) {
+				pr = candidate
+				break
+			}
+		}
+
+		if pr == nil {
+			return false, cs.waitForHealthCheck(ps)
+		}
 	} else {
-		sort.Sort(PoolReplicasSortByCreationTimestamp(ps.Available))
-		pr := ps.Available[0]
+		pr = ps.Available[0]
+	}
+
+	klog.V(4).InfoS("checkout state: load: using PVC from pool", "checkout", cs.Checkout.Key, "pool", pool.Key, "pvc", pr.PersistentVolumeClaim.Key, "pv", pr.PersistentVolume.Name)
+	cs.LockedPersistentVolume = pr.PersistentVolume
 
-		klog.V(4).InfoS("checkout state: load: using PVC from pool", "checkout", cs.Checkout.Key, "pool", pool.Key, "pvc", pr.PersistentVolumeClaim.Key, "pv", pr.PersistentVolume.Name)
-		cs.LockedPersistentVolume = pr.PersistentVolume
+	cs.Source = &pvpoolv1alpha1.CheckoutSource{
+		PoolRef: pvpoolv1alpha1.PoolReference{
+			Namespace: pool.Key.Namespace,
+			Name:      pool.Key.Name,
+		},
+		PersistentVolumeClaimName: pr.PersistentVolumeClaim.Key.Name,
+		PersistentVolumeName:      pr.PersistentVolume.Name,
+	}
+	if generation, ok := pr.PoolGeneration(); ok {
+		cs.Source.PoolGeneration = generation
 	}
 
+	annotateCheckoutSource(pr)
+
 	return true, nil
 }
 
-func (cs *CheckoutState) Load(ctx context.Context, cl client.Client) (bool, error) {
+// annotateCheckoutSource records the provenance of a replica on its PV. The
+// locked PV keeps these annotations until the checkout completes, so they
+// survive even if the controller restarts partway through a checkout.
+func annotateCheckoutSource(pr *PoolReplica) {
+	annotations := pr.PersistentVolumeClaim.Object.GetAnnotations()
+
+	source := map[string]string{
+		CheckoutSourcePoolNamespaceAnnotationKey:   pr.Pool.Key.Namespace,
+		CheckoutSourcePoolNameAnnotationKey:        pr.Pool.Key.Name,
+		CheckoutSourcePoolGenerationAnnotationKey:  annotations[PoolReplicaPoolGenerationAnnotationKey],
+		CheckoutSourceClaimNameAnnotationKey:       pr.PersistentVolumeClaim.Key.Name,
+		CheckoutSourceVolumeNameAnnotationKey:      pr.PersistentVolume.Name,
+		CheckoutSourceInitJobNameAnnotationKey:     annotations[PoolReplicaInitJobNameAnnotationKey],
+		CheckoutSourceInitCompletedAtAnnotationKey: annotations[PoolReplicaInitCompletedAtAnnotationKey],
+	}
+	for key, value := range source {
+		if value != "" {
+			helper.Annotate(pr.PersistentVolume.Object, key, value)
+		}
+	}
+}
+
+// checkoutSourceFromAnnotations reconstructs the provenance of a checkout from
+// the annotations set by annotateCheckoutSource. It returns nil if the PV does
+// not record the pool it was taken from.
+func checkoutSourceFromAnnotations(pv *corev1obj.PersistentVolume) *pvpoolv1alpha1.CheckoutSource {
+	if pv == nil {
+		return nil
+	}
+
+	annotations := pv.Object.GetAnnotations()
+
+	name := annotations[CheckoutSourcePoolNameAnnotationKey]
+	if name == "" {
+		return nil
+	}
+
+	source := &pvpoolv1alpha1.CheckoutSource{
+		PoolRef: pvpoolv1alpha1.PoolReference{
+			Namespace: annotations[CheckoutSourcePoolNamespaceAnnotationKey],
+			Name:      name,
+		},
+		PersistentVolumeClaimName: annotations[CheckoutSourceClaimNameAnnotationKey],
+		PersistentVolumeName:      annotations[CheckoutSourceVolumeNameAnnotationKey],
+	}
+	if generation, err := strconv.ParseInt(annotations[CheckoutSourcePoolGenerationAnnotationKey], 10, 64); err == nil {
+		source.PoolGeneration = generation
+	}
+
+	return source
+}
+
+// waitForHealthCheck records on the checkout that it needs a replica of the
+// pool to pass a health check. The pool reconciler looks for checkouts in this
+// state and starts the health check; we never modify the pool's PVCs here.
+func (cs *CheckoutState) waitForHealthCheck(ps *PoolState) error {
+	klog.V(4).InfoS("checkout state: load: waiting for health check", "checkout", cs.Checkout.Key, "pool", ps.Pool.Key)
+
+	cs.Conds[pvpoolv1alpha1.CheckoutAcquired] = pvpoolv1alpha1.Condition{
+		Status:  corev1.ConditionUnknown,
+		Reason:  pvpoolv1alpha1.CheckoutAcquiredReasonHealthCheckPending,
+		Message: fmt.Sprintf("Waiting for a PVC in the pool %q to pass its health check.", ps.Pool.Key),
+	}
+
+	return errmark.MarkTransient(fmt.Errorf("pool %s has no verified PVCs", ps.Pool.Key))
+}
+
+// CheckoutWaitingForHealthCheck returns true if the given checkout is waiting
+// for a replica of its pool to pass a health check.
+func CheckoutWaitingForHealthCheck(co *pvpoolv1alpha1.Checkout) bool {
+	for _, cond := range co.Status.Conditions {
+		if cond.Type == pvpoolv1alpha1.CheckoutAcquired {
+			return cond.Reason == pvpoolv1alpha1.CheckoutAcquiredReasonHealthCheckPending
+		}
+	}
+
+	return false
+}
+
+// CheckoutPoolRefIndexField is the name of the field index that the
+// controller keeps on checkouts to find the checkouts that refer to a pool.
+const CheckoutPoolRefIndexField = "spec.poolRef"
+
+// CheckoutPoolRefIndexValues is the indexer function for
+// CheckoutPoolRefIndexField.
+func CheckoutPoolRefIndexValues(obj client.Object) []string {
+	co, ok := obj.(*pvpoolv1alpha1.Checkout)
+	if !ok {
+		return nil
+	}
+
+	ref := co.Spec.PoolRef
+	if ref.Kind == pvpoolv1alpha1.ClusterPoolKind.Kind {
+		return []string{checkoutPoolRefIndexValue(ref.Kind, client.ObjectKey{Name: ref.Name})}
+	}
+
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = co.GetNamespace()
+	}
+
+	return []string{checkoutPoolRefIndexValue(pvpoolv1alpha1.PoolKind.Kind, client.ObjectKey{Namespace: namespace, Name: ref.Name})}
+}
+
+// poolCheckoutPoolRefIndexValues returns the values of
+// CheckoutPoolRefIndexField under which checkouts that take their volume from
+// the given pool are indexed, either directly or through the cluster pool that
+// controls it.
+func poolCheckoutPoolRefIndexValues(pool *pvpoolv1alpha1.Pool) []string {
+	values := []string{checkoutPoolRefIndexValue(pvpoolv1alpha1.PoolKind.Kind, client.ObjectKeyFromObject(pool))}
+	if ctrl := metav1.GetControllerOf(pool); ctrl != nil && ctrl.Kind == pvpoolv1alpha1.ClusterPoolKind.Kind {
+		values = append(values, checkoutPoolRefIndexValue(ctrl.Kind, client.ObjectKey{Name: ctrl.Name}))
+	}
+	return values
+}
+
+func checkoutPoolRefIndexValue(kind string, key client.ObjectKey) string {
+	return kind + ":" + key.String()
+}
+
+// CheckoutRefersToPool returns true if the given checkout takes its volume
+// from the given pool, either directly or through the cluster pool that
+// controls it.
+func CheckoutRefersToPool(co *pvpoolv1alpha1.Checkout, pool *pvpoolv1alpha1.Pool) bool {
+	for _, want := range poolCheckoutPoolRefIndexValues(pool) {
+		for _, got := range CheckoutPoolRefIndexValues(co) {
+			if got == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (cs *CheckoutState) Load(ctx context.Context, cl client.Client) (ok bool, err error) {
+	ctx, span := tracing.Start(ctx, "CheckoutState.Load", tracing.ObjectKeyAttributes(client.ObjectKeyFromObject(cs.Checkout.Object))...)
+	defer func() { tracing.End(span, err) }()
+
 	pairs := []struct {
 		PersistentVolumeClaim *corev1obj.PersistentVolumeClaim
 		PersistentVolume      **corev1obj.PersistentVolume
@@ -165,7 +414,10 @@ func (cs *CheckoutState) Load(ctx context.Context, cl client.Client) (bool, erro
 	return true, nil
 }
 
-func (cs *CheckoutState) Persist(ctx context.Context, cl client.Client) error {
+func (cs *CheckoutState) Persist(ctx context.Context, cl client.Client) (err error) {
+	ctx, span := tracing.Start(ctx, "CheckoutState.Persist", tracing.ObjectKeyAttributes(client.ObjectKeyFromObject(cs.Checkout.Object))...)
+	defer func() { tracing.End(span, err) }()
+
 	// We can either be in a state where we're still trying to allocate the PV
 	// and PVC, or we can have the PVC settled and bound. If it's not bound,
 	// we'll also set up the locked PV/PVC.
@@ -263,6 +515,12 @@ func NewCheckoutState(c *pvpoolv1alpha1obj.Checkout) *CheckoutState {
 	}
 }
 
+// checkoutPersistentVolumeName returns the name of the PV that a checkout
+// creates to take over the storage of the given PV from a pool.
+func checkoutPersistentVolumeName(locked *corev1obj.PersistentVolume) string {
+	return norm.MetaNameSuffixed("pvpool", "-"+string(locked.Object.GetUID()))
+}
+
 func ConfigureCheckoutState(cs *CheckoutState) (*CheckoutState, error) {
 	switch {
 	case cs.PersistentVolumeClaim.Object.Status.Phase == corev1.ClaimBound:
@@ -272,7 +530,7 @@ func ConfigureCheckoutState(cs *CheckoutState) (*CheckoutState, error) {
 		return cs, nil
 	case cs.PersistentVolume == nil:
 		// Uniqueness follows the underlying volume.
-		cs.PersistentVolume = corev1obj.NewPersistentVolume(norm.MetaNameSuffixed("pvpool", "-"+string(cs.LockedPersistentVolume.Object.GetUID())))
+		cs.PersistentVolume = corev1obj.NewPersistentVolume(checkoutPersistentVolumeName(cs.LockedPersistentVolume))
 	}
 
 	// We need to keep track of the original reclaim policy so we can use it for
@@ -284,11 +542,26 @@ func ConfigureCheckoutState(cs *CheckoutState) (*CheckoutState, error) {
 		helper.Annotate(cs.LockedPersistentVolume.Object, CheckoutReclaimPolicyAnnotationKey, string(cs.LockedPersistentVolume.Object.Spec.PersistentVolumeReclaimPolicy))
 	}
 
+	// Record the checkout on the locked PV so that the volume reconciler can
+	// find out whether it still exists.
+	helper.Annotate(cs.LockedPersistentVolume.Object, CheckoutNamespaceAnnotationKey, cs.Checkout.Key.Namespace)
+	helper.Annotate(cs.LockedPersistentVolume.Object, CheckoutNameAnnotationKey, cs.Checkout.Key.Name)
+	helper.Annotate(cs.LockedPersistentVolume.Object, CheckoutUIDAnnotationKey, string(cs.Checkout.Object.GetUID()))
+
 	// Copy locked PV to new PV. Note that we also copy annotations as they are
 	// used to keep track of deallocators in CSI.
 	helper.CopyLabelsAndAnnotations(cs.PersistentVolume.Object, cs.LockedPersistentVolume.Object)
 	cs.LockedPersistentVolume.Object.Spec.DeepCopyInto(&cs.PersistentVolume.Object.Spec)
 
+	// Record provenance on the PV and PVC we hand to the requestor.
+	helper.Annotate(cs.PersistentVolume.Object, CheckoutUIDAnnotationKey, string(cs.Checkout.Object.GetUID()))
+	helper.Annotate(cs.PersistentVolumeClaim.Object, CheckoutUIDAnnotationKey, string(cs.Checkout.Object.GetUID()))
+	for _, key := range checkoutSourceAnnotationKeys {
+		if value, ok := cs.PersistentVolume.Object.GetAnnotations()[key]; ok {
+			helper.Annotate(cs.PersistentVolumeClaim.Object, key, value)
+		}
+	}
+
 	// Set up PV.
 	cs.PersistentVolume.Object.Spec.AccessModes = cs.Checkout.Object.Spec.AccessModes
 	cs.PersistentVolume.Object.Spec.ClaimRef = &corev1.ObjectReference{
@@ -0,0 +1,178 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	corev1obj "github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/api/corev1"
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestConfigureCheckoutTimingSource(t *testing.T) {
+	annotations := map[string]string{
+		CheckoutSourcePoolNamespaceAnnotationKey:  "pools",
+		CheckoutSourcePoolNameAnnotationKey:       "test-pool",
+		CheckoutSourcePoolGenerationAnnotationKey: "3",
+		CheckoutSourceClaimNameAnnotationKey:      "test-pool-abcde",
+		CheckoutSourceVolumeNameAnnotationKey:     "pvc-1234",
+	}
+	expected := &pvpoolv1alpha1.CheckoutSource{
+		PoolRef: pvpoolv1alpha1.PoolReference{
+			Namespace: "pools",
+			Name:      "test-pool",
+		},
+		PoolGeneration:            3,
+		PersistentVolumeClaimName: "test-pool-abcde",
+		PersistentVolumeName:      "pvc-1234",
+	}
+
+	annotatedVolume := func(name string, annotations map[string]string) *corev1obj.PersistentVolume {
+		pv := corev1obj.NewPersistentVolume(name)
+		pv.Object.SetAnnotations(annotations)
+		return pv
+	}
+
+	tests := []struct {
+		Name     string
+		Setup    func(cs *CheckoutState)
+		Expected *pvpoolv1alpha1.CheckoutSource
+	}{
+		{
+			Name:     "No volumes",
+			Setup:    func(cs *CheckoutState) {},
+			Expected: nil,
+		},
+		{
+			Name: "Selected from pool",
+			Setup: func(cs *CheckoutState) {
+				cs.Source = expected
+				cs.LockedPersistentVolume = annotatedVolume("pvc-1234", nil)
+			},
+			Expected: expected,
+		},
+		{
+			Name: "Locked volume",
+			Setup: func(cs *CheckoutState) {
+				cs.LockedPersistentVolume = annotatedVolume("pvc-1234", annotations)
+			},
+			Expected: expected,
+		},
+		{
+			Name: "Locked volume without volume name",
+			Setup: func(cs *CheckoutState) {
+				withoutVolumeName := make(map[string]string, len(annotations))
+				for k, v := range annotations {
+					if k != CheckoutSourceVolumeNameAnnotationKey {
+						withoutVolumeName[k] = v
+					}
+				}
+
+				cs.LockedPersistentVolume = annotatedVolume("pvc-1234", withoutVolumeName)
+			},
+			Expected: expected,
+		},
+		{
+			Name: "Final volume",
+			Setup: func(cs *CheckoutState) {
+				cs.PersistentVolume = annotatedVolume("pvpool-5678", annotations)
+			},
+			Expected: expected,
+		},
+		{
+			Name: "Unannotated volumes",
+			Setup: func(cs *CheckoutState) {
+				cs.LockedPersistentVolume = annotatedVolume("pvc-1234", nil)
+				cs.PersistentVolume = annotatedVolume("pvpool-5678", nil)
+			},
+			Expected: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			cs := NewCheckoutState(pvpoolv1alpha1obj.NewCheckout(client.ObjectKey{Namespace: "test", Name: "test"}))
+			test.Setup(cs)
+
+			configureCheckoutTiming(cs, time.Now())
+			assert.Equal(t, test.Expected, cs.Checkout.Object.Status.Source)
+		})
+	}
+}
+
+func TestCheckoutRefersToPool(t *testing.T) {
+	pool := &pvpoolv1alpha1.Pool{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "pools",
+			Name:      "test-pool",
+		},
+	}
+	clusterPool := pool.DeepCopy()
+	clusterPool.SetOwnerReferences([]metav1.OwnerReference{
+		*metav1.NewControllerRef(&pvpoolv1alpha1.ClusterPool{ObjectMeta: metav1.ObjectMeta{Name: "test-pool"}}, pvpoolv1alpha1.ClusterPoolKind),
+	})
+
+	tests := []struct {
+		Name      string
+		Namespace string
+		PoolRef   pvpoolv1alpha1.PoolReference
+		Pool      *pvpoolv1alpha1.Pool
+		Expected  bool
+	}{
+		{
+			Name:      "Same namespace",
+			Namespace: "pools",
+			PoolRef:   pvpoolv1alpha1.PoolReference{Name: "test-pool"},
+			Pool:      pool,
+			Expected:  true,
+		},
+		{
+			Name:      "Other namespace",
+			Namespace: "checkouts",
+			PoolRef:   pvpoolv1alpha1.PoolReference{Namespace: "pools", Name: "test-pool"},
+			Pool:      pool,
+			Expected:  true,
+		},
+		{
+			Name:      "Defaulted namespace mismatch",
+			Namespace: "checkouts",
+			PoolRef:   pvpoolv1alpha1.PoolReference{Name: "test-pool"},
+			Pool:      pool,
+		},
+		{
+			Name:      "Name mismatch",
+			Namespace: "pools",
+			PoolRef:   pvpoolv1alpha1.PoolReference{Name: "other-pool"},
+			Pool:      pool,
+		},
+		{
+			Name:      "Cluster pool",
+			Namespace: "checkouts",
+			PoolRef:   pvpoolv1alpha1.PoolReference{Kind: pvpoolv1alpha1.ClusterPoolKind.Kind, Name: "test-pool"},
+			Pool:      clusterPool,
+			Expected:  true,
+		},
+		{
+			Name:      "Cluster pool without controller",
+			Namespace: "checkouts",
+			PoolRef:   pvpoolv1alpha1.PoolReference{Kind: pvpoolv1alpha1.ClusterPoolKind.Kind, Name: "test-pool"},
+			Pool:      pool,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			co := &pvpoolv1alpha1.Checkout{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: test.Namespace,
+					Name:      "test",
+				},
+				Spec: pvpoolv1alpha1.CheckoutSpec{
+					PoolRef: test.PoolRef,
+				},
+			}
+			assert.Equal(t, test.Expected, CheckoutRefersToPool(co, test.Pool))
+		})
+	}
+}
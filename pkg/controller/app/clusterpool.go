@@ -0,0 +1,112 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/puppetlabs/leg/errmap/pkg/errmark"
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/eventctx"
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/helper"
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
+	"github.com/puppetlabs/pvpool/pkg/tracing"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ClusterPoolGenerationAnnotationKey records the generation of the cluster
+	// pool that a backing pool was last configured from.
+	ClusterPoolGenerationAnnotationKey = "pvpool.puppet.com/cluster-pool.generation"
+)
+
+// ClusterPoolState manages the pool in the storage namespace that holds the
+// replicas for a cluster pool.
+type ClusterPoolState struct {
+	ClusterPool *pvpoolv1alpha1obj.ClusterPool
+
+	// Pool is the backing pool. It has the same name as the cluster pool.
+	Pool *pvpoolv1alpha1obj.Pool
+}
+
+var _ lifecycle.Loader = &ClusterPoolState{}
+var _ lifecycle.Persister = &ClusterPoolState{}
+
+func (cps *ClusterPoolState) Load(ctx context.Context, cl client.Client) (ok bool, err error) {
+	ctx, span := tracing.Start(ctx, "ClusterPoolState.Load", tracing.ObjectKeyAttributes(client.ObjectKeyFromObject(cps.ClusterPool.Object))...)
+	defer func() { tracing.End(span, err) }()
+
+	if ok, err := cps.Pool.Load(ctx, cl); err != nil || !ok {
+		return ok, err
+	}
+
+	if ctrl := metav1.GetControllerOf(cps.Pool.Object); ctrl == nil || ctrl.UID != cps.ClusterPool.Object.GetUID() {
+		eventctx.EventRecorder(ctx).Eventf(cps.ClusterPool.Object, "Warning", "PoolConflict", "A non-controlled pool with the name %s already exists", cps.Pool.Key)
+		return false, errmark.MarkTransient(fmt.Errorf("a pool with this cluster pool's name already exists in namespace %q", cps.Pool.Key.Namespace))
+	}
+
+	return true, nil
+}
+
+func (cps *ClusterPoolState) Persist(ctx context.Context, cl client.Client) (err error) {
+	ctx, span := tracing.Start(ctx, "ClusterPoolState.Persist", tracing.ObjectKeyAttributes(client.ObjectKeyFromObject(cps.ClusterPool.Object))...)
+	defer func() { tracing.End(span, err) }()
+
+	// The owner is cluster-scoped, so we can't use the namespace-checking
+	// ownership helpers here.
+	if metav1.GetControllerOf(cps.Pool.Object) == nil {
+		cps.Pool.Object.SetOwnerReferences(append(
+			cps.Pool.Object.GetOwnerReferences(),
+			*metav1.NewControllerRef(cps.ClusterPool.Object, pvpoolv1alpha1obj.ClusterPoolKind),
+		))
+	}
+
+	return cps.Pool.Persist(ctx, cl)
+}
+
+func NewClusterPoolState(cp *pvpoolv1alpha1obj.ClusterPool, namespace string) *ClusterPoolState {
+	return &ClusterPoolState{
+		ClusterPool: cp,
+		Pool: pvpoolv1alpha1obj.NewPool(client.ObjectKey{
+			Namespace: namespace,
+			Name:      cp.Name,
+		}),
+	}
+}
+
+func ConfigureClusterPoolState(cps *ClusterPoolState) *ClusterPoolState {
+	cps.ClusterPool.Object.Spec.DeepCopyInto(&cps.Pool.Object.Spec)
+	helper.Annotate(cps.Pool.Object, ClusterPoolGenerationAnnotationKey, strconv.FormatInt(cps.ClusterPool.Object.GetGeneration(), 10))
+
+	return cps
+}
+
+// ConfigureClusterPool copies the status of the backing pool to the cluster
+// pool.
+func ConfigureClusterPool(cps *ClusterPoolState) *pvpoolv1alpha1obj.ClusterPool {
+	if ctrl := metav1.GetControllerOf(cps.Pool.Object); ctrl == nil || ctrl.UID != cps.ClusterPool.Object.GetUID() {
+		return cps.ClusterPool
+	}
+
+	status := &cps.ClusterPool.Object.Status
+
+	observedGeneration := status.ObservedGeneration
+	if cps.Pool.Object.Status.ObservedGeneration == cps.Pool.Object.GetGeneration() {
+		// The pool has caught up with its spec, so the cluster pool has caught
+		// up with whichever generation the spec was copied from.
+		if generation, err := strconv.ParseInt(cps.Pool.Object.GetAnnotations()[ClusterPoolGenerationAnnotationKey], 10, 64); err == nil {
+			observedGeneration = generation
+		}
+	}
+
+	cps.Pool.Object.Status.DeepCopyInto(&status.PoolStatus)
+	status.ObservedGeneration = observedGeneration
+	status.PoolRef = &pvpoolv1alpha1.PoolReference{
+		Namespace: cps.Pool.Key.Namespace,
+		Name:      cps.Pool.Key.Name,
+	}
+
+	return cps.ClusterPool
+}
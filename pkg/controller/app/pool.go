@@ -1,17 +1,43 @@
 package app
 
 import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	batchv1obj "github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/api/batchv1"
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/helper"
 	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
 	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+const (
+	// PoolReplicaStatusesLimit is the maximum number of replicas to describe
+	// in a pool's status.
+	PoolReplicaStatusesLimit = 100
+
+	// SelectedNodeAnnotationKey is set on a PVC by the scheduler when its
+	// storage class waits for the first consumer.
+	SelectedNodeAnnotationKey = "volume.kubernetes.io/selected-node"
+)
+
+var (
+	nodeTopologyKeys = []string{corev1.LabelHostname}
+	zoneTopologyKeys = []string{corev1.LabelTopologyZone, corev1.LabelFailureDomainBetaZone}
 )
 
 func ConfigurePool(ps *PoolState) *pvpoolv1alpha1obj.Pool {
 	ps.Pool.Object.Status.ObservedGeneration = ps.Pool.Object.GetGeneration()
-	ps.Pool.Object.Status.Replicas = int32(len(ps.Available) + len(ps.Initializing) + len(ps.Stale))
+	ps.Pool.Object.Status.Replicas = int32(len(ps.Available) + len(ps.Verifying) + len(ps.Initializing) + len(ps.Stale))
 	ps.Pool.Object.Status.AvailableReplicas = int32(len(ps.Available))
+	ps.Pool.Object.Status.TemplateHash = PoolTemplateHash(ps.Pool)
+	ps.Pool.Object.Status.ReplicaStatuses = configurePoolReplicaStatuses(ps)
 
 	var conds []pvpoolv1alpha1.PoolCondition
-	for _, typ := range []pvpoolv1alpha1.PoolConditionType{pvpoolv1alpha1.PoolAvailable, pvpoolv1alpha1.PoolSettlement} {
+	for _, typ := range []pvpoolv1alpha1.PoolConditionType{pvpoolv1alpha1.PoolAvailable, pvpoolv1alpha1.PoolSettlement, pvpoolv1alpha1.PoolProvisioningStalled} {
 		prev, _ := ps.Pool.Condition(typ)
 		next := ps.Conds[typ]
 		conds = append(conds, pvpoolv1alpha1.PoolCondition{
@@ -23,3 +49,114 @@ func ConfigurePool(ps *PoolState) *pvpoolv1alpha1obj.Pool {
 
 	return ps.Pool
 }
+
+// PoolTemplateHash computes a short hash of a pool's PVC template so that
+// replicas created from an old version of the template can be identified.
+func PoolTemplateHash(p *pvpoolv1alpha1obj.Pool) string {
+	b, err := json.Marshal(p.Object.Spec.Template)
+	if err != nil {
+		return ""
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write(b)
+	return rand.SafeEncodeString(fmt.Sprint(h.Sum32()))
+}
+
+func configurePoolReplicaStatuses(ps *PoolState) []pvpoolv1alpha1.PoolReplicaStatus {
+	var statuses []pvpoolv1alpha1.PoolReplicaStatus
+
+	// Replicas that aren't available are the most interesting, so they go
+	// first in case we need to truncate the list. Stale replicas that were
+	// just deleted are included so that their failed jobs are reported.
+	for _, group := range []struct {
+		Phase    pvpoolv1alpha1.PoolReplicaPhase
+		Replicas PoolReplicas
+	}{
+		{Phase: pvpoolv1alpha1.PoolReplicaPhaseStale, Replicas: append(append(PoolReplicas(nil), ps.Stale...), ps.Deleted...)},
+		{Phase: pvpoolv1alpha1.PoolReplicaPhaseInitializing, Replicas: ps.Initializing},
+		{Phase: pvpoolv1alpha1.PoolReplicaPhaseVerifying, Replicas: ps.Verifying},
+		{Phase: pvpoolv1alpha1.PoolReplicaPhaseAvailable, Replicas: ps.Available},
+	} {
+		prs := append(PoolReplicas(nil), group.Replicas...)
+		sort.Sort(PoolReplicasSortByCreationTimestamp(prs))
+
+		for _, pr := range prs {
+			if len(statuses) >= PoolReplicaStatusesLimit {
+				return statuses
+			}
+
+			statuses = append(statuses, poolReplicaStatus(pr, group.Phase))
+		}
+	}
+
+	return statuses
+}
+
+func poolReplicaStatus(pr *PoolReplica, phase pvpoolv1alpha1.PoolReplicaPhase) pvpoolv1alpha1.PoolReplicaStatus {
+	status := pvpoolv1alpha1.PoolReplicaStatus{
+		ClaimName:         pr.PersistentVolumeClaim.Key.Name,
+		Phase:             phase,
+		CreationTimestamp: pr.PersistentVolumeClaim.Object.GetCreationTimestamp(),
+		NodeName:          pr.PersistentVolumeClaim.Object.GetAnnotations()[SelectedNodeAnnotationKey],
+		TemplateHash:      pr.PersistentVolumeClaim.Object.GetAnnotations()[PoolReplicaTemplateHashAnnotationKey],
+		InitJob:           poolReplicaJobStatus(pr.InitJob),
+		HealthCheckJob:    poolReplicaJobStatus(pr.HealthCheckJob),
+	}
+
+	if pv := pr.PersistentVolume; pv != nil {
+		status.VolumeName = pv.Name
+
+		if node := persistentVolumeTopologyValue(pv.Object, nodeTopologyKeys); node != "" {
+			status.NodeName = node
+		}
+		status.Zone = persistentVolumeTopologyValue(pv.Object, zoneTopologyKeys)
+	}
+
+	return status
+}
+
+func poolReplicaJobStatus(job *batchv1obj.Job) *pvpoolv1alpha1.PoolReplicaJobStatus {
+	if helper.Exists(job.Object) {
+		status := &pvpoolv1alpha1.PoolReplicaJobStatus{
+			Name:  job.Key.Name,
+			State: pvpoolv1alpha1.PoolReplicaJobStateRunning,
+		}
+
+		if fc, ok := job.FailedCondition(); ok && fc.Status == corev1.ConditionTrue {
+			status.State = pvpoolv1alpha1.PoolReplicaJobStateFailed
+			status.Reason = fc.Reason
+			status.Message = fc.Message
+		} else if job.Succeeded() {
+			status.State = pvpoolv1alpha1.PoolReplicaJobStateSucceeded
+		}
+
+		return status
+	}
+
+	return nil
+}
+
+// persistentVolumeTopologyValue finds the value of the first of the given
+// topology keys in a PV's labels or required node affinity.
+func persistentVolumeTopologyValue(pv *corev1.PersistentVolume, keys []string) string {
+	for _, key := range keys {
+		if value := pv.GetLabels()[key]; value != "" {
+			return value
+		}
+
+		if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+			continue
+		}
+
+		for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+			for _, expr := range term.MatchExpressions {
+				if expr.Key == key && expr.Operator == corev1.NodeSelectorOpIn && len(expr.Values) == 1 {
+					return expr.Values[0]
+				}
+			}
+		}
+	}
+
+	return ""
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
+	"time"
 
 	batchv1obj "github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/api/batchv1"
 	corev1obj "github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/api/corev1"
@@ -11,11 +13,15 @@ import (
 	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
 	"github.com/puppetlabs/leg/k8sutil/pkg/norm"
 	"github.com/puppetlabs/leg/mathutil/pkg/rand"
+	pvpoolv1alpha1defaults "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/defaults"
 	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
 	pvpoolv1alpha1validation "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/validation"
+	"github.com/puppetlabs/pvpool/pkg/tracing"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -25,23 +31,56 @@ const (
 
 	PoolReplicaPhaseAnnotationValueInitializing = "Initializing"
 	PoolReplicaPhaseAnnotationValueAvailable    = "Available"
+	PoolReplicaPhaseAnnotationValueVerifying    = "Verifying"
+
+	// PoolReplicaVerifiedAtAnnotationKey records the last time a replica was
+	// known to be usable, either because its init job or its health check job
+	// succeeded.
+	PoolReplicaVerifiedAtAnnotationKey = "pvpool.puppet.com/replica.verified-at"
+
+	// PoolReplicaVerificationRequestedAtAnnotationKey records the last time the
+	// pool started verifying a replica because a checkout was waiting for one.
+	PoolReplicaVerificationRequestedAtAnnotationKey = "pvpool.puppet.com/replica.verification-requested-at"
+
+	// PoolReplicaPoolGenerationAnnotationKey records the generation of the
+	// pool spec that a replica's PVC was created from.
+	PoolReplicaPoolGenerationAnnotationKey = "pvpool.puppet.com/replica.pool-generation"
+
+	// PoolReplicaTemplateHashAnnotationKey records the hash of the pool's PVC
+	// template at the time a replica's PVC was created.
+	PoolReplicaTemplateHashAnnotationKey = "pvpool.puppet.com/replica.template-hash"
+
+	// PoolReplicaInitJobNameAnnotationKey and
+	// PoolReplicaInitCompletedAtAnnotationKey record the init job that
+	// prepared a replica and when it finished, since the job itself is deleted
+	// once the replica becomes available.
+	PoolReplicaInitJobNameAnnotationKey     = "pvpool.puppet.com/replica.init-job-name"
+	PoolReplicaInitCompletedAtAnnotationKey = "pvpool.puppet.com/replica.init-completed-at"
+
+	// PoolReplicaAdoptedAtAnnotationKey records when an existing PVC was
+	// adopted into a pool instead of being created by it.
+	PoolReplicaAdoptedAtAnnotationKey = "pvpool.puppet.com/replica.adopted-at"
+
+	// PoolReplicaReclaimPolicyAnnotationKey records the reclaim policy a PV had
+	// before its pool was deleted with the Retain deletion policy.
+	PoolReplicaReclaimPolicyAnnotationKey = "pvpool.puppet.com/replica.reclaim-policy"
 )
 
-var (
-	DefaultPoolReplicaInitJobSpec = batchv1.JobSpec{
-		Template: corev1.PodTemplateSpec{
-			Spec: corev1.PodSpec{
-				Containers: []corev1.Container{
-					{
-						Name: "init",
-						// https://hub.docker.com/layers/busybox/library/busybox/stable-musl/images/sha256-8d0c42425011ea3fb5b4ec5a121dde4ce986c2efea46be9d981a478fe1d206ec?context=explore
-						Image: "busybox@sha256:8d0c42425011ea3fb5b4ec5a121dde4ce986c2efea46be9d981a478fe1d206ec",
-					},
-				},
-			},
-		},
-		BackoffLimit: pointer.Int32Ptr(pvpoolv1alpha1validation.MountJobMaxBackoffLimit),
-	}
+// Labels applied to the pods of a replica's init job so that they can be read
+// using the downward API.
+const (
+	PoolReplicaPoolNameLabelKey       = "pvpool.puppet.com/pool.name"
+	PoolReplicaPoolNamespaceLabelKey  = "pvpool.puppet.com/pool.namespace"
+	PoolReplicaPoolGenerationLabelKey = "pvpool.puppet.com/pool.generation"
+	PoolReplicaClaimNameLabelKey      = "pvpool.puppet.com/replica.claim-name"
+)
+
+// Environment variables set on every container of a replica's init job.
+const (
+	PoolReplicaPoolNameEnvVar       = "PVPOOL_POOL_NAME"
+	PoolReplicaPoolNamespaceEnvVar  = "PVPOOL_POOL_NAMESPACE"
+	PoolReplicaPoolGenerationEnvVar = "PVPOOL_POOL_GENERATION"
+	PoolReplicaClaimNameEnvVar      = "PVPOOL_REPLICA_CLAIM_NAME"
 )
 
 type PoolReplica struct {
@@ -49,6 +88,15 @@ type PoolReplica struct {
 	PersistentVolumeClaim *corev1obj.PersistentVolumeClaim
 	PersistentVolume      *corev1obj.PersistentVolume
 	InitJob               *batchv1obj.Job
+	HealthCheckJob        *batchv1obj.Job
+
+	// Events are the events recorded for the PVC. They are only loaded when
+	// we need to explain why the PVC hasn't been provisioned.
+	Events []corev1.Event
+
+	// ProvisioningAbandoned is set when the PVC has been pending for so long
+	// that we want to replace it.
+	ProvisioningAbandoned bool
 }
 
 var _ lifecycle.Deleter = &PoolReplica{}
@@ -58,20 +106,66 @@ var _ lifecycle.Persister = &PoolReplica{}
 func (pr *PoolReplica) Delete(ctx context.Context, cl client.Client, opts ...lifecycle.DeleteOption) (bool, error) {
 	// We have to delete the job first because its existence will block the PVC
 	// from being deleted (unless it's failed).
-	if _, err := pr.InitJob.Delete(ctx, cl, opts...); err != nil {
-		return false, err
+	for _, job := range []*batchv1obj.Job{pr.InitJob, pr.HealthCheckJob} {
+		if _, err := job.Delete(ctx, cl, opts...); err != nil {
+			return false, err
+		}
 	}
 
 	return pr.PersistentVolumeClaim.Delete(ctx, cl, opts...)
 }
 
-func (pr *PoolReplica) Load(ctx context.Context, cl client.Client) (bool, error) {
-	// The init job may not exist. This is desired behavior.
-	if _, err := pr.InitJob.Load(ctx, cl); err != nil {
-		return false, err
+// Orphan removes this replica from its pool without deleting its PVC. Any
+// running jobs are deleted so that a pool that later adopts the PVC can start
+// them again.
+func (pr *PoolReplica) Orphan(ctx context.Context, cl client.Client) error {
+	for _, job := range []*batchv1obj.Job{pr.InitJob, pr.HealthCheckJob} {
+		if _, err := job.Delete(ctx, cl, lifecycle.DeleteWithPropagationPolicy(metav1.DeletePropagationForeground)); err != nil {
+			return err
+		}
+	}
+
+	refs := pr.PersistentVolumeClaim.Object.GetOwnerReferences()
+	for i := 0; i < len(refs); {
+		if refs[i].UID == pr.Pool.Object.GetUID() {
+			refs = append(refs[:i], refs[i+1:]...)
+		} else {
+			i++
+		}
+	}
+	pr.PersistentVolumeClaim.Object.SetOwnerReferences(refs)
+
+	return pr.PersistentVolumeClaim.Persist(ctx, cl)
+}
+
+// Retain deletes this replica, but first sets the reclaim policy of its PV, if
+// any, to Retain so that the underlying storage is kept.
+func (pr *PoolReplica) Retain(ctx context.Context, cl client.Client) error {
+	if pv := pr.PersistentVolume; pv != nil && pv.Object.Spec.PersistentVolumeReclaimPolicy != corev1.PersistentVolumeReclaimRetain {
+		helper.Annotate(pv.Object, PoolReplicaReclaimPolicyAnnotationKey, string(pv.Object.Spec.PersistentVolumeReclaimPolicy))
+		pv.Object.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimRetain
+
+		if err := pv.Persist(ctx, cl); err != nil {
+			return err
+		}
 	}
 
-	ok, err := pr.PersistentVolumeClaim.Load(ctx, cl)
+	_, err := pr.Delete(ctx, cl)
+	return err
+}
+
+func (pr *PoolReplica) Load(ctx context.Context, cl client.Client) (ok bool, err error) {
+	ctx, span := tracing.Start(ctx, "PoolReplica.Load", tracing.ObjectKeyAttributes(client.ObjectKeyFromObject(pr.PersistentVolumeClaim.Object))...)
+	defer func() { tracing.End(span, err) }()
+
+	// The init and health check jobs may not exist. This is desired behavior.
+	for _, job := range []*batchv1obj.Job{pr.InitJob, pr.HealthCheckJob} {
+		if _, err := job.Load(ctx, cl); err != nil {
+			return false, err
+		}
+	}
+
+	ok, err = pr.PersistentVolumeClaim.Load(ctx, cl)
 	if err != nil || !ok {
 		return ok, err
 	}
@@ -90,7 +184,29 @@ func (pr *PoolReplica) Load(ctx context.Context, cl client.Client) (bool, error)
 	return true, nil
 }
 
-func (pr *PoolReplica) Persist(ctx context.Context, cl client.Client) error {
+// LoadEvents loads the events for this replica's PVC, most recent first.
+func (pr *PoolReplica) LoadEvents(ctx context.Context, r client.Reader) error {
+	events := &corev1.EventList{}
+	if err := r.List(
+		ctx, events,
+		client.InNamespace(pr.PersistentVolumeClaim.Key.Namespace),
+		client.MatchingFieldsSelector{Selector: fields.OneTermEqualSelector("involvedObject.uid", string(pr.PersistentVolumeClaim.Object.GetUID()))},
+	); err != nil {
+		return err
+	}
+
+	sort.Slice(events.Items, func(i, j int) bool {
+		return eventTime(&events.Items[i]).After(eventTime(&events.Items[j]))
+	})
+
+	pr.Events = events.Items
+	return nil
+}
+
+func (pr *PoolReplica) Persist(ctx context.Context, cl client.Client) (err error) {
+	ctx, span := tracing.Start(ctx, "PoolReplica.Persist", tracing.ObjectKeyAttributes(client.ObjectKeyFromObject(pr.PersistentVolumeClaim.Object))...)
+	defer func() { tracing.End(span, err) }()
+
 	pr.PersistentVolumeClaim.LabelAnnotateFrom(ctx, &pr.Pool.Object.Spec.Template.ObjectMeta)
 
 	if err := pr.Pool.Own(ctx, pr.PersistentVolumeClaim); err != nil {
@@ -100,12 +216,32 @@ func (pr *PoolReplica) Persist(ctx context.Context, cl client.Client) error {
 	switch {
 	case pr.Available():
 		// If we're in the Available phase, we can go ahead and delete the init
-		// job and not worry about it again.
-		if _, err := pr.InitJob.Delete(ctx, cl, lifecycle.DeleteWithPropagationPolicy(metav1.DeletePropagationForeground)); err != nil {
+		// job and any completed health check and not worry about them again.
+		for _, job := range []*batchv1obj.Job{pr.InitJob, pr.HealthCheckJob} {
+			if _, err := job.Delete(ctx, cl, lifecycle.DeleteWithPropagationPolicy(metav1.DeletePropagationForeground)); err != nil {
+				return err
+			}
+		}
+
+		return pr.PersistentVolumeClaim.Persist(ctx, cl)
+	case pr.Verifying():
+		if helper.Exists(pr.HealthCheckJob.Object) {
+			return pr.PersistentVolumeClaim.Persist(ctx, cl)
+		}
+
+		pr.HealthCheckJob.LabelAnnotateFrom(ctx, &pr.Pool.Object.Spec.HealthCheck.Job.Template.ObjectMeta)
+
+		if err := DependencyManager.SetDependencyOf(pr.HealthCheckJob.Object, lifecycle.TypedObject{
+			GVK:    pvpoolv1alpha1obj.PoolKind,
+			Object: pr.Pool.Object,
+		}); err != nil {
 			return err
 		}
 
-		fallthrough
+		return lifecycle.OwnershipPersister{
+			Owner:     pr.PersistentVolumeClaim,
+			Dependent: pr.HealthCheckJob,
+		}.Persist(ctx, cl)
 	case helper.Exists(pr.InitJob.Object):
 		// If the init job exists, we can't reconfigure it. Jobs are effectively
 		// immutable once they start.
@@ -138,22 +274,113 @@ func (pr *PoolReplica) Persist(ctx context.Context, cl client.Client) error {
 func (pr *PoolReplica) Stale() bool {
 	return !pr.PersistentVolumeClaim.Object.GetDeletionTimestamp().IsZero() ||
 		pr.PersistentVolumeClaim.Object.Status.Phase == corev1.ClaimLost ||
-		pr.InitJob.Failed()
+		pr.InitJob.Failed() ||
+		pr.HealthCheckJob.Failed() ||
+		pr.ProvisioningAbandoned
+}
+
+// Pending returns true if this replica's PVC exists but has not been bound.
+func (pr *PoolReplica) Pending() bool {
+	return helper.Exists(pr.PersistentVolumeClaim.Object) && pr.PersistentVolumeClaim.Object.Status.Phase == corev1.ClaimPending
+}
+
+// PendingFor returns the amount of time this replica's PVC has been pending.
+func (pr *PoolReplica) PendingFor(now time.Time) time.Duration {
+	if !pr.Pending() {
+		return 0
+	}
+
+	return now.Sub(pr.PersistentVolumeClaim.Object.GetCreationTimestamp().Time)
 }
 
 func (pr *PoolReplica) Available() bool {
 	return pr.PersistentVolume != nil && pr.PersistentVolumeClaim.Object.GetAnnotations()[PoolReplicaPhaseAnnotationKey] == PoolReplicaPhaseAnnotationValueAvailable
 }
 
+func (pr *PoolReplica) Verifying() bool {
+	return pr.PersistentVolume != nil && pr.PersistentVolumeClaim.Object.GetAnnotations()[PoolReplicaPhaseAnnotationKey] == PoolReplicaPhaseAnnotationValueVerifying
+}
+
+func (pr *PoolReplica) annotationTime(key string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339Nano, pr.PersistentVolumeClaim.Object.GetAnnotations()[key])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+// PoolGeneration returns the generation of the pool spec that this replica
+// was created from, if known.
+func (pr *PoolReplica) PoolGeneration() (int64, bool) {
+	generation, err := strconv.ParseInt(pr.PersistentVolumeClaim.Object.GetAnnotations()[PoolReplicaPoolGenerationAnnotationKey], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return generation, true
+}
+
+// VerifiedAt returns the last time this replica was known to be usable.
+func (pr *PoolReplica) VerifiedAt() (time.Time, bool) {
+	return pr.annotationTime(PoolReplicaVerifiedAtAnnotationKey)
+}
+
+// VerificationRequestedAt returns the last time this replica was chosen to be
+// verified for a waiting checkout.
+func (pr *PoolReplica) VerificationRequestedAt() (time.Time, bool) {
+	return pr.annotationTime(PoolReplicaVerificationRequestedAtAnnotationKey)
+}
+
+// NeedsVerification returns true if this replica is available and should have
+// its health check run.
+func (pr *PoolReplica) NeedsVerification(now time.Time) bool {
+	hc := pr.Pool.Object.Spec.HealthCheck
+	if hc == nil || !pr.Available() || helper.Exists(pr.HealthCheckJob.Object) {
+		return false
+	}
+
+	verifiedAt, ok := pr.VerifiedAt()
+	if !ok {
+		return true
+	}
+
+	if requestedAt, ok := pr.VerificationRequestedAt(); ok && requestedAt.After(verifiedAt) {
+		return true
+	}
+
+	return hc.Interval != nil && now.Sub(verifiedAt) >= hc.Interval.Duration
+}
+
+// NextVerification returns the amount of time until this replica should next
+// be verified, if it is to be verified periodically.
+func (pr *PoolReplica) NextVerification(now time.Time) (time.Duration, bool) {
+	hc := pr.Pool.Object.Spec.HealthCheck
+	if hc == nil || hc.Interval == nil || !pr.Available() {
+		return 0, false
+	}
+
+	verifiedAt, ok := pr.VerifiedAt()
+	if !ok {
+		return 0, true
+	}
+
+	return verifiedAt.Add(hc.Interval.Duration).Sub(now), true
+}
+
 func NewPoolReplica(p *pvpoolv1alpha1obj.Pool, key client.ObjectKey) *PoolReplica {
 	return &PoolReplica{
 		Pool:                  p,
 		PersistentVolumeClaim: corev1obj.NewPersistentVolumeClaim(key),
 		InitJob:               batchv1obj.NewJob(key),
+		HealthCheckJob: batchv1obj.NewJob(client.ObjectKey{
+			Namespace: key.Namespace,
+			Name:      norm.MetaNameSuffixed(key.Name, "-health-check"),
+		}),
 	}
 }
 
-func ConfigurePoolReplica(pr *PoolReplica) *PoolReplica {
+func ConfigurePoolReplica(pr *PoolReplica, limits pvpoolv1alpha1validation.MountJobLimits) *PoolReplica {
 	if pr.Stale() || pr.Available() {
 		return pr
 	}
@@ -183,57 +410,237 @@ func ConfigurePoolReplica(pr *PoolReplica) *PoolReplica {
 				corev1.ReadWriteOnce,
 			}
 		}
+
+		// Keep track of which version of the pool this PVC came from so
+		// checkouts can trace it back.
+		if _, ok := pvc.GetAnnotations()[PoolReplicaPoolGenerationAnnotationKey]; !ok {
+			helper.Annotate(pvc, PoolReplicaPoolGenerationAnnotationKey, strconv.FormatInt(pr.Pool.Object.GetGeneration(), 10))
+			helper.Annotate(pvc, PoolReplicaTemplateHashAnnotationKey, PoolTemplateHash(pr.Pool))
+		}
 	}
 
 	// Configure init job if it hasn't already started to run. Note that we
 	// always configure an init job because some storage classes insist use
 	// WaitForFirstConsumer which is not compatible with pooling.
 	if !pr.InitJob.Succeeded() {
-		var volumeName string
-
 		// Copy spec from template if it exists.
 		if pr.Pool.Object.Spec.InitJob != nil {
-			pr.InitJob.Object.Spec = pr.Pool.Object.Spec.InitJob.Template.Spec
-			volumeName = pr.Pool.Object.Spec.InitJob.VolumeName
+			configurePoolReplicaMountJob(pr, pr.InitJob, pr.Pool.Object.Spec.InitJob.Template.Spec, pr.Pool.Object.Spec.InitJob.VolumeName, limits)
 		} else {
-			pr.InitJob.Object.Spec = DefaultPoolReplicaInitJobSpec
-			volumeName = "workspace"
+			spec := *pvpoolv1alpha1defaults.InitJobSpec.DeepCopy()
+			spec.BackoffLimit = pointer.Int32Ptr(limits.MaxBackoffLimit)
+
+			configurePoolReplicaMountJob(pr, pr.InitJob, spec, pvpoolv1alpha1defaults.MountJobVolumeName, limits)
 		}
 
-		// Configure some of the required fields.
-		if pr.InitJob.Object.Spec.Template.Spec.RestartPolicy == "" {
-			pr.InitJob.Object.Spec.Template.Spec.RestartPolicy = pvpoolv1alpha1validation.MountJobSpecBackoffPolicy
+		// Mark PVC as initializing.
+		helper.Annotate(pr.PersistentVolumeClaim.Object, PoolReplicaPhaseAnnotationKey, PoolReplicaPhaseAnnotationValueInitializing)
+	} else {
+		helper.Annotate(pr.PersistentVolumeClaim.Object, PoolReplicaPhaseAnnotationKey, PoolReplicaPhaseAnnotationValueAvailable)
+		markPoolReplicaVerified(pr, time.Now())
+
+		helper.Annotate(pr.PersistentVolumeClaim.Object, PoolReplicaInitJobNameAnnotationKey, pr.InitJob.Key.Name)
+		if completed := pr.InitJob.Object.Status.CompletionTime; completed != nil {
+			helper.Annotate(pr.PersistentVolumeClaim.Object, PoolReplicaInitCompletedAtAnnotationKey, completed.UTC().Format(time.RFC3339))
 		}
+	}
+
+	return pr
+}
+
+// AdoptPoolReplica prepares a replica for an existing PVC that is joining the
+// pool. Unless runInitJob is set, the replica is made available immediately.
+func AdoptPoolReplica(pr *PoolReplica, runInitJob bool, limits pvpoolv1alpha1validation.MountJobLimits, now time.Time) *PoolReplica {
+	pvc := pr.PersistentVolumeClaim.Object
+
+	helper.Annotate(pvc, PoolReplicaAdoptedAtAnnotationKey, now.UTC().Format(time.RFC3339))
+	helper.Annotate(pvc, PoolReplicaPoolGenerationAnnotationKey, strconv.FormatInt(pr.Pool.Object.GetGeneration(), 10))
+
+	if runInitJob {
+		// The PVC may have been left behind by another pool, in which case we
+		// need to clear its phase to run the init job again.
+		delete(pvc.Annotations, PoolReplicaPhaseAnnotationKey)
+
+		return ConfigurePoolReplica(pr, limits)
+	}
+
+	helper.Annotate(pvc, PoolReplicaPhaseAnnotationKey, PoolReplicaPhaseAnnotationValueAvailable)
+	markPoolReplicaVerified(pr, now)
+
+	return pr
+}
+
+// Adoptable returns true if the PVC for this replica may be adopted into its
+// pool. The PVC must be bound and not controlled by anything else, and any
+// jobs that have the names the pool would use for the replica must belong to
+// the PVC so that we don't delete or collide with someone else's job. The PVC
+// must also be able to stand in for one created from the pool's PVC template;
+// see TemplateMismatch.
+func (pr *PoolReplica) Adoptable() bool {
+	return pr.unclaimed() && pr.TemplateMismatch() == ""
+}
 
-		if pr.InitJob.Object.Spec.ActiveDeadlineSeconds == nil || *pr.InitJob.Object.Spec.ActiveDeadlineSeconds > pvpoolv1alpha1validation.MountJobMaxActiveDeadlineSeconds {
-			pr.InitJob.Object.Spec.ActiveDeadlineSeconds = pointer.Int64Ptr(pvpoolv1alpha1validation.MountJobMaxActiveDeadlineSeconds)
+func (pr *PoolReplica) unclaimed() bool {
+	pvc := pr.PersistentVolumeClaim.Object
+
+	if pr.PersistentVolume == nil || !pvc.GetDeletionTimestamp().IsZero() || metav1.GetControllerOf(pvc) != nil {
+		return false
+	}
+
+	for _, job := range []*batchv1obj.Job{pr.InitJob, pr.HealthCheckJob} {
+		if helper.Exists(job.Object) && !metav1.IsControlledBy(job.Object, pvc) {
+			return false
 		}
+	}
+
+	return true
+}
 
-		if pr.InitJob.Object.Spec.BackoffLimit != nil && *pr.InitJob.Object.Spec.BackoffLimit > pvpoolv1alpha1validation.MountJobMaxBackoffLimit {
-			pr.InitJob.Object.Spec.BackoffLimit = pointer.Int32Ptr(pvpoolv1alpha1validation.MountJobMaxBackoffLimit)
+// TemplateMismatch compares the PVC for this replica with the pool's PVC
+// template. It returns a description of the first difference that would make
+// the PVC unsuitable as a replica, or an empty string if there is none.
+//
+// The storage class, access modes, and volume mode must match those in the
+// template when the template sets them, and the capacity of the PVC must be at
+// least the template's storage request.
+func (pr *PoolReplica) TemplateMismatch() string {
+	tpl := &pr.Pool.Object.Spec.Template.Spec
+	pvc := pr.PersistentVolumeClaim.Object
+
+	if tpl.StorageClassName != nil {
+		if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName != *tpl.StorageClassName {
+			return fmt.Sprintf("storage class %q does not match %q", pointer.StringPtrDerefOr(pvc.Spec.StorageClassName, ""), *tpl.StorageClassName)
 		}
+	}
+
+	if len(tpl.AccessModes) > 0 && !sameAccessModes(pvc.Spec.AccessModes, tpl.AccessModes) {
+		return fmt.Sprintf("access modes %v do not match %v", pvc.Spec.AccessModes, tpl.AccessModes)
+	}
+
+	if actual, expected := volumeModeOrDefault(pvc.Spec.VolumeMode), volumeModeOrDefault(tpl.VolumeMode); actual != expected {
+		return fmt.Sprintf("volume mode %s does not match %s", actual, expected)
+	}
 
-		// Set up volume.
-		vols := &pr.InitJob.Object.Spec.Template.Spec.Volumes
-		volIdx := indexVolumeByName(*vols, volumeName)
-		if volIdx < 0 {
-			volIdx = len(*vols)
-			*vols = append(*vols, corev1.Volume{Name: volumeName})
+	if request, ok := tpl.Resources.Requests[corev1.ResourceStorage]; ok {
+		capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]
+		if !ok {
+			capacity = pvc.Spec.Resources.Requests[corev1.ResourceStorage]
 		}
-		(*vols)[volIdx].PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
-			ClaimName: pr.PersistentVolumeClaim.Key.Name,
+
+		if capacity.Cmp(request) < 0 {
+			return fmt.Sprintf("capacity %s is less than the requested %s", capacity.String(), request.String())
 		}
+	}
 
-		// Mark PVC as initializing.
-		helper.Annotate(pr.PersistentVolumeClaim.Object, PoolReplicaPhaseAnnotationKey, PoolReplicaPhaseAnnotationValueInitializing)
-	} else {
+	return ""
+}
+
+func sameAccessModes(a, b []corev1.PersistentVolumeAccessMode) bool {
+	set := make(map[corev1.PersistentVolumeAccessMode]struct{}, len(a))
+	for _, mode := range a {
+		set[mode] = struct{}{}
+	}
+
+	other := make(map[corev1.PersistentVolumeAccessMode]struct{}, len(b))
+	for _, mode := range b {
+		if _, ok := set[mode]; !ok {
+			return false
+		}
+		other[mode] = struct{}{}
+	}
+
+	return len(set) == len(other)
+}
+
+func volumeModeOrDefault(mode *corev1.PersistentVolumeMode) corev1.PersistentVolumeMode {
+	if mode == nil {
+		return corev1.PersistentVolumeFilesystem
+	}
+	return *mode
+}
+
+// ConfigurePoolReplicaHealthCheck moves an available replica into the
+// verifying phase if it is due for a health check and moves a verifying replica
+// back to the available phase once its health check succeeds.
+func ConfigurePoolReplicaHealthCheck(pr *PoolReplica, limits pvpoolv1alpha1validation.MountJobLimits, now time.Time) *PoolReplica {
+	hc := pr.Pool.Object.Spec.HealthCheck
+
+	switch {
+	case pr.Stale():
+	case pr.Verifying() && pr.HealthCheckJob.Succeeded():
+		helper.Annotate(pr.PersistentVolumeClaim.Object, PoolReplicaPhaseAnnotationKey, PoolReplicaPhaseAnnotationValueAvailable)
+		markPoolReplicaVerified(pr, now)
+	case pr.Verifying() && hc == nil:
+		// The health check was removed from the pool while this replica was
+		// being verified.
 		helper.Annotate(pr.PersistentVolumeClaim.Object, PoolReplicaPhaseAnnotationKey, PoolReplicaPhaseAnnotationValueAvailable)
+	case pr.Verifying() && !helper.Exists(pr.HealthCheckJob.Object), pr.NeedsVerification(now):
+		configurePoolReplicaMountJob(pr, pr.HealthCheckJob, hc.Job.Template.Spec, hc.Job.VolumeName, limits)
+
+		helper.Annotate(pr.PersistentVolumeClaim.Object, PoolReplicaPhaseAnnotationKey, PoolReplicaPhaseAnnotationValueVerifying)
 	}
 
 	return pr
 }
 
-func ApplyPoolReplica(ctx context.Context, cl client.Client, p *pvpoolv1alpha1obj.Pool, id string) (*PoolReplica, error) {
+func markPoolReplicaVerified(pr *PoolReplica, now time.Time) {
+	helper.Annotate(pr.PersistentVolumeClaim.Object, PoolReplicaVerifiedAtAnnotationKey, now.UTC().Format(time.RFC3339Nano))
+}
+
+func configurePoolReplicaMountJob(pr *PoolReplica, job *batchv1obj.Job, spec batchv1.JobSpec, volumeName string, limits pvpoolv1alpha1validation.MountJobLimits) {
+	// We make a deep copy because we modify the containers and volumes below.
+	spec.DeepCopyInto(&job.Object.Spec)
+
+	// The webhook sets these defaults when the pool is admitted, but pools
+	// stored before it existed may not have them. Values that exceed the
+	// limits are reported as a policy violation on the pool instead.
+	pvpoolv1alpha1defaults.DefaultMountJobSpec(&job.Object.Spec, limits)
+
+	// Tell the job which replica it's working on.
+	configurePoolReplicaIdentity(pr, &job.Object.Spec.Template)
+
+	// Set up volume.
+	vols := &job.Object.Spec.Template.Spec.Volumes
+	volIdx := indexVolumeByName(*vols, volumeName)
+	if volIdx < 0 {
+		volIdx = len(*vols)
+		*vols = append(*vols, corev1.Volume{Name: volumeName})
+	}
+	(*vols)[volIdx].VolumeSource = corev1.VolumeSource{
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: pr.PersistentVolumeClaim.Key.Name,
+		},
+	}
+}
+
+func configurePoolReplicaIdentity(pr *PoolReplica, tpl *corev1.PodTemplateSpec) {
+	identity := []struct {
+		LabelKey string
+		EnvVar   string
+		Value    string
+	}{
+		{PoolReplicaPoolNameLabelKey, PoolReplicaPoolNameEnvVar, pr.Pool.Key.Name},
+		{PoolReplicaPoolNamespaceLabelKey, PoolReplicaPoolNamespaceEnvVar, pr.Pool.Key.Namespace},
+		{PoolReplicaPoolGenerationLabelKey, PoolReplicaPoolGenerationEnvVar, strconv.FormatInt(pr.Pool.Object.GetGeneration(), 10)},
+		{PoolReplicaClaimNameLabelKey, PoolReplicaClaimNameEnvVar, pr.PersistentVolumeClaim.Key.Name},
+	}
+
+	for _, id := range identity {
+		// Some names (e.g., the pool name) can be longer than a label value
+		// allows. They're still available in the environment.
+		if id.Value != "" && len(validation.IsValidLabelValue(id.Value)) == 0 {
+			helper.Label(tpl, id.LabelKey, id.Value)
+		}
+
+		for _, containers := range [][]corev1.Container{tpl.Spec.InitContainers, tpl.Spec.Containers} {
+			for i := range containers {
+				setEnvVar(&containers[i], corev1.EnvVar{Name: id.EnvVar, Value: id.Value})
+			}
+		}
+	}
+}
+
+func ApplyPoolReplica(ctx context.Context, cl client.Client, p *pvpoolv1alpha1obj.Pool, limits pvpoolv1alpha1validation.MountJobLimits, id string) (*PoolReplica, error) {
 	key := client.ObjectKey{
 		Namespace: p.Key.Namespace,
 		Name:      norm.MetaNameSuffixed(p.Key.Name, fmt.Sprintf("-%s", id)),
@@ -245,7 +652,7 @@ func ApplyPoolReplica(ctx context.Context, cl client.Client, p *pvpoolv1alpha1ob
 		return nil, err
 	}
 
-	pr = ConfigurePoolReplica(pr)
+	pr = ConfigurePoolReplica(pr, limits)
 
 	if err := pr.Persist(ctx, cl); err != nil {
 		return nil, err
@@ -274,6 +681,17 @@ func (prs *PoolReplicas) Pop(rng rand.Rand) (*PoolReplica, bool, error) {
 	return pr, true, nil
 }
 
+func setEnvVar(container *corev1.Container, ev corev1.EnvVar) {
+	for i := range container.Env {
+		if container.Env[i].Name == ev.Name {
+			container.Env[i] = ev
+			return
+		}
+	}
+
+	container.Env = append(container.Env, ev)
+}
+
 func indexVolumeByName(vols []corev1.Volume, name string) int {
 	for i := range vols {
 		if vols[i].Name == name {
@@ -293,3 +711,14 @@ func (prs PoolReplicasSortByCreationTimestamp) Swap(i, j int) { prs[i], prs[j] =
 func (prs PoolReplicasSortByCreationTimestamp) Less(i, j int) bool {
 	return prs[i].PersistentVolumeClaim.Object.CreationTimestamp.Before(&prs[j].PersistentVolumeClaim.Object.CreationTimestamp)
 }
+
+func eventTime(ev *corev1.Event) time.Time {
+	switch {
+	case !ev.EventTime.IsZero():
+		return ev.EventTime.Time
+	case !ev.LastTimestamp.IsZero():
+		return ev.LastTimestamp.Time
+	default:
+		return ev.GetCreationTimestamp().Time
+	}
+}
@@ -0,0 +1,447 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1obj "github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/api/corev1"
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
+	pvpoolv1alpha1validation "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newAdoptionTestPool() *pvpoolv1alpha1.Pool {
+	return &pvpoolv1alpha1.Pool{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "test-pool",
+			UID:       "pool",
+		},
+		Spec: pvpoolv1alpha1.PoolSpec{
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"pool": "test-pool"},
+			},
+			Template: pvpoolv1alpha1.PersistentVolumeClaimTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"pool": "test-pool"},
+				},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					StorageClassName: pointer.StringPtr("fast"),
+					AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse("1Gi"),
+						},
+					},
+				},
+			},
+			Adoption: &pvpoolv1alpha1.PoolAdoption{
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"migrate": "true"},
+				},
+			},
+		},
+	}
+}
+
+func newAdoptionTestClaim(name string) (*corev1.PersistentVolumeClaim, *corev1.PersistentVolume) {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "test",
+			Name:              name,
+			UID:               types.UID(name),
+			Labels:            map[string]string{"migrate": "true"},
+			CreationTimestamp: metav1.Now(),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: pointer.StringPtr("fast"),
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("1Gi"),
+				},
+			},
+			VolumeName: "pv-" + name,
+		},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Phase: corev1.ClaimBound,
+			Capacity: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse("1Gi"),
+			},
+		},
+	}
+
+	pv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: pvc.Spec.VolumeName,
+			UID:  types.UID(pvc.Spec.VolumeName),
+		},
+		Spec: corev1.PersistentVolumeSpec{
+			ClaimRef: &corev1.ObjectReference{
+				Namespace: pvc.GetNamespace(),
+				Name:      pvc.GetName(),
+				UID:       pvc.GetUID(),
+			},
+		},
+	}
+
+	return pvc, pv
+}
+
+func TestPoolReplicaAdoptable(t *testing.T) {
+	tests := []struct {
+		Name         string
+		Setup        func(pr *PoolReplica)
+		Expected     bool
+		ExpectedDiff bool
+	}{
+		{
+			Name:     "Matches template",
+			Setup:    func(pr *PoolReplica) {},
+			Expected: true,
+		},
+		{
+			Name: "Not bound",
+			Setup: func(pr *PoolReplica) {
+				pr.PersistentVolume = nil
+			},
+		},
+		{
+			Name: "Being deleted",
+			Setup: func(pr *PoolReplica) {
+				now := metav1.Now()
+				pr.PersistentVolumeClaim.Object.SetDeletionTimestamp(&now)
+			},
+		},
+		{
+			Name: "Controlled by another object",
+			Setup: func(pr *PoolReplica) {
+				pr.PersistentVolumeClaim.Object.SetOwnerReferences([]metav1.OwnerReference{
+					{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "test", UID: "sts", Controller: pointer.BoolPtr(true)},
+				})
+			},
+		},
+		{
+			Name: "Init job belongs to someone else",
+			Setup: func(pr *PoolReplica) {
+				pr.InitJob.Object = &batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: pr.InitJob.Key.Name, UID: "job"},
+				}
+			},
+		},
+		{
+			Name: "Different storage class",
+			Setup: func(pr *PoolReplica) {
+				pr.PersistentVolumeClaim.Object.Spec.StorageClassName = pointer.StringPtr("slow")
+			},
+			ExpectedDiff: true,
+		},
+		{
+			Name: "No storage class",
+			Setup: func(pr *PoolReplica) {
+				pr.PersistentVolumeClaim.Object.Spec.StorageClassName = nil
+			},
+			ExpectedDiff: true,
+		},
+		{
+			Name: "Template without storage class",
+			Setup: func(pr *PoolReplica) {
+				pr.Pool.Object.Spec.Template.Spec.StorageClassName = nil
+				pr.PersistentVolumeClaim.Object.Spec.StorageClassName = pointer.StringPtr("slow")
+			},
+			Expected: true,
+		},
+		{
+			Name: "Different access modes",
+			Setup: func(pr *PoolReplica) {
+				pr.PersistentVolumeClaim.Object.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}
+			},
+			ExpectedDiff: true,
+		},
+		{
+			Name: "Additional access modes",
+			Setup: func(pr *PoolReplica) {
+				pr.PersistentVolumeClaim.Object.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce, corev1.ReadOnlyMany}
+			},
+			ExpectedDiff: true,
+		},
+		{
+			Name: "Different volume mode",
+			Setup: func(pr *PoolReplica) {
+				mode := corev1.PersistentVolumeBlock
+				pr.PersistentVolumeClaim.Object.Spec.VolumeMode = &mode
+			},
+			ExpectedDiff: true,
+		},
+		{
+			Name: "Explicit default volume mode",
+			Setup: func(pr *PoolReplica) {
+				mode := corev1.PersistentVolumeFilesystem
+				pr.PersistentVolumeClaim.Object.Spec.VolumeMode = &mode
+			},
+			Expected: true,
+		},
+		{
+			Name: "Smaller capacity",
+			Setup: func(pr *PoolReplica) {
+				pr.PersistentVolumeClaim.Object.Status.Capacity[corev1.ResourceStorage] = resource.MustParse("512Mi")
+			},
+			ExpectedDiff: true,
+		},
+		{
+			Name: "Larger capacity",
+			Setup: func(pr *PoolReplica) {
+				pr.PersistentVolumeClaim.Object.Status.Capacity[corev1.ResourceStorage] = resource.MustParse("10Gi")
+			},
+			Expected: true,
+		},
+		{
+			Name: "Capacity from request",
+			Setup: func(pr *PoolReplica) {
+				pr.PersistentVolumeClaim.Object.Status.Capacity = nil
+				pr.PersistentVolumeClaim.Object.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("512Mi")
+			},
+			ExpectedDiff: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			pvc, pv := newAdoptionTestClaim("test")
+
+			pr := NewPoolReplica(pvpoolv1alpha1obj.NewPoolFromObject(newAdoptionTestPool()), client.ObjectKeyFromObject(pvc))
+			pr.PersistentVolumeClaim = corev1obj.NewPersistentVolumeClaimFromObject(pvc)
+			pr.PersistentVolume = corev1obj.NewPersistentVolumeFromObject(pv)
+			test.Setup(pr)
+
+			assert.Equal(t, test.Expected, pr.Adoptable())
+			assert.Equal(t, test.ExpectedDiff, pr.TemplateMismatch() != "", "mismatch: %q", pr.TemplateMismatch())
+		})
+	}
+}
+
+func TestPoolStateLoadAdoptable(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, batchv1.AddToScheme(scheme))
+	require.NoError(t, pvpoolv1alpha1.AddToScheme(scheme))
+
+	pool := newAdoptionTestPool()
+
+	older, olderPV := newAdoptionTestClaim("older")
+	older.CreationTimestamp = metav1.NewTime(older.CreationTimestamp.Add(-time.Hour))
+
+	newer, newerPV := newAdoptionTestClaim("newer")
+
+	small, smallPV := newAdoptionTestClaim("small")
+	small.Status.Capacity[corev1.ResourceStorage] = resource.MustParse("512Mi")
+
+	unbound, _ := newAdoptionTestClaim("unbound")
+	unbound.Status.Phase = corev1.ClaimPending
+
+	replica, replicaPV := newAdoptionTestClaim("replica")
+	replica.Labels["pool"] = "test-pool"
+
+	unlabeled, unlabeledPV := newAdoptionTestClaim("unlabeled")
+	delete(unlabeled.Labels, "migrate")
+
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			pool,
+			older, olderPV,
+			newer, newerPV,
+			small, smallPV,
+			unbound,
+			replica, replicaPV,
+			unlabeled, unlabeledPV,
+		).
+		Build()
+
+	ps := NewPoolState(pvpoolv1alpha1obj.NewPoolFromObject(pool))
+	require.NoError(t, ps.loadAdoptable(ctx, cl))
+
+	var names []string
+	for _, pr := range ps.Adoptable {
+		names = append(names, pr.PersistentVolumeClaim.Key.Name)
+	}
+	assert.Equal(t, []string{"older", "newer"}, names)
+}
+
+func TestConfigurePoolReplicaHealthCheck(t *testing.T) {
+	now := time.Now()
+	longAgo := now.Add(-2 * time.Hour)
+	recently := now.Add(-time.Minute)
+
+	healthCheck := func(interval time.Duration) *pvpoolv1alpha1.PoolHealthCheck {
+		hc := &pvpoolv1alpha1.PoolHealthCheck{
+			Job: pvpoolv1alpha1.MountJob{
+				Template: pvpoolv1alpha1.JobTemplate{
+					Spec: batchv1.JobSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{{Name: "check", Image: "busybox"}},
+							},
+						},
+					},
+				},
+				VolumeName: "workspace",
+			},
+		}
+		if interval > 0 {
+			hc.Interval = &metav1.Duration{Duration: interval}
+		}
+		return hc
+	}
+	jobWithCondition := func(typ batchv1.JobConditionType) func(pr *PoolReplica) {
+		return func(pr *PoolReplica) {
+			pr.HealthCheckJob.Object.SetUID("job")
+			pr.HealthCheckJob.Object.Status.Conditions = []batchv1.JobCondition{
+				{Type: typ, Status: corev1.ConditionTrue},
+			}
+		}
+	}
+
+	tests := []struct {
+		Name                  string
+		HealthCheck           *pvpoolv1alpha1.PoolHealthCheck
+		Phase                 string
+		VerifiedAt            *time.Time
+		RequestedAt           *time.Time
+		Setup                 func(pr *PoolReplica)
+		ExpectedPhase         string
+		ExpectedVerified      bool
+		ExpectedJobConfigured bool
+	}{
+		{
+			Name:          "No health check",
+			Phase:         PoolReplicaPhaseAnnotationValueAvailable,
+			VerifiedAt:    &longAgo,
+			ExpectedPhase: PoolReplicaPhaseAnnotationValueAvailable,
+		},
+		{
+			Name:          "Not due",
+			HealthCheck:   healthCheck(time.Hour),
+			Phase:         PoolReplicaPhaseAnnotationValueAvailable,
+			VerifiedAt:    &recently,
+			ExpectedPhase: PoolReplicaPhaseAnnotationValueAvailable,
+		},
+		{
+			Name:                  "Interval elapsed",
+			HealthCheck:           healthCheck(time.Hour),
+			Phase:                 PoolReplicaPhaseAnnotationValueAvailable,
+			VerifiedAt:            &longAgo,
+			ExpectedPhase:         PoolReplicaPhaseAnnotationValueVerifying,
+			ExpectedJobConfigured: true,
+		},
+		{
+			Name:                  "Never verified",
+			HealthCheck:           healthCheck(0),
+			Phase:                 PoolReplicaPhaseAnnotationValueAvailable,
+			ExpectedPhase:         PoolReplicaPhaseAnnotationValueVerifying,
+			ExpectedJobConfigured: true,
+		},
+		{
+			Name:                  "Requested for a checkout",
+			HealthCheck:           healthCheck(0),
+			Phase:                 PoolReplicaPhaseAnnotationValueAvailable,
+			VerifiedAt:            &longAgo,
+			RequestedAt:           &recently,
+			ExpectedPhase:         PoolReplicaPhaseAnnotationValueVerifying,
+			ExpectedJobConfigured: true,
+		},
+		{
+			Name:          "Request already satisfied",
+			HealthCheck:   healthCheck(0),
+			Phase:         PoolReplicaPhaseAnnotationValueAvailable,
+			VerifiedAt:    &recently,
+			RequestedAt:   &longAgo,
+			ExpectedPhase: PoolReplicaPhaseAnnotationValueAvailable,
+		},
+		{
+			Name:             "Health check succeeded",
+			HealthCheck:      healthCheck(time.Hour),
+			Phase:            PoolReplicaPhaseAnnotationValueVerifying,
+			VerifiedAt:       &longAgo,
+			Setup:            jobWithCondition(batchv1.JobComplete),
+			ExpectedPhase:    PoolReplicaPhaseAnnotationValueAvailable,
+			ExpectedVerified: true,
+		},
+		{
+			Name:          "Health check running",
+			HealthCheck:   healthCheck(time.Hour),
+			Phase:         PoolReplicaPhaseAnnotationValueVerifying,
+			VerifiedAt:    &longAgo,
+			Setup:         func(pr *PoolReplica) { pr.HealthCheckJob.Object.SetUID("job") },
+			ExpectedPhase: PoolReplicaPhaseAnnotationValueVerifying,
+		},
+		{
+			Name:          "Health check failed",
+			HealthCheck:   healthCheck(time.Hour),
+			Phase:         PoolReplicaPhaseAnnotationValueVerifying,
+			VerifiedAt:    &longAgo,
+			Setup:         jobWithCondition(batchv1.JobFailed),
+			ExpectedPhase: PoolReplicaPhaseAnnotationValueVerifying,
+		},
+		{
+			Name:                  "Health check job missing",
+			HealthCheck:           healthCheck(time.Hour),
+			Phase:                 PoolReplicaPhaseAnnotationValueVerifying,
+			VerifiedAt:            &longAgo,
+			ExpectedPhase:         PoolReplicaPhaseAnnotationValueVerifying,
+			ExpectedJobConfigured: true,
+		},
+		{
+			Name:          "Health check removed while verifying",
+			Phase:         PoolReplicaPhaseAnnotationValueVerifying,
+			VerifiedAt:    &longAgo,
+			ExpectedPhase: PoolReplicaPhaseAnnotationValueAvailable,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			pvc, pv := newAdoptionTestClaim("test")
+
+			annotations := map[string]string{PoolReplicaPhaseAnnotationKey: test.Phase}
+			if test.VerifiedAt != nil {
+				annotations[PoolReplicaVerifiedAtAnnotationKey] = test.VerifiedAt.UTC().Format(time.RFC3339Nano)
+			}
+			if test.RequestedAt != nil {
+				annotations[PoolReplicaVerificationRequestedAtAnnotationKey] = test.RequestedAt.UTC().Format(time.RFC3339Nano)
+			}
+			pvc.SetAnnotations(annotations)
+
+			pool := newAdoptionTestPool()
+			pool.Spec.HealthCheck = test.HealthCheck
+
+			pr := NewPoolReplica(pvpoolv1alpha1obj.NewPoolFromObject(pool), client.ObjectKeyFromObject(pvc))
+			pr.PersistentVolumeClaim = corev1obj.NewPersistentVolumeClaimFromObject(pvc)
+			pr.PersistentVolume = corev1obj.NewPersistentVolumeFromObject(pv)
+			if test.Setup != nil {
+				test.Setup(pr)
+			}
+
+			pr = ConfigurePoolReplicaHealthCheck(pr, pvpoolv1alpha1validation.DefaultMountJobLimits, now)
+
+			assert.Equal(t, test.ExpectedPhase, pr.PersistentVolumeClaim.Object.GetAnnotations()[PoolReplicaPhaseAnnotationKey])
+
+			verifiedAt, _ := pr.VerifiedAt()
+			assert.Equal(t, test.ExpectedVerified, verifiedAt.Equal(now))
+
+			assert.Equal(t, test.ExpectedJobConfigured, len(pr.HealthCheckJob.Object.Spec.Template.Spec.Containers) > 0)
+		})
+	}
+}
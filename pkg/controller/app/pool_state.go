@@ -4,17 +4,27 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/puppetlabs/leg/errmap/pkg/errmark"
 	"github.com/puppetlabs/leg/k8sutil/pkg/controller/eventctx"
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/helper"
 	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
 	"github.com/puppetlabs/leg/mathutil/pkg/rand"
 	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
 	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
+	pvpoolv1alpha1validation "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/validation"
+	"github.com/puppetlabs/pvpool/pkg/controller/metrics"
+	"github.com/puppetlabs/pvpool/pkg/tracing"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -23,12 +33,43 @@ type PoolState struct {
 	Pool         *pvpoolv1alpha1obj.Pool
 	Initializing PoolReplicas
 	Available    PoolReplicas
+	Verifying    PoolReplicas
 	Stale        PoolReplicas
 
+	// Deleted are the stale replicas that were deleted when the state was
+	// last persisted. They are kept so that the pool status can still say why
+	// they were removed.
+	Deleted PoolReplicas
+
+	// Adoptable are existing PVCs that match the pool's adoption selector and
+	// may be adopted as replicas, oldest first.
+	Adoptable PoolReplicas
+
+	// Policies are the cluster-wide policies that this pool must satisfy.
+	Policies []pvpoolv1alpha1.PoolPolicy
+
+	// NamespaceStorage is the total storage requested by every other pool in
+	// this pool's namespace.
+	NamespaceStorage resource.Quantity
+
+	// HealthCheckRequestedAt is the creation time of the oldest checkout that
+	// is waiting for one of this pool's replicas to pass a health check. It is
+	// zero if no checkout is waiting.
+	HealthCheckRequestedAt time.Time
+
+	// EventReader, if set, is used to load the events for PVCs that have
+	// stalled during provisioning. It should not be backed by a cache.
+	EventReader client.Reader
+
 	// Conds represent status updates for given conditions.
 	Conds map[pvpoolv1alpha1.PoolConditionType]pvpoolv1alpha1.Condition
 }
 
+// DefaultPoolProvisioningStalledAfter is the amount of time a PVC may remain
+// pending before we report that provisioning has stalled if the pool doesn't
+// say otherwise.
+const DefaultPoolProvisioningStalledAfter = 5 * time.Minute
+
 var _ lifecycle.Deleter = &PoolState{}
 var _ lifecycle.Loader = &PoolState{}
 var _ lifecycle.Persister = &PoolState{}
@@ -39,7 +80,7 @@ func (ps *PoolState) Delete(ctx context.Context, cl client.Client, opts ...lifec
 		return false, err
 	}
 
-	for _, prs := range []*PoolReplicas{&ps.Initializing, &ps.Available, &ps.Stale} {
+	for _, prs := range []*PoolReplicas{&ps.Initializing, &ps.Verifying, &ps.Available, &ps.Stale} {
 		for {
 			pr, found, err := prs.Pop(rng)
 			if err != nil {
@@ -59,7 +100,72 @@ func (ps *PoolState) Delete(ctx context.Context, cl client.Client, opts ...lifec
 	return true, nil
 }
 
-func (ps *PoolState) Load(ctx context.Context, cl client.Client) (bool, error) {
+// Finalize releases the replicas in this pool according to the pool's deletion
+// policy.
+func (ps *PoolState) Finalize(ctx context.Context, cl client.Client) (err error) {
+	ctx, span := tracing.Start(ctx, "PoolState.Finalize", tracing.ObjectKeyAttributes(client.ObjectKeyFromObject(ps.Pool.Object))...)
+	defer func() { tracing.End(span, err) }()
+
+	switch ps.Pool.Object.Spec.DeletionPolicy {
+	case pvpoolv1alpha1.PoolDeletionPolicyOrphan:
+		// Stale replicas are of no use to anyone, so we always delete them.
+		for _, prs := range []*PoolReplicas{&ps.Initializing, &ps.Verifying, &ps.Available} {
+			for len(*prs) > 0 {
+				if err := (*prs)[0].Orphan(ctx, cl); err != nil {
+					return err
+				}
+				*prs = (*prs)[1:]
+			}
+		}
+
+		return ps.persistStale(ctx, cl)
+	case pvpoolv1alpha1.PoolDeletionPolicyRetain:
+		for _, prs := range []*PoolReplicas{&ps.Initializing, &ps.Verifying, &ps.Available, &ps.Stale} {
+			for len(*prs) > 0 {
+				if err := (*prs)[0].Retain(ctx, cl); err != nil {
+					return err
+				}
+				*prs = (*prs)[1:]
+			}
+		}
+
+		return nil
+	default:
+		_, err := ps.Delete(ctx, cl)
+		return err
+	}
+}
+
+func (ps *PoolState) loadPolicies(ctx context.Context, cl client.Client) error {
+	policies := &pvpoolv1alpha1.PoolPolicyList{}
+	if err := cl.List(ctx, policies); err != nil {
+		return err
+	}
+
+	ps.Policies = policies.Items
+	ps.NamespaceStorage = resource.Quantity{}
+
+	// Only bother computing namespace usage if some policy needs it.
+	if pvpoolv1alpha1validation.PoliciesLimitNamespaceStorage(ps.Policies) {
+		pools := &pvpoolv1alpha1.PoolList{}
+		if err := cl.List(ctx, pools, client.InNamespace(ps.Pool.Key.Namespace)); err != nil {
+			return err
+		}
+
+		ps.NamespaceStorage = pvpoolv1alpha1validation.NamespaceStorageRequest(pools.Items, ps.Pool.Key.Name)
+	}
+
+	return nil
+}
+
+func (ps *PoolState) Load(ctx context.Context, cl client.Client) (ok bool, err error) {
+	ctx, span := tracing.Start(ctx, "PoolState.Load", tracing.ObjectKeyAttributes(client.ObjectKeyFromObject(ps.Pool.Object))...)
+	defer func() { tracing.End(span, err) }()
+
+	if err := ps.loadPolicies(ctx, cl); err != nil {
+		return false, err
+	}
+
 	labelSelector, err := metav1.LabelSelectorAsSelector(&ps.Pool.Object.Spec.Selector)
 	if err != nil {
 		return false, err
@@ -76,7 +182,9 @@ func (ps *PoolState) Load(ctx context.Context, cl client.Client) (bool, error) {
 
 	ps.Initializing = nil
 	ps.Available = nil
+	ps.Verifying = nil
 	ps.Stale = nil
+	ps.Deleted = nil
 	for i := range pvcs.Items {
 		pr := NewPoolReplica(ps.Pool, client.ObjectKeyFromObject(&pvcs.Items[i]))
 		ok, err := pr.Load(ctx, cl)
@@ -100,15 +208,129 @@ func (ps *PoolState) Load(ctx context.Context, cl client.Client) (bool, error) {
 		case pr.Available():
 			klog.V(4).InfoS("pool state: load: replica is available", "pvc", pr.PersistentVolumeClaim.Key)
 			ps.Available = append(ps.Available, pr)
+		case pr.Verifying():
+			klog.V(4).InfoS("pool state: load: replica is verifying", "pvc", pr.PersistentVolumeClaim.Key)
+			ps.Verifying = append(ps.Verifying, pr)
 		default:
 			klog.V(4).InfoS("pool state: load: replica is initializing", "pvc", pr.PersistentVolumeClaim.Key)
 			ps.Initializing = append(ps.Initializing, pr)
 		}
 	}
 
+	if err := ps.loadAdoptable(ctx, cl); err != nil {
+		return false, err
+	}
+
+	// Find out why stalled PVCs haven't been provisioned.
+	if ps.EventReader != nil {
+		now := time.Now()
+		for _, pr := range ps.Initializing {
+			if pr.PendingFor(now) < ps.ProvisioningStalledAfter() {
+				continue
+			}
+
+			if err := pr.LoadEvents(ctx, ps.EventReader); err != nil {
+				return false, err
+			}
+		}
+	}
+
 	return true, nil
 }
 
+// LoadHealthCheckRequests finds checkouts that are waiting for one of this
+// pool's replicas to pass a health check before they take it. The client must
+// index checkouts by CheckoutPoolRefIndexField.
+func (ps *PoolState) LoadHealthCheckRequests(ctx context.Context, cl client.Client) (err error) {
+	ctx, span := tracing.Start(ctx, "PoolState.LoadHealthCheckRequests", tracing.ObjectKeyAttributes(client.ObjectKeyFromObject(ps.Pool.Object))...)
+	defer func() { tracing.End(span, err) }()
+
+	ps.HealthCheckRequestedAt = time.Time{}
+
+	if hc := ps.Pool.Object.Spec.HealthCheck; hc == nil || !hc.BeforeCheckout {
+		return nil
+	}
+
+	for _, value := range poolCheckoutPoolRefIndexValues(ps.Pool.Object) {
+		checkouts := &pvpoolv1alpha1.CheckoutList{}
+		if err := cl.List(ctx, checkouts, client.MatchingFields{CheckoutPoolRefIndexField: value}); err != nil {
+			return err
+		}
+
+		for i := range checkouts.Items {
+			co := &checkouts.Items[i]
+			if !CheckoutWaitingForHealthCheck(co) || !CheckoutRefersToPool(co, ps.Pool.Object) {
+				continue
+			}
+
+			if createdAt := co.GetCreationTimestamp().Time; ps.HealthCheckRequestedAt.IsZero() || createdAt.Before(ps.HealthCheckRequestedAt) {
+				ps.HealthCheckRequestedAt = createdAt
+			}
+		}
+	}
+
+	return nil
+}
+
+func (ps *PoolState) loadAdoptable(ctx context.Context, cl client.Client) error {
+	ps.Adoptable = nil
+
+	pa := ps.Pool.Object.Spec.Adoption
+	if pa == nil {
+		return nil
+	}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(&ps.Pool.Object.Spec.Selector)
+	if err != nil {
+		return err
+	}
+
+	adoptionSelector, err := metav1.LabelSelectorAsSelector(&pa.Selector)
+	if err != nil {
+		return err
+	}
+
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	if err := cl.List(
+		ctx, pvcs,
+		client.InNamespace(ps.Pool.Key.Namespace),
+		client.MatchingLabelsSelector{Selector: adoptionSelector},
+	); err != nil {
+		return err
+	}
+
+	for i := range pvcs.Items {
+		// PVCs matching the pool's own selector were handled when we loaded
+		// the replicas.
+		if labelSelector.Matches(labels.Set(pvcs.Items[i].GetLabels())) {
+			continue
+		}
+
+		pr := NewPoolReplica(ps.Pool, client.ObjectKeyFromObject(&pvcs.Items[i]))
+		if ok, err := pr.Load(ctx, cl); err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+
+		if !pr.unclaimed() {
+			klog.V(4).InfoS("pool state: load: PVC is not adoptable", "pool", ps.Pool.Key, "pvc", pr.PersistentVolumeClaim.Key)
+			continue
+		}
+
+		if mismatch := pr.TemplateMismatch(); mismatch != "" {
+			klog.V(4).InfoS("pool state: load: PVC does not match template", "pool", ps.Pool.Key, "pvc", pr.PersistentVolumeClaim.Key, "mismatch", mismatch)
+			eventctx.EventRecorder(ctx).Eventf(ps.Pool.Object, "Warning", "AdoptionSkipped", "Not adopting PVC %s because it does not match the PVC template: %s", pr.PersistentVolumeClaim.Key.Name, mismatch)
+			continue
+		}
+
+		ps.Adoptable = append(ps.Adoptable, pr)
+	}
+
+	sort.Sort(PoolReplicasSortByCreationTimestamp(ps.Adoptable))
+	return nil
+}
+
 func (ps *PoolState) persistInitializing(ctx context.Context, cl client.Client) error {
 	for i := 0; i < len(ps.Initializing); {
 		if err := ps.Initializing[i].Persist(ctx, cl); err != nil {
@@ -117,6 +339,8 @@ func (ps *PoolState) persistInitializing(ctx context.Context, cl client.Client)
 
 		// Move initializing PVCs to available if possible.
 		if ps.Initializing[i].Available() {
+			metrics.ObserveInitJob(ps.Pool.Key, ps.Initializing[i].InitJob.Object)
+
 			ps.Available = append(ps.Available, ps.Initializing[i])
 			ps.Initializing[i] = ps.Initializing[len(ps.Initializing)-1]
 			ps.Initializing = ps.Initializing[:len(ps.Initializing)-1]
@@ -128,6 +352,25 @@ func (ps *PoolState) persistInitializing(ctx context.Context, cl client.Client)
 	return nil
 }
 
+func (ps *PoolState) persistVerifying(ctx context.Context, cl client.Client) error {
+	for i := 0; i < len(ps.Verifying); {
+		if err := ps.Verifying[i].Persist(ctx, cl); err != nil {
+			return err
+		}
+
+		// Move verified PVCs back to available.
+		if ps.Verifying[i].Available() {
+			ps.Available = append(ps.Available, ps.Verifying[i])
+			ps.Verifying[i] = ps.Verifying[len(ps.Verifying)-1]
+			ps.Verifying = ps.Verifying[:len(ps.Verifying)-1]
+		} else {
+			i++
+		}
+	}
+
+	return nil
+}
+
 func (ps *PoolState) persistAvailable(ctx context.Context, cl client.Client) error {
 	for _, pr := range ps.Available {
 		if err := pr.Persist(ctx, cl); err != nil {
@@ -167,6 +410,20 @@ func (ps *PoolState) persistStale(ctx context.Context, cl client.Client) error {
 				Reason:  pvpoolv1alpha1.PoolSettlementReasonInitJobFailed,
 				Message: fmt.Sprintf("A PVC could not be initialized because its job failed: %s: %s", fc.Reason, fc.Message),
 			}
+		} else if pr.ProvisioningAbandoned {
+			eventctx.EventRecorder(ctx).Eventf(ps.Pool.Object, "Warning", "StalledReplica", "Deleting replica whose PVC %s was never provisioned", pr.PersistentVolumeClaim.Key.Name)
+			ps.Conds[pvpoolv1alpha1.PoolSettlement] = pvpoolv1alpha1.Condition{
+				Status:  corev1.ConditionUnknown,
+				Reason:  pvpoolv1alpha1.PoolSettlementReasonProvisioningStalled,
+				Message: fmt.Sprintf("The PVC %s was replaced because it was not provisioned in time.", pr.PersistentVolumeClaim.Key.Name),
+			}
+		} else if fc, ok := pr.HealthCheckJob.FailedCondition(); ok && fc.Status == corev1.ConditionTrue {
+			eventctx.EventRecorder(ctx).Eventf(ps.Pool.Object, "Warning", "UnhealthyReplica", "Deleting replica with failed health check: %s: %s", fc.Reason, fc.Message)
+			ps.Conds[pvpoolv1alpha1.PoolSettlement] = pvpoolv1alpha1.Condition{
+				Status:  corev1.ConditionUnknown,
+				Reason:  pvpoolv1alpha1.PoolSettlementReasonHealthCheckFailed,
+				Message: fmt.Sprintf("A PVC was replaced because its health check failed: %s: %s", fc.Reason, fc.Message),
+			}
 		}
 
 		if _, err := pr.Delete(ctx, cl); err != nil {
@@ -174,16 +431,34 @@ func (ps *PoolState) persistStale(ctx context.Context, cl client.Client) error {
 			ps.Stale = append(ps.Stale, pr)
 			return err
 		}
+
+		ps.Deleted = append(ps.Deleted, pr)
+
+		metrics.PoolStaleReplicasTotal.WithLabelValues(ps.Pool.Key.Namespace, ps.Pool.Key.Name).Inc()
+		switch {
+		case pr.InitJob.Failed():
+			metrics.PoolFailedReplicasTotal.WithLabelValues(ps.Pool.Key.Namespace, ps.Pool.Key.Name, metrics.JobInit).Inc()
+			metrics.ObserveInitJob(ps.Pool.Key, pr.InitJob.Object)
+		case pr.HealthCheckJob.Failed():
+			metrics.PoolFailedReplicasTotal.WithLabelValues(ps.Pool.Key.Namespace, ps.Pool.Key.Name, metrics.JobHealthCheck).Inc()
+		}
 	}
 
 	return nil
 }
 
 func (ps *PoolState) persistScaleUp(ctx context.Context, cl client.Client) error {
+	if len(ps.Adoptable) > 0 {
+		pr := ps.Adoptable[0]
+		ps.Adoptable = ps.Adoptable[1:]
+
+		return ps.persistAdopt(ctx, cl, pr)
+	}
+
 	klog.InfoS("pool state: adding a PVC to meet replica request", "pool", ps.Pool.Key)
 
 	id := uuid.New()
-	pr, err := ApplyPoolReplica(ctx, cl, ps.Pool, hex.EncodeToString(id[:]))
+	pr, err := ApplyPoolReplica(ctx, cl, ps.Pool, ps.MountJobLimits(), hex.EncodeToString(id[:]))
 	if errors.IsInvalid(err) {
 		ps.Conds[pvpoolv1alpha1.PoolSettlement] = pvpoolv1alpha1.Condition{
 			Status:  corev1.ConditionFalse,
@@ -207,6 +482,25 @@ func (ps *PoolState) persistScaleUp(ctx context.Context, cl client.Client) error
 	return nil
 }
 
+func (ps *PoolState) persistAdopt(ctx context.Context, cl client.Client, pr *PoolReplica) error {
+	klog.InfoS("pool state: adopting a PVC to meet replica request", "pool", ps.Pool.Key, "pvc", pr.PersistentVolumeClaim.Key)
+
+	pr = AdoptPoolReplica(pr, ps.Pool.Object.Spec.Adoption.RunInitJob, ps.MountJobLimits(), time.Now())
+	if err := pr.Persist(ctx, cl); err != nil {
+		return err
+	}
+
+	eventctx.EventRecorder(ctx).Eventf(ps.Pool.Object, "Normal", "AdoptedReplica", "Adopted existing PVC %s", pr.PersistentVolumeClaim.Key.Name)
+
+	if pr.Available() {
+		ps.Available = append(ps.Available, pr)
+	} else {
+		ps.Initializing = append(ps.Initializing, pr)
+	}
+
+	return nil
+}
+
 func (ps *PoolState) persistScaleDown(ctx context.Context, cl client.Client) error {
 	rng, err := rand.DefaultFactory.New()
 	if err != nil {
@@ -216,8 +510,9 @@ func (ps *PoolState) persistScaleDown(ctx context.Context, cl client.Client) err
 	klog.InfoS("pool state: removing a PVC to meet replica request", "pool", ps.Pool.Key)
 
 	// First work through initializing replicas and delete them. If none are
-	// available from the initializing list, use available instead.
-	for _, prs := range []*PoolReplicas{&ps.Initializing, &ps.Available} {
+	// available from the initializing list, use verifying and then available
+	// instead.
+	for _, prs := range []*PoolReplicas{&ps.Initializing, &ps.Verifying, &ps.Available} {
 		pr, found, err := prs.Pop(rng)
 		if err != nil {
 			return err
@@ -236,23 +531,36 @@ func (ps *PoolState) persistScaleDown(ctx context.Context, cl client.Client) err
 	return nil
 }
 
-func (ps *PoolState) persistScale(ctx context.Context, cl client.Client) error {
-	var request int32 = 1
-	if n := ps.Pool.Object.Spec.Replicas; n != nil {
-		request = *n
+// persistPolicyViolation records an event when the pool starts violating the
+// cluster's pool policies. The settlement condition carries the details for as
+// long as the violation lasts, so we don't repeat the event on every reconcile.
+func (ps *PoolState) persistPolicyViolation(ctx context.Context) {
+	if !ps.PolicyViolated() {
+		return
+	}
+
+	if prev, _ := ps.Pool.Condition(pvpoolv1alpha1.PoolSettlement); prev.Reason == pvpoolv1alpha1.PoolSettlementReasonPolicyViolation {
+		return
 	}
 
-	actual := int32(len(ps.Available) + len(ps.Initializing))
+	eventctx.EventRecorder(ctx).Event(ps.Pool.Object, "Warning", "PolicyViolation", "Pool violates one or more pool policies; not scaling up or starting health checks")
+}
+
+func (ps *PoolState) persistScale(ctx context.Context, cl client.Client) error {
+	request := ps.DesiredReplicas()
+	actual := int32(len(ps.Available) + len(ps.Verifying) + len(ps.Initializing))
 	klog.V(4).InfoS("pool state: scale assessed", "pool", ps.Pool.Key, "request", request, "actual", actual)
 
 	switch {
+	case actual < request && ps.PolicyViolated():
+		return nil
 	case actual < request:
 		eventctx.EventRecorder(ctx).Eventf(ps.Pool.Object, "Normal", "PoolScaling", "Scaling pool up to %d replicas", request)
 		return ps.persistScaleUp(ctx, cl)
 	case actual > request:
 		eventctx.EventRecorder(ctx).Eventf(ps.Pool.Object, "Normal", "PoolScaling", "Scaling pool down to %d replicas", request)
 		return ps.persistScaleDown(ctx, cl)
-	case len(ps.Initializing) == 0:
+	case len(ps.Initializing) == 0 && !ps.PolicyViolated():
 		ps.Conds[pvpoolv1alpha1.PoolSettlement] = pvpoolv1alpha1.Condition{
 			Status:  corev1.ConditionTrue,
 			Reason:  pvpoolv1alpha1.PoolSettlementReasonSettled,
@@ -264,15 +572,120 @@ func (ps *PoolState) persistScale(ctx context.Context, cl client.Client) error {
 	}
 }
 
-func (ps *PoolState) Persist(ctx context.Context, cl client.Client) error {
+// DesiredReplicas returns the number of replicas requested by the pool spec.
+func (ps *PoolState) DesiredReplicas() int32 {
+	if n := ps.Pool.Object.Spec.Replicas; n != nil {
+		return *n
+	}
+
+	return 1
+}
+
+// MountJobLimits returns the limits to apply to the jobs for this pool's
+// replicas.
+func (ps *PoolState) MountJobLimits() pvpoolv1alpha1validation.MountJobLimits {
+	return pvpoolv1alpha1validation.MountJobLimitsForPolicies(ps.Policies)
+}
+
+// ProvisioningStalledAfter returns the amount of time a PVC in this pool may
+// remain pending before it is considered stalled.
+func (ps *PoolState) ProvisioningStalledAfter() time.Duration {
+	if pp := ps.Pool.Object.Spec.Provisioning; pp != nil && pp.StalledAfter != nil {
+		return pp.StalledAfter.Duration
+	}
+
+	return DefaultPoolProvisioningStalledAfter
+}
+
+// ProvisioningReplaceAfter returns the amount of time a PVC in this pool may
+// remain pending before it is replaced, if the pool replaces pending PVCs.
+func (ps *PoolState) ProvisioningReplaceAfter() (time.Duration, bool) {
+	if pp := ps.Pool.Object.Spec.Provisioning; pp != nil && pp.ReplaceAfter != nil {
+		return pp.ReplaceAfter.Duration, true
+	}
+
+	return 0, false
+}
+
+// RequeueAfter returns the amount of time until this pool should be
+// reconciled again even if nothing about it changes.
+func (ps *PoolState) RequeueAfter(now time.Time) (time.Duration, bool) {
+	next, found := ps.NextHealthCheck(now)
+
+	// Check back when pending PVCs cross a threshold.
+	for _, pr := range ps.Initializing {
+		if !pr.Pending() {
+			continue
+		}
+
+		thresholds := []time.Duration{ps.ProvisioningStalledAfter()}
+		if d, ok := ps.ProvisioningReplaceAfter(); ok {
+			thresholds = append(thresholds, d)
+		}
+
+		for _, threshold := range thresholds {
+			d := threshold - pr.PendingFor(now)
+			if d <= 0 {
+				continue
+			}
+
+			if !found || d < next {
+				next, found = d, true
+			}
+		}
+	}
+
+	return next, found
+}
+
+// NextHealthCheck returns the amount of time until the next periodic health
+// check for any of this pool's available replicas is due.
+func (ps *PoolState) NextHealthCheck(now time.Time) (time.Duration, bool) {
+	var next time.Duration
+	var found bool
+
+	for _, pr := range ps.Available {
+		d, ok := pr.NextVerification(now)
+		if !ok {
+			continue
+		}
+
+		if !found || d < next {
+			next, found = d, true
+		}
+	}
+
+	if found && next < 0 {
+		next = 0
+	}
+
+	return next, found
+}
+
+// PolicyViolated returns true if the settlement condition indicates that this
+// pool does not meet the requirements of the cluster's pool policies.
+func (ps *PoolState) PolicyViolated() bool {
+	return ps.Conds[pvpoolv1alpha1.PoolSettlement].Reason == pvpoolv1alpha1.PoolSettlementReasonPolicyViolation
+}
+
+func (ps *PoolState) Persist(ctx context.Context, cl client.Client) (err error) {
+	ctx, span := tracing.Start(ctx, "PoolState.Persist", tracing.ObjectKeyAttributes(client.ObjectKeyFromObject(ps.Pool.Object))...)
+	defer func() { tracing.End(span, err) }()
+
 	if err := ps.persistInitializing(ctx, cl); err != nil {
 		return err
 	}
 
+	if err := ps.persistVerifying(ctx, cl); err != nil {
+		return err
+	}
+
 	if err := ps.persistAvailable(ctx, cl); err != nil {
 		return err
 	}
 
+	ps.persistPolicyViolation(ctx)
+
 	if err := ps.persistScale(ctx, cl); err != nil {
 		return err
 	}
@@ -292,11 +705,42 @@ func NewPoolState(p *pvpoolv1alpha1obj.Pool) *PoolState {
 }
 
 func ConfigurePoolState(ps *PoolState) *PoolState {
+	// Make sure the pool is still permitted by policy. Policies may have
+	// changed since the pool was admitted, so its jobs may now exceed the
+	// mount job limits. We don't start new health checks until it is fixed.
+	if errs := pvpoolv1alpha1validation.ValidatePoolSpecForPolicies(&ps.Pool.Object.Spec, ps.Policies, ps.NamespaceStorage, field.NewPath("spec")); len(errs) > 0 {
+		ps.Conds[pvpoolv1alpha1.PoolSettlement] = pvpoolv1alpha1.Condition{
+			Status:  corev1.ConditionFalse,
+			Reason:  pvpoolv1alpha1.PoolSettlementReasonPolicyViolation,
+			Message: fmt.Sprintf("The pool does not satisfy the cluster's pool policies: %v", errs.ToAggregate()),
+		}
+	}
+
 	// See if any initializing PVCs need to be moved.
 	for i := range ps.Initializing {
-		ps.Initializing[i] = ConfigurePoolReplica(ps.Initializing[i])
+		ps.Initializing[i] = ConfigurePoolReplica(ps.Initializing[i], ps.MountJobLimits())
 	}
 
+	// Start health checks for available PVCs that are due for one and finish
+	// health checks that have completed.
+	now := time.Now()
+	configurePoolStateHealthCheckRequests(ps, now)
+	for i := range ps.Verifying {
+		ps.Verifying[i] = ConfigurePoolReplicaHealthCheck(ps.Verifying[i], ps.MountJobLimits(), now)
+	}
+	for i := 0; i < len(ps.Available) && !ps.PolicyViolated(); {
+		ps.Available[i] = ConfigurePoolReplicaHealthCheck(ps.Available[i], ps.MountJobLimits(), now)
+		if ps.Available[i].Verifying() {
+			ps.Verifying = append(ps.Verifying, ps.Available[i])
+			ps.Available[i] = ps.Available[len(ps.Available)-1]
+			ps.Available = ps.Available[:len(ps.Available)-1]
+		} else {
+			i++
+		}
+	}
+
+	configurePoolStateProvisioning(ps, now)
+
 	// Set initial relevant condition reasons, if applicable.
 	if request := ps.Pool.Object.Spec.Replicas; request != nil && *request == 0 {
 		ps.Conds[pvpoolv1alpha1.PoolAvailable] = pvpoolv1alpha1.Condition{
@@ -308,3 +752,73 @@ func ConfigurePoolState(ps *PoolState) *PoolState {
 
 	return ps
 }
+
+// configurePoolStateHealthCheckRequests asks for the oldest available replica
+// to be verified if a checkout is waiting for a verified replica and no replica
+// is being verified or has been verified since the checkout was created.
+func configurePoolStateHealthCheckRequests(ps *PoolState, now time.Time) {
+	if ps.HealthCheckRequestedAt.IsZero() || len(ps.Verifying) > 0 || len(ps.Available) == 0 {
+		return
+	}
+
+	for _, pr := range ps.Available {
+		if verifiedAt, ok := pr.VerifiedAt(); ok && verifiedAt.After(ps.HealthCheckRequestedAt) {
+			return
+		}
+	}
+
+	sort.Sort(PoolReplicasSortByCreationTimestamp(ps.Available))
+
+	pr := ps.Available[0]
+	klog.V(4).InfoS("pool state: configure: checkout requested health check for PVC", "pool", ps.Pool.Key, "pvc", pr.PersistentVolumeClaim.Key)
+	helper.Annotate(pr.PersistentVolumeClaim.Object, PoolReplicaVerificationRequestedAtAnnotationKey, now.UTC().Format(time.RFC3339Nano))
+}
+
+func configurePoolStateProvisioning(ps *PoolState, now time.Time) {
+	var stalled []string
+	for i := 0; i < len(ps.Initializing); {
+		pr := ps.Initializing[i]
+
+		pendingFor := pr.PendingFor(now)
+		if pendingFor < ps.ProvisioningStalledAfter() {
+			i++
+			continue
+		}
+
+		// This message must not change between reconciles unless something
+		// about the PVC changes, so we report when it was created instead of
+		// how long it has been pending.
+		msg := fmt.Sprintf("%s (pending since %s", pr.PersistentVolumeClaim.Key.Name, pr.PersistentVolumeClaim.Object.GetCreationTimestamp().UTC().Format(time.RFC3339))
+		for _, ev := range pr.Events {
+			if ev.Type == corev1.EventTypeWarning {
+				msg += fmt.Sprintf(": %s: %s", ev.Reason, ev.Message)
+				break
+			}
+		}
+		stalled = append(stalled, msg+")")
+
+		if d, ok := ps.ProvisioningReplaceAfter(); ok && pendingFor >= d {
+			pr.ProvisioningAbandoned = true
+
+			ps.Stale = append(ps.Stale, pr)
+			ps.Initializing[i] = ps.Initializing[len(ps.Initializing)-1]
+			ps.Initializing = ps.Initializing[:len(ps.Initializing)-1]
+		} else {
+			i++
+		}
+	}
+
+	if len(stalled) > 0 {
+		ps.Conds[pvpoolv1alpha1.PoolProvisioningStalled] = pvpoolv1alpha1.Condition{
+			Status:  corev1.ConditionTrue,
+			Reason:  pvpoolv1alpha1.PoolProvisioningStalledReasonClaimPending,
+			Message: fmt.Sprintf("The storage provisioner has not bound the following PVCs: %s.", strings.Join(stalled, "; ")),
+		}
+	} else {
+		ps.Conds[pvpoolv1alpha1.PoolProvisioningStalled] = pvpoolv1alpha1.Condition{
+			Status:  corev1.ConditionFalse,
+			Reason:  pvpoolv1alpha1.PoolProvisioningStalledReasonNotStalled,
+			Message: "No PVCs are stuck waiting to be provisioned.",
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/eventctx"
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// eventRecorderManager provides just enough of a manager to install an event
+// recorder in a context.
+type eventRecorderManager struct {
+	manager.Manager
+	recorder record.EventRecorder
+}
+
+func (erm *eventRecorderManager) GetEventRecorderFor(name string) record.EventRecorder {
+	return erm.recorder
+}
+
+func TestPoolStatePersistPolicyViolation(t *testing.T) {
+	violation := pvpoolv1alpha1.Condition{
+		Status: corev1.ConditionFalse,
+		Reason: pvpoolv1alpha1.PoolSettlementReasonPolicyViolation,
+	}
+
+	tests := []struct {
+		Name          string
+		Previous      pvpoolv1alpha1.Condition
+		Next          pvpoolv1alpha1.Condition
+		ExpectedEvent bool
+	}{
+		{
+			Name:          "Violation starts",
+			Previous:      pvpoolv1alpha1.Condition{Status: corev1.ConditionTrue, Reason: pvpoolv1alpha1.PoolSettlementReasonSettled},
+			Next:          violation,
+			ExpectedEvent: true,
+		},
+		{
+			Name:     "Violation continues",
+			Previous: violation,
+			Next:     violation,
+		},
+		{
+			Name:     "No violation",
+			Previous: violation,
+			Next:     pvpoolv1alpha1.Condition{Status: corev1.ConditionTrue, Reason: pvpoolv1alpha1.PoolSettlementReasonSettled},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			pool := newAdoptionTestPool()
+			pool.Status.Conditions = []pvpoolv1alpha1.PoolCondition{
+				{Condition: test.Previous, Type: pvpoolv1alpha1.PoolSettlement},
+			}
+
+			ps := NewPoolState(pvpoolv1alpha1obj.NewPoolFromObject(pool))
+			ps.Conds[pvpoolv1alpha1.PoolSettlement] = test.Next
+
+			recorder := record.NewFakeRecorder(1)
+			ctx := eventctx.WithEventRecorder(context.Background(), &eventRecorderManager{recorder: recorder}, "test")
+
+			ps.persistPolicyViolation(ctx)
+			assert.Equal(t, test.ExpectedEvent, len(recorder.Events) == 1)
+		})
+	}
+}
+
+func TestPoolStateLoadHealthCheckRequests(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, pvpoolv1alpha1.AddToScheme(scheme))
+
+	now := time.Now().Truncate(time.Second)
+
+	pool := newAdoptionTestPool()
+	pool.Spec.HealthCheck = &pvpoolv1alpha1.PoolHealthCheck{BeforeCheckout: true}
+
+	checkout := func(name string, poolRef pvpoolv1alpha1.PoolReference, createdAt time.Time, reason string) *pvpoolv1alpha1.Checkout {
+		return &pvpoolv1alpha1.Checkout{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "checkouts",
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(createdAt),
+			},
+			Spec: pvpoolv1alpha1.CheckoutSpec{
+				PoolRef: poolRef,
+			},
+			Status: pvpoolv1alpha1.CheckoutStatus{
+				Conditions: []pvpoolv1alpha1.CheckoutCondition{
+					{
+						Condition: pvpoolv1alpha1.Condition{Status: corev1.ConditionFalse, Reason: reason},
+						Type:      pvpoolv1alpha1.CheckoutAcquired,
+					},
+				},
+			},
+		}
+	}
+	poolRef := pvpoolv1alpha1.PoolReference{Namespace: pool.GetNamespace(), Name: pool.GetName()}
+	otherPoolRef := pvpoolv1alpha1.PoolReference{Namespace: pool.GetNamespace(), Name: "other-pool"}
+
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			pool,
+			checkout("newer", poolRef, now.Add(-time.Minute), pvpoolv1alpha1.CheckoutAcquiredReasonHealthCheckPending),
+			checkout("older", poolRef, now.Add(-time.Hour), pvpoolv1alpha1.CheckoutAcquiredReasonHealthCheckPending),
+			checkout("not-waiting", poolRef, now.Add(-2*time.Hour), pvpoolv1alpha1.CheckoutAcquiredReasonNotAvailable),
+			checkout("other-pool", otherPoolRef, now.Add(-3*time.Hour), pvpoolv1alpha1.CheckoutAcquiredReasonHealthCheckPending),
+		).
+		Build()
+
+	ps := NewPoolState(pvpoolv1alpha1obj.NewPoolFromObject(pool))
+	require.NoError(t, ps.LoadHealthCheckRequests(ctx, cl))
+	assert.True(t, ps.HealthCheckRequestedAt.Equal(now.Add(-time.Hour)), "requested at %s", ps.HealthCheckRequestedAt)
+
+	// Without beforeCheckout, checkouts don't ask for health checks.
+	pool.Spec.HealthCheck.BeforeCheckout = false
+	require.NoError(t, ps.LoadHealthCheckRequests(ctx, cl))
+	assert.True(t, ps.HealthCheckRequestedAt.IsZero())
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestConfigurePoolReplicaStatuses(t *testing.T) {
+	pool := pvpoolv1alpha1obj.NewPoolFromObject(newAdoptionTestPool())
+	now := time.Now().Truncate(time.Second)
+
+	var n int
+	replicas := func(count int) PoolReplicas {
+		prs := make(PoolReplicas, count)
+		for i := range prs {
+			n++
+
+			// Create the replicas newest first so that they need sorting.
+			pr := NewPoolReplica(pool, client.ObjectKey{Namespace: pool.Key.Namespace, Name: fmt.Sprintf("test-pool-%03d", n)})
+			pr.PersistentVolumeClaim.Object.CreationTimestamp = metav1.NewTime(now.Add(-time.Duration(i) * time.Minute))
+			prs[i] = pr
+		}
+		return prs
+	}
+
+	ps := NewPoolState(pool)
+	ps.Available = replicas(80)
+	ps.Verifying = replicas(5)
+	ps.Initializing = replicas(10)
+	ps.Stale = replicas(2)
+	ps.Deleted = replicas(3)
+
+	statuses := configurePoolReplicaStatuses(ps)
+	require.Len(t, statuses, PoolReplicaStatusesLimit)
+
+	expected := []struct {
+		Phase pvpoolv1alpha1.PoolReplicaPhase
+		Count int
+	}{
+		{Phase: pvpoolv1alpha1.PoolReplicaPhaseStale, Count: 5},
+		{Phase: pvpoolv1alpha1.PoolReplicaPhaseInitializing, Count: 10},
+		{Phase: pvpoolv1alpha1.PoolReplicaPhaseVerifying, Count: 5},
+		{Phase: pvpoolv1alpha1.PoolReplicaPhaseAvailable, Count: 80},
+	}
+
+	i := 0
+	for _, group := range expected {
+		for j := 0; j < group.Count && i < len(statuses); j++ {
+			assert.Equal(t, group.Phase, statuses[i].Phase, "status %d", i)
+			if j > 0 && statuses[i-1].Phase == group.Phase {
+				assert.False(t, statuses[i].CreationTimestamp.Before(&statuses[i-1].CreationTimestamp), "status %d is out of order", i)
+			}
+			i++
+		}
+	}
+
+	// The deleted stale replicas are reported along with the others.
+	var stale []string
+	for _, status := range statuses[:5] {
+		stale = append(stale, status.ClaimName)
+	}
+	for _, pr := range append(ps.Stale, ps.Deleted...) {
+		assert.Contains(t, stale, pr.PersistentVolumeClaim.Key.Name)
+	}
+
+	// The newest available replicas are truncated.
+	assert.Equal(t, ps.Available[80-(PoolReplicaStatusesLimit-20)].PersistentVolumeClaim.Key.Name, statuses[len(statuses)-1].ClaimName)
+}
@@ -0,0 +1,144 @@
+package app
+
+import (
+	"context"
+
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/eventctx"
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	pvpoolv1alpha1defaults "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/defaults"
+	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
+	"github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/pooltemplate"
+	pvpoolv1alpha1validation "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/validation"
+	"github.com/puppetlabs/pvpool/pkg/tracing"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PoolTemplateState tracks the pools and cluster pools that refer to a pool
+// template.
+type PoolTemplateState struct {
+	PoolTemplate *pvpoolv1alpha1obj.PoolTemplate
+
+	// Pools and ClusterPools refer to the template. After the state is
+	// configured, they only contain the objects that need to be updated.
+	Pools        []*pvpoolv1alpha1obj.Pool
+	ClusterPools []*pvpoolv1alpha1obj.ClusterPool
+
+	// Limits are the mount job limits of the cluster's pool policies, which
+	// the webhook uses to set defaults.
+	Limits pvpoolv1alpha1validation.MountJobLimits
+}
+
+var _ lifecycle.Loader = &PoolTemplateState{}
+var _ lifecycle.Persister = &PoolTemplateState{}
+
+func (pts *PoolTemplateState) Load(ctx context.Context, cl client.Client) (ok bool, err error) {
+	ctx, span := tracing.Start(ctx, "PoolTemplateState.Load", tracing.ObjectKeyAttributes(client.ObjectKey{Name: pts.PoolTemplate.Name})...)
+	defer func() { tracing.End(span, err) }()
+
+	if ok, err := pts.PoolTemplate.Load(ctx, cl); err != nil || !ok {
+		return ok, err
+	}
+
+	pools := &pvpoolv1alpha1.PoolList{}
+	if err := cl.List(ctx, pools); err != nil {
+		return false, err
+	}
+
+	pts.Pools = nil
+	for i := range pools.Items {
+		if ref := pools.Items[i].Spec.TemplateRef; ref != nil && ref.Name == pts.PoolTemplate.Name {
+			pts.Pools = append(pts.Pools, pvpoolv1alpha1obj.NewPoolFromObject(&pools.Items[i]))
+		}
+	}
+
+	cps := &pvpoolv1alpha1.ClusterPoolList{}
+	if err := cl.List(ctx, cps); err != nil {
+		return false, err
+	}
+
+	pts.ClusterPools = nil
+	for i := range cps.Items {
+		if ref := cps.Items[i].Spec.TemplateRef; ref != nil && ref.Name == pts.PoolTemplate.Name {
+			pts.ClusterPools = append(pts.ClusterPools, pvpoolv1alpha1obj.NewClusterPoolFromObject(&cps.Items[i]))
+		}
+	}
+
+	policies := &pvpoolv1alpha1.PoolPolicyList{}
+	if err := cl.List(ctx, policies); err != nil {
+		return false, err
+	}
+
+	pts.Limits = pvpoolv1alpha1validation.MountJobLimitsForPolicies(policies.Items)
+
+	return true, nil
+}
+
+func (pts *PoolTemplateState) Persist(ctx context.Context, cl client.Client) (err error) {
+	ctx, span := tracing.Start(ctx, "PoolTemplateState.Persist", tracing.ObjectKeyAttributes(client.ObjectKey{Name: pts.PoolTemplate.Name})...)
+	defer func() { tracing.End(span, err) }()
+
+	for _, pool := range pts.Pools {
+		klog.V(4).InfoS("pool template state: persist: updating pool", "pooltemplate", pts.PoolTemplate.Name, "pool", pool.Key)
+		if err := pool.Persist(ctx, cl); err != nil {
+			return err
+		}
+	}
+
+	for _, cp := range pts.ClusterPools {
+		klog.V(4).InfoS("pool template state: persist: updating cluster pool", "pooltemplate", pts.PoolTemplate.Name, "clusterpool", cp.Name)
+		if err := cp.Persist(ctx, cl); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func NewPoolTemplateState(pt *pvpoolv1alpha1obj.PoolTemplate) *PoolTemplateState {
+	return &PoolTemplateState{
+		PoolTemplate: pt,
+	}
+}
+
+// ConfigurePoolTemplateState copies the template into each pool that refers
+// to it, keeping only the pools that changed.
+func ConfigurePoolTemplateState(ctx context.Context, pts *PoolTemplateState) *PoolTemplateState {
+	var pools []*pvpoolv1alpha1obj.Pool
+	for _, pool := range pts.Pools {
+		if pts.configurePoolSpec(ctx, pool.Object, &pool.Object.Spec) {
+			pools = append(pools, pool)
+		}
+	}
+	pts.Pools = pools
+
+	var cps []*pvpoolv1alpha1obj.ClusterPool
+	for _, cp := range pts.ClusterPools {
+		if pts.configurePoolSpec(ctx, cp.Object, &cp.Object.Spec) {
+			cps = append(cps, cp)
+		}
+	}
+	pts.ClusterPools = cps
+
+	return pts
+}
+
+func (pts *PoolTemplateState) configurePoolSpec(ctx context.Context, obj client.Object, spec *pvpoolv1alpha1.PoolSpec) bool {
+	// Apply defaults the same way the webhook does so that we only update
+	// pools that would actually change.
+	next := spec.DeepCopy()
+	if err := pooltemplate.Apply(next, pts.PoolTemplate.Object); err != nil {
+		eventctx.EventRecorder(ctx).Eventf(obj, "Warning", "PoolTemplate", "Could not apply pool template %s: %v", pts.PoolTemplate.Name, err)
+		return false
+	}
+	pvpoolv1alpha1defaults.DefaultPoolSpec(next, pts.Limits)
+
+	if equality.Semantic.DeepEqual(spec, next) {
+		return false
+	}
+
+	*spec = *next
+	return true
+}
@@ -0,0 +1,154 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/eventctx"
+	corev1obj "github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/api/corev1"
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/helper"
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
+	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
+	"github.com/puppetlabs/pvpool/pkg/tracing"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VolumeState tracks a PV that a checkout has modified so that it can be
+// cleaned up if the checkout never finishes with it.
+type VolumeState struct {
+	PersistentVolume *corev1obj.PersistentVolume
+
+	// Checkout is the checkout recorded on PersistentVolume, if any.
+	Checkout *pvpoolv1alpha1obj.Checkout
+
+	// Successor is the PV a checkout creates to take over the storage of
+	// PersistentVolume. If it exists, the storage is no longer ours to
+	// release.
+	Successor *corev1obj.PersistentVolume
+}
+
+var _ lifecycle.Loader = &VolumeState{}
+var _ lifecycle.Persister = &VolumeState{}
+
+// modified returns true if a checkout modified this PV and the claim it was
+// bound to no longer exists.
+func (vs *VolumeState) modified() bool {
+	if _, ok := vs.PersistentVolume.Object.GetAnnotations()[CheckoutReclaimPolicyAnnotationKey]; !ok {
+		return false
+	}
+
+	return vs.PersistentVolume.Object.Status.Phase == corev1.VolumeReleased
+}
+
+// Orphaned returns true if a checkout modified this PV, the claim it was bound
+// to no longer exists, and the checkout recorded on the PV is gone. Once the
+// checkout has taken the PV's claim, its pool no longer manages the PV, so
+// the checkout is the only thing that can release it.
+//
+// PVs modified before the checkout was recorded on them only have the reclaim
+// policy annotation, so we consider them orphaned as soon as they are
+// released.
+func (vs *VolumeState) Orphaned() bool {
+	if !vs.modified() {
+		return false
+	} else if vs.Checkout == nil || !helper.Exists(vs.Checkout.Object) {
+		return true
+	}
+
+	// A checkout with the same name but a different UID is a new checkout, so
+	// the one that modified this PV is gone.
+	uid, ok := vs.PersistentVolume.Object.GetAnnotations()[CheckoutUIDAnnotationKey]
+	return ok && types.UID(uid) != vs.Checkout.Object.GetUID()
+}
+
+// ReclaimPolicy returns the reclaim policy the PV had before a checkout
+// modified it.
+func (vs *VolumeState) ReclaimPolicy() corev1.PersistentVolumeReclaimPolicy {
+	return corev1.PersistentVolumeReclaimPolicy(vs.PersistentVolume.Object.GetAnnotations()[CheckoutReclaimPolicyAnnotationKey])
+}
+
+func (vs *VolumeState) Load(ctx context.Context, cl client.Client) (ok bool, err error) {
+	ctx, span := tracing.Start(ctx, "VolumeState.Load", tracing.ObjectKeyAttributes(client.ObjectKeyFromObject(vs.PersistentVolume.Object))...)
+	defer func() { tracing.End(span, err) }()
+
+	if ok, err := vs.PersistentVolume.Load(ctx, cl); err != nil || !ok {
+		return ok, err
+	}
+
+	if !vs.modified() {
+		return true, nil
+	}
+
+	annotations := vs.PersistentVolume.Object.GetAnnotations()
+
+	if name := annotations[CheckoutNameAnnotationKey]; name != "" {
+		vs.Checkout = pvpoolv1alpha1obj.NewCheckout(client.ObjectKey{
+			Namespace: annotations[CheckoutNamespaceAnnotationKey],
+			Name:      name,
+		})
+		if _, err := vs.Checkout.Load(ctx, cl); err != nil {
+			return false, err
+		}
+	}
+
+	if !vs.Orphaned() {
+		return true, nil
+	}
+
+	vs.Successor = corev1obj.NewPersistentVolume(checkoutPersistentVolumeName(vs.PersistentVolume))
+	if _, err := vs.Successor.Load(ctx, cl); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (vs *VolumeState) Persist(ctx context.Context, cl client.Client) (err error) {
+	ctx, span := tracing.Start(ctx, "VolumeState.Persist", tracing.ObjectKeyAttributes(client.ObjectKeyFromObject(vs.PersistentVolume.Object))...)
+	defer func() { tracing.End(span, err) }()
+
+	if !vs.Orphaned() {
+		return nil
+	}
+
+	switch {
+	case helper.Exists(vs.Successor.Object):
+		// The checkout got as far as handing the storage over to a new PV, so
+		// this PV is just a leftover reference to it. As long as it is set to
+		// Retain, deleting it leaves the storage alone.
+		klog.InfoS("volume state: deleting orphaned PV superseded by checkout", "pv", vs.PersistentVolume.Name, "successor", vs.Successor.Name)
+		eventctx.EventRecorder(ctx).Eventf(vs.PersistentVolume.Object, "Warning", "OrphanedVolume", "Deleting volume left behind by an interrupted checkout; its storage now belongs to %s", vs.Successor.Name)
+
+		if vs.PersistentVolume.Object.Spec.PersistentVolumeReclaimPolicy != corev1.PersistentVolumeReclaimRetain {
+			vs.PersistentVolume.Object.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimRetain
+			if err := vs.PersistentVolume.Persist(ctx, cl); err != nil {
+				return err
+			}
+		}
+
+		_, err := vs.PersistentVolume.Delete(ctx, cl, lifecycle.DeleteWithPropagationPolicy(metav1.DeletePropagationBackground))
+		return err
+	case vs.ReclaimPolicy() != "" && vs.PersistentVolume.Object.Spec.PersistentVolumeReclaimPolicy != vs.ReclaimPolicy():
+		// Nothing else references the storage, so let Kubernetes release it
+		// the way it would have before the checkout touched it.
+		klog.InfoS("volume state: restoring reclaim policy of orphaned PV", "pv", vs.PersistentVolume.Name, "policy", vs.ReclaimPolicy())
+		eventctx.EventRecorder(ctx).Eventf(vs.PersistentVolume.Object, "Warning", "OrphanedVolume", "Restoring reclaim policy %s on volume left behind by an interrupted checkout", vs.ReclaimPolicy())
+
+		vs.PersistentVolume.Object.Spec.PersistentVolumeReclaimPolicy = vs.ReclaimPolicy()
+		if err := vs.PersistentVolume.Persist(ctx, cl); err != nil {
+			return fmt.Errorf("failed to restore reclaim policy: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func NewVolumeState(pv *corev1obj.PersistentVolume) *VolumeState {
+	return &VolumeState{
+		PersistentVolume: pv,
+	}
+}
@@ -0,0 +1,159 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	corev1obj "github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/api/corev1"
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestVolumeState(t *testing.T) {
+	const (
+		volumeName    = "pvc-1234"
+		volumeUID     = types.UID("1234")
+		checkoutUID   = types.UID("5678")
+		successorName = "pvpool-1234"
+	)
+
+	checkout := func(uid types.UID) client.Object {
+		return &pvpoolv1alpha1.Checkout{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "test-checkout", UID: uid},
+		}
+	}
+	pool := &pvpoolv1alpha1.Pool{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "pools", Name: "test-pool", UID: "abcd"},
+	}
+	successor := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: successorName, UID: "efgh"},
+	}
+
+	volume := func(phase corev1.PersistentVolumePhase, annotations map[string]string) *corev1.PersistentVolume {
+		return &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        volumeName,
+				UID:         volumeUID,
+				Annotations: annotations,
+			},
+			Spec: corev1.PersistentVolumeSpec{
+				PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain,
+			},
+			Status: corev1.PersistentVolumeStatus{
+				Phase: phase,
+			},
+		}
+	}
+	modified := map[string]string{
+		CheckoutReclaimPolicyAnnotationKey:       string(corev1.PersistentVolumeReclaimDelete),
+		CheckoutNamespaceAnnotationKey:           "test",
+		CheckoutNameAnnotationKey:                "test-checkout",
+		CheckoutUIDAnnotationKey:                 string(checkoutUID),
+		CheckoutSourcePoolNamespaceAnnotationKey: "pools",
+		CheckoutSourcePoolNameAnnotationKey:      "test-pool",
+	}
+
+	tests := []struct {
+		Name             string
+		Objects          []client.Object
+		ExpectedOrphaned bool
+		ExpectedDeleted  bool
+		ExpectedPolicy   corev1.PersistentVolumeReclaimPolicy
+	}{
+		{
+			Name:           "Not modified by a checkout",
+			Objects:        []client.Object{volume(corev1.VolumeReleased, nil)},
+			ExpectedPolicy: corev1.PersistentVolumeReclaimRetain,
+		},
+		{
+			Name:           "Still bound",
+			Objects:        []client.Object{volume(corev1.VolumeBound, modified)},
+			ExpectedPolicy: corev1.PersistentVolumeReclaimRetain,
+		},
+		{
+			Name:           "Checkout and pool exist",
+			Objects:        []client.Object{volume(corev1.VolumeReleased, modified), checkout(checkoutUID), pool, successor},
+			ExpectedPolicy: corev1.PersistentVolumeReclaimRetain,
+		},
+		{
+			Name:           "Checkout exists",
+			Objects:        []client.Object{volume(corev1.VolumeReleased, modified), checkout(checkoutUID)},
+			ExpectedPolicy: corev1.PersistentVolumeReclaimRetain,
+		},
+		{
+			Name:             "Pool exists",
+			Objects:          []client.Object{volume(corev1.VolumeReleased, modified), pool},
+			ExpectedOrphaned: true,
+			ExpectedPolicy:   corev1.PersistentVolumeReclaimDelete,
+		},
+		{
+			Name:             "Pool exists and successor exists",
+			Objects:          []client.Object{volume(corev1.VolumeReleased, modified), pool, successor},
+			ExpectedOrphaned: true,
+			ExpectedDeleted:  true,
+		},
+		{
+			Name: "Checkout not recorded",
+			Objects: []client.Object{volume(corev1.VolumeReleased, map[string]string{
+				CheckoutReclaimPolicyAnnotationKey: string(corev1.PersistentVolumeReclaimDelete),
+			}), pool},
+			ExpectedOrphaned: true,
+			ExpectedPolicy:   corev1.PersistentVolumeReclaimDelete,
+		},
+		{
+			Name:             "Successor exists",
+			Objects:          []client.Object{volume(corev1.VolumeReleased, modified), successor},
+			ExpectedOrphaned: true,
+			ExpectedDeleted:  true,
+		},
+		{
+			Name:             "Restore policy",
+			Objects:          []client.Object{volume(corev1.VolumeReleased, modified)},
+			ExpectedOrphaned: true,
+			ExpectedPolicy:   corev1.PersistentVolumeReclaimDelete,
+		},
+		{
+			Name:             "Checkout recreated with the same name",
+			Objects:          []client.Object{volume(corev1.VolumeReleased, modified), checkout("9999")},
+			ExpectedOrphaned: true,
+			ExpectedPolicy:   corev1.PersistentVolumeReclaimDelete,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ctx := context.Background()
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, corev1.AddToScheme(scheme))
+			require.NoError(t, pvpoolv1alpha1.AddToScheme(scheme))
+
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(test.Objects...).Build()
+
+			vs := NewVolumeState(corev1obj.NewPersistentVolume(volumeName))
+			ok, err := vs.Load(ctx, cl)
+			require.NoError(t, err)
+			require.True(t, ok)
+			assert.Equal(t, test.ExpectedOrphaned, vs.Orphaned())
+
+			require.NoError(t, vs.Persist(ctx, cl))
+
+			pv := corev1obj.NewPersistentVolume(volumeName)
+			ok, err = pv.Load(ctx, cl)
+			require.NoError(t, err)
+			if test.ExpectedDeleted {
+				assert.False(t, ok)
+				return
+			}
+
+			require.True(t, ok)
+			assert.Equal(t, test.ExpectedPolicy, pv.Object.Spec.PersistentVolumeReclaimPolicy)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+// Package metrics defines the domain metrics exported by the controller. All
+// metrics are registered with the controller-runtime registry, so they are
+// served from the manager's metrics endpoint.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespace = "pvpool"
+)
+
+// Replica phases used as the value of the phase label.
+const (
+	PhaseInitializing = "initializing"
+	PhaseVerifying    = "verifying"
+	PhaseAvailable    = "available"
+	PhaseStale        = "stale"
+)
+
+// Job results used as the value of the result label.
+const (
+	ResultSucceeded = "succeeded"
+	ResultFailed    = "failed"
+)
+
+// Job kinds used as the value of the job label.
+const (
+	JobInit        = "init"
+	JobHealthCheck = "health_check"
+)
+
+var (
+	// PoolReplicas is the number of replicas in each pool by phase.
+	PoolReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "pool",
+			Name:      "replicas",
+			Help:      "Number of replicas in the pool by phase.",
+		},
+		[]string{"namespace", "pool", "phase"},
+	)
+
+	// PoolStaleReplicasTotal is the number of stale replicas each pool has
+	// removed.
+	PoolStaleReplicasTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "pool",
+			Name:      "stale_replicas_total",
+			Help:      "Total number of stale replicas removed from the pool.",
+		},
+		[]string{"namespace", "pool"},
+	)
+
+	// PoolFailedReplicasTotal is the number of replicas each pool has removed
+	// because one of their jobs failed.
+	PoolFailedReplicasTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "pool",
+			Name:      "failed_replicas_total",
+			Help:      "Total number of replicas removed from the pool because a job failed.",
+		},
+		[]string{"namespace", "pool", "job"},
+	)
+
+	// InitJobDurationSeconds is the time taken by init jobs to finish.
+	InitJobDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "pool",
+			Name:      "init_job_duration_seconds",
+			Help:      "Time taken by init jobs to complete.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 11),
+		},
+		[]string{"namespace", "pool", "result"},
+	)
+
+	// CheckoutAcquisitionDurationSeconds is the time between a checkout being
+	// created and its PVC becoming ready to use.
+	CheckoutAcquisitionDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "checkout",
+			Name:      "acquisition_duration_seconds",
+			Help:      "Time between a checkout being created and its PVC becoming ready to use.",
+			Buckets:   prometheus.ExponentialBuckets(0.25, 2, 12),
+		},
+		[]string{"pool_namespace", "pool"},
+	)
+
+	// CheckoutNotAvailableTotal is the number of times a checkout started
+	// waiting because its pool had no available replicas.
+	CheckoutNotAvailableTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "checkout",
+			Name:      "not_available_total",
+			Help:      "Total number of times a checkout started waiting because its pool had no available replicas.",
+		},
+		[]string{"pool_namespace", "pool"},
+	)
+)
+
+// SetPoolReplicas records the number of replicas in each phase for a pool.
+func SetPoolReplicas(key client.ObjectKey, byPhase map[string]int) {
+	for phase, n := range byPhase {
+		PoolReplicas.WithLabelValues(key.Namespace, key.Name, phase).Set(float64(n))
+	}
+}
+
+// ObserveInitJob records the duration of a finished init job.
+func ObserveInitJob(key client.ObjectKey, job *batchv1.Job) {
+	start := job.Status.StartTime
+	if start == nil {
+		return
+	}
+
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		var result string
+		switch cond.Type {
+		case batchv1.JobComplete:
+			result = ResultSucceeded
+		case batchv1.JobFailed:
+			result = ResultFailed
+		default:
+			continue
+		}
+
+		InitJobDurationSeconds.WithLabelValues(key.Namespace, key.Name, result).Observe(cond.LastTransitionTime.Sub(start.Time).Seconds())
+		return
+	}
+}
+
+// DeletePool removes all of the per-pool series for a pool that no longer
+// exists.
+func DeletePool(key client.ObjectKey) {
+	for _, phase := range []string{PhaseInitializing, PhaseVerifying, PhaseAvailable, PhaseStale} {
+		PoolReplicas.DeleteLabelValues(key.Namespace, key.Name, phase)
+	}
+
+	PoolStaleReplicasTotal.DeleteLabelValues(key.Namespace, key.Name)
+
+	for _, job := range []string{JobInit, JobHealthCheck} {
+		PoolFailedReplicasTotal.DeleteLabelValues(key.Namespace, key.Name, job)
+	}
+
+	for _, result := range []string{ResultSucceeded, ResultFailed} {
+		InitJobDurationSeconds.DeleteLabelValues(key.Namespace, key.Name, result)
+	}
+}
+
+func init() {
+	metrics.Registry.MustRegister(
+		PoolReplicas,
+		PoolStaleReplicasTotal,
+		PoolFailedReplicasTotal,
+		InitJobDurationSeconds,
+		CheckoutAcquisitionDurationSeconds,
+		CheckoutNotAvailableTotal,
+	)
+}
@@ -7,7 +7,9 @@ import (
 	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
 	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
 	"github.com/puppetlabs/pvpool/pkg/controller/app"
+	"github.com/puppetlabs/pvpool/pkg/controller/metrics"
 	"github.com/puppetlabs/pvpool/pkg/opt"
+	"github.com/puppetlabs/pvpool/pkg/tracing"
 	"golang.org/x/time/rate"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/util/workqueue"
@@ -20,7 +22,7 @@ import (
 )
 
 // +kubebuilder:rbac:groups=pvpool.puppet.com,resources=checkouts,verbs=get;list;watch
-// +kubebuilder:rbac:groups=pvpool.puppet.com,resources=checkouts/status,verbs=update
+// +kubebuilder:rbac:groups=pvpool.puppet.com,resources=checkouts/status,verbs=patch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=core,resources=persistentvolumes;persistentvolumeclaims,verbs=get;list;watch;create;update;delete
 
@@ -31,6 +33,9 @@ type CheckoutReconciler struct {
 var _ reconcile.Reconciler = &CheckoutReconciler{}
 
 func (pr *CheckoutReconciler) Reconcile(ctx context.Context, req reconcile.Request) (r reconcile.Result, err error) {
+	ctx, span := tracing.Start(ctx, "CheckoutReconciler.Reconcile", append(tracing.ObjectKeyAttributes(req.NamespacedName), tracing.KindKey.String("Checkout"))...)
+	defer func() { tracing.End(span, err) }()
+
 	klog.InfoS("checkout reconciler: starting reconcile for checkout", "checkout", req.NamespacedName)
 	defer klog.InfoS("checkout reconciler: ending reconcile for checkout", "checkout", req.NamespacedName)
 	defer func() {
@@ -46,6 +51,8 @@ func (pr *CheckoutReconciler) Reconcile(ctx context.Context, req reconcile.Reque
 
 	cs := app.NewCheckoutState(checkout)
 	defer func() {
+		prev, _ := checkout.Condition(pvpoolv1alpha1.CheckoutAcquired)
+
 		checkout = app.ConfigureCheckout(cs)
 		if serr := checkout.PersistStatus(ctx, pr.cl); serr != nil {
 			if err == nil {
@@ -53,6 +60,24 @@ func (pr *CheckoutReconciler) Reconcile(ctx context.Context, req reconcile.Reque
 			} else {
 				klog.ErrorS(serr, "checkout reconciler: failed to update checkout status", "pool", req.NamespacedName)
 			}
+			return
+		}
+
+		// Record how long it took to acquire the PVC the first time we
+		// observe it.
+		if next, _ := checkout.Condition(pvpoolv1alpha1.CheckoutAcquired); next.Status == corev1.ConditionTrue && prev.Status != corev1.ConditionTrue {
+			// The source records the pool the volume actually came from, which
+			// accounts for cluster pools.
+			poolRef := checkout.Object.Spec.PoolRef
+			if src := checkout.Object.Status.Source; src != nil {
+				poolRef = src.PoolRef
+			} else if poolRef.Namespace == "" {
+				poolRef.Namespace = checkout.Key.Namespace
+			}
+
+			metrics.CheckoutAcquisitionDurationSeconds.
+				WithLabelValues(poolRef.Namespace, poolRef.Name).
+				Observe(next.LastTransitionTime.Sub(checkout.Object.GetCreationTimestamp().Time).Seconds())
 		}
 	}()
 
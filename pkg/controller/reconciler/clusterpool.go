@@ -0,0 +1,97 @@
+package reconciler
+
+import (
+	"context"
+	"time"
+
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
+	"github.com/puppetlabs/pvpool/pkg/controller/app"
+	"github.com/puppetlabs/pvpool/pkg/opt"
+	"github.com/puppetlabs/pvpool/pkg/tracing"
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// +kubebuilder:rbac:groups=pvpool.puppet.com,resources=clusterpools,verbs=get;list;watch
+// +kubebuilder:rbac:groups=pvpool.puppet.com,resources=clusterpools/status,verbs=patch
+// +kubebuilder:rbac:groups=pvpool.puppet.com,resources=pools,verbs=create
+
+type ClusterPoolReconciler struct {
+	cl        client.Client
+	namespace string
+}
+
+var _ reconcile.Reconciler = &ClusterPoolReconciler{}
+
+func (cpr *ClusterPoolReconciler) Reconcile(ctx context.Context, req reconcile.Request) (r reconcile.Result, err error) {
+	ctx, span := tracing.Start(ctx, "ClusterPoolReconciler.Reconcile", append(tracing.ObjectKeyAttributes(req.NamespacedName), tracing.KindKey.String("ClusterPool"))...)
+	defer func() { tracing.End(span, err) }()
+
+	klog.InfoS("cluster pool reconciler: starting reconcile for cluster pool", "clusterpool", req.Name)
+	defer klog.InfoS("cluster pool reconciler: ending reconcile for cluster pool", "clusterpool", req.Name)
+	defer func() {
+		if err != nil {
+			klog.ErrorS(err, "cluster pool reconciler: failed to reconcile cluster pool", "clusterpool", req.Name)
+		}
+	}()
+
+	cp := pvpoolv1alpha1obj.NewClusterPool(req.Name)
+	if ok, err := cp.Load(ctx, cpr.cl); err != nil || !ok {
+		return reconcile.Result{}, err
+	}
+
+	if cp.Object.GetDeletionTimestamp() != nil {
+		// The backing pool is garbage collected.
+		return reconcile.Result{}, nil
+	}
+
+	cps := app.NewClusterPoolState(cp, cpr.namespace)
+	defer func() {
+		cp = app.ConfigureClusterPool(cps)
+		if serr := cp.PersistStatus(ctx, cpr.cl); serr != nil {
+			if err == nil {
+				err = serr
+			} else {
+				klog.ErrorS(serr, "cluster pool reconciler: failed to update cluster pool status", "clusterpool", req.Name)
+			}
+		}
+	}()
+
+	if _, err := cps.Load(ctx, cpr.cl); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	cps = app.ConfigureClusterPoolState(cps)
+
+	err = cps.Persist(ctx, cpr.cl)
+	return
+}
+
+func NewClusterPoolReconciler(cl client.Client, namespace string) *ClusterPoolReconciler {
+	return &ClusterPoolReconciler{
+		cl:        cl,
+		namespace: namespace,
+	}
+}
+
+func AddClusterPoolReconcilerToManager(mgr manager.Manager, cfg *opt.Config) error {
+	rl := workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, cfg.ControllerMaxReconcileBackoffDuration),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+	)
+
+	r := NewClusterPoolReconciler(mgr.GetClient(), cfg.ClusterPoolNamespace)
+
+	return builder.ControllerManagedBy(mgr).
+		For(&pvpoolv1alpha1.ClusterPool{}).
+		Owns(&pvpoolv1alpha1.Pool{}).
+		WithOptions(controller.Options{RateLimiter: rl}).
+		Complete(r)
+}
@@ -8,23 +8,32 @@ import (
 	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
 	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
 	"github.com/puppetlabs/pvpool/pkg/controller/app"
+	"github.com/puppetlabs/pvpool/pkg/controller/metrics"
 	"github.com/puppetlabs/pvpool/pkg/opt"
+	"github.com/puppetlabs/pvpool/pkg/tracing"
 	"golang.org/x/time/rate"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // +kubebuilder:rbac:groups=pvpool.puppet.com,resources=pools,verbs=get;list;watch;update
-// +kubebuilder:rbac:groups=pvpool.puppet.com,resources=pools/status,verbs=update
+// +kubebuilder:rbac:groups=pvpool.puppet.com,resources=pools/status,verbs=patch
+// +kubebuilder:rbac:groups=pvpool.puppet.com,resources=poolpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=pvpool.puppet.com,resources=checkouts,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=core,resources=persistentvolumes,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=core,resources=events,verbs=list
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
 
 const (
@@ -32,12 +41,16 @@ const (
 )
 
 type PoolReconciler struct {
-	cl client.Client
+	cl     client.Client
+	reader client.Reader
 }
 
 var _ reconcile.Reconciler = &PoolReconciler{}
 
 func (pr *PoolReconciler) Reconcile(ctx context.Context, req reconcile.Request) (r reconcile.Result, err error) {
+	ctx, span := tracing.Start(ctx, "PoolReconciler.Reconcile", append(tracing.ObjectKeyAttributes(req.NamespacedName), tracing.KindKey.String("Pool"))...)
+	defer func() { tracing.End(span, err) }()
+
 	klog.InfoS("pool reconciler: starting reconcile for pool", "pool", req.NamespacedName)
 	defer klog.InfoS("pool reconciler: ending reconcile for pool", "pool", req.NamespacedName)
 	defer func() {
@@ -47,17 +60,31 @@ func (pr *PoolReconciler) Reconcile(ctx context.Context, req reconcile.Request)
 	}()
 
 	pool := pvpoolv1alpha1obj.NewPool(req.NamespacedName)
-	if ok, err := pool.Load(ctx, pr.cl); err != nil || !ok {
+	if ok, err := pool.Load(ctx, pr.cl); err != nil {
 		return reconcile.Result{}, err
+	} else if !ok {
+		metrics.DeletePool(req.NamespacedName)
+		return reconcile.Result{}, nil
 	}
 
 	ps := app.NewPoolState(pool)
+	ps.EventReader = pr.reader
 	defer func() {
 		pool = app.ConfigurePool(ps)
 		if pool.Finalizing() {
+			metrics.DeletePool(req.NamespacedName)
 			return
 		}
 
+		// Stale replicas deleted in this pass still count as stale so that the
+		// gauge reflects the pool as it was loaded.
+		metrics.SetPoolReplicas(req.NamespacedName, map[string]int{
+			metrics.PhaseInitializing: len(ps.Initializing),
+			metrics.PhaseVerifying:    len(ps.Verifying),
+			metrics.PhaseAvailable:    len(ps.Available),
+			metrics.PhaseStale:        len(ps.Stale) + len(ps.Deleted),
+		})
+
 		if serr := pool.PersistStatus(ctx, pr.cl); serr != nil {
 			if err == nil {
 				err = serr
@@ -72,22 +99,34 @@ func (pr *PoolReconciler) Reconcile(ctx context.Context, req reconcile.Request)
 	}
 
 	finalized, err := lifecycle.Finalize(ctx, pr.cl, PoolReconcilerFinalizerName, pool, func() error {
-		_, err := ps.Delete(ctx, pr.cl)
-		return err
+		return ps.Finalize(ctx, pr.cl)
 	})
 	if err != nil || finalized {
 		return reconcile.Result{}, err
 	}
 
+	if err := ps.LoadHealthCheckRequests(ctx, pr.cl); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	ps = app.ConfigurePoolState(ps)
 
-	err = ps.Persist(ctx, pr.cl)
+	if err = ps.Persist(ctx, pr.cl); err != nil {
+		return
+	}
+
+	// Come back when the next health check is due or a pending PVC needs
+	// attention.
+	if d, ok := ps.RequeueAfter(time.Now()); ok {
+		r.RequeueAfter = d
+	}
 	return
 }
 
-func NewPoolReconciler(cl client.Client) *PoolReconciler {
+func NewPoolReconciler(cl client.Client, reader client.Reader) *PoolReconciler {
 	return &PoolReconciler{
-		cl: cl,
+		cl:     cl,
+		reader: reader,
 	}
 }
 
@@ -97,7 +136,13 @@ func AddPoolReconcilerToManager(mgr manager.Manager, cfg *opt.Config) error {
 		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
 	)
 
-	r := NewPoolReconciler(mgr.GetClient())
+	// Checkouts that are waiting for a health check are looked up by the
+	// pool they refer to.
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &pvpoolv1alpha1.Checkout{}, app.CheckoutPoolRefIndexField, app.CheckoutPoolRefIndexValues); err != nil {
+		return err
+	}
+
+	r := NewPoolReconciler(mgr.GetClient(), mgr.GetAPIReader())
 
 	return builder.ControllerManagedBy(mgr).
 		For(&pvpoolv1alpha1.Pool{}).
@@ -106,6 +151,87 @@ func AddPoolReconcilerToManager(mgr manager.Manager, cfg *opt.Config) error {
 			&source.Kind{Type: &batchv1.Job{}},
 			app.DependencyManager.NewEnqueueRequestForAnnotatedDependencyOf(&pvpoolv1alpha1.Pool{}),
 		).
+		Watches(
+			&source.Kind{Type: &corev1.PersistentVolumeClaim{}},
+			handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+				// Controlled PVCs are handled by Owns() above. We're only
+				// interested in PVCs that a pool might adopt.
+				if metav1.GetControllerOf(obj) != nil {
+					return nil
+				}
+
+				pools := &pvpoolv1alpha1.PoolList{}
+				if err := mgr.GetClient().List(context.Background(), pools, client.InNamespace(obj.GetNamespace())); err != nil {
+					klog.ErrorS(err, "pool reconciler: failed to list pools for PVC", "pvc", client.ObjectKeyFromObject(obj))
+					return nil
+				}
+
+				var reqs []reconcile.Request
+				for i := range pools.Items {
+					pa := pools.Items[i].Spec.Adoption
+					if pa == nil {
+						continue
+					}
+
+					sel, err := metav1.LabelSelectorAsSelector(&pa.Selector)
+					if err != nil || !sel.Matches(labels.Set(obj.GetLabels())) {
+						continue
+					}
+
+					reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&pools.Items[i])})
+				}
+				return reqs
+			}),
+		).
+		Watches(
+			&source.Kind{Type: &pvpoolv1alpha1.Checkout{}},
+			handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+				// Checkouts that need a verified replica record it in their
+				// status, and we start the health check for them.
+				co, ok := obj.(*pvpoolv1alpha1.Checkout)
+				if !ok || !app.CheckoutWaitingForHealthCheck(co) {
+					return nil
+				}
+
+				ref := co.Spec.PoolRef
+				if ref.Kind != pvpoolv1alpha1.ClusterPoolKind.Kind {
+					namespace := ref.Namespace
+					if namespace == "" {
+						namespace = co.GetNamespace()
+					}
+
+					return []reconcile.Request{{NamespacedName: client.ObjectKey{Namespace: namespace, Name: ref.Name}}}
+				}
+
+				cp := &pvpoolv1alpha1.ClusterPool{}
+				if err := mgr.GetClient().Get(context.Background(), client.ObjectKey{Name: ref.Name}, cp); err != nil {
+					klog.ErrorS(err, "pool reconciler: failed to get cluster pool for checkout", "checkout", client.ObjectKeyFromObject(obj))
+					return nil
+				} else if cp.Status.PoolRef == nil {
+					return nil
+				}
+
+				return []reconcile.Request{{NamespacedName: client.ObjectKey{Namespace: cp.Status.PoolRef.Namespace, Name: cp.Status.PoolRef.Name}}}
+			}),
+		).
+		Watches(
+			&source.Kind{Type: &pvpoolv1alpha1.PoolPolicy{}},
+			handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+				// Any change to a policy could affect every pool in the
+				// cluster.
+				pools := &pvpoolv1alpha1.PoolList{}
+				if err := mgr.GetClient().List(context.Background(), pools); err != nil {
+					klog.ErrorS(err, "pool reconciler: failed to list pools for policy", "policy", obj.GetName())
+					return nil
+				}
+
+				reqs := make([]reconcile.Request, len(pools.Items))
+				for i := range pools.Items {
+					reqs[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&pools.Items[i])}
+				}
+				return reqs
+			}),
+		).
 		WithOptions(controller.Options{RateLimiter: rl}).
 		Complete(r)
 }
@@ -0,0 +1,107 @@
+package reconciler
+
+import (
+	"context"
+	"time"
+
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
+	"github.com/puppetlabs/pvpool/pkg/controller/app"
+	"github.com/puppetlabs/pvpool/pkg/opt"
+	"github.com/puppetlabs/pvpool/pkg/tracing"
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// +kubebuilder:rbac:groups=pvpool.puppet.com,resources=pooltemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=pvpool.puppet.com,resources=clusterpools,verbs=update
+
+type PoolTemplateReconciler struct {
+	cl client.Client
+}
+
+var _ reconcile.Reconciler = &PoolTemplateReconciler{}
+
+func (ptr *PoolTemplateReconciler) Reconcile(ctx context.Context, req reconcile.Request) (r reconcile.Result, err error) {
+	ctx, span := tracing.Start(ctx, "PoolTemplateReconciler.Reconcile", append(tracing.ObjectKeyAttributes(req.NamespacedName), tracing.KindKey.String("PoolTemplate"))...)
+	defer func() { tracing.End(span, err) }()
+
+	klog.InfoS("pool template reconciler: starting reconcile for pool template", "pooltemplate", req.Name)
+	defer klog.InfoS("pool template reconciler: ending reconcile for pool template", "pooltemplate", req.Name)
+	defer func() {
+		if err != nil {
+			klog.ErrorS(err, "pool template reconciler: failed to reconcile pool template", "pooltemplate", req.Name)
+		}
+	}()
+
+	pts := app.NewPoolTemplateState(pvpoolv1alpha1obj.NewPoolTemplate(req.Name))
+	if ok, err := pts.Load(ctx, ptr.cl); err != nil || !ok {
+		// Pools keep their last copy of a deleted template.
+		return reconcile.Result{}, err
+	}
+
+	pts = app.ConfigurePoolTemplateState(ctx, pts)
+
+	err = pts.Persist(ctx, ptr.cl)
+	return
+}
+
+func NewPoolTemplateReconciler(cl client.Client) *PoolTemplateReconciler {
+	return &PoolTemplateReconciler{
+		cl: cl,
+	}
+}
+
+func AddPoolTemplateReconcilerToManager(mgr manager.Manager, cfg *opt.Config) error {
+	rl := workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, cfg.ControllerMaxReconcileBackoffDuration),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+	)
+
+	r := NewPoolTemplateReconciler(mgr.GetClient())
+
+	// Pools are normally configured from their template by the webhook, but
+	// we check them again whenever their spec changes in case the template
+	// changed in the meantime.
+	enqueueTemplate := handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+		var spec *pvpoolv1alpha1.PoolSpec
+		switch obj := obj.(type) {
+		case *pvpoolv1alpha1.Pool:
+			spec = &obj.Spec
+		case *pvpoolv1alpha1.ClusterPool:
+			spec = &obj.Spec
+		}
+
+		if spec == nil || spec.TemplateRef == nil {
+			return nil
+		}
+
+		return []reconcile.Request{
+			{NamespacedName: client.ObjectKey{Name: spec.TemplateRef.Name}},
+		}
+	})
+
+	return builder.ControllerManagedBy(mgr).
+		For(&pvpoolv1alpha1.PoolTemplate{}).
+		Watches(
+			&source.Kind{Type: &pvpoolv1alpha1.Pool{}},
+			enqueueTemplate,
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&source.Kind{Type: &pvpoolv1alpha1.ClusterPool{}},
+			enqueueTemplate,
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		WithOptions(controller.Options{RateLimiter: rl}).
+		Complete(r)
+}
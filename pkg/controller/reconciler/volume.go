@@ -0,0 +1,116 @@
+package reconciler
+
+import (
+	"context"
+	"time"
+
+	corev1obj "github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/api/corev1"
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	"github.com/puppetlabs/pvpool/pkg/controller/app"
+	"github.com/puppetlabs/pvpool/pkg/opt"
+	"github.com/puppetlabs/pvpool/pkg/tracing"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=persistentvolumes,verbs=get;list;watch;update;delete
+
+// VolumeReconciler cleans up PVs left behind by checkouts that were deleted or
+// interrupted before they finished taking over a PV from a pool.
+type VolumeReconciler struct {
+	cl client.Client
+}
+
+var _ reconcile.Reconciler = &VolumeReconciler{}
+
+func (vr *VolumeReconciler) Reconcile(ctx context.Context, req reconcile.Request) (r reconcile.Result, err error) {
+	ctx, span := tracing.Start(ctx, "VolumeReconciler.Reconcile", append(tracing.ObjectKeyAttributes(req.NamespacedName), tracing.KindKey.String("PersistentVolume"))...)
+	defer func() { tracing.End(span, err) }()
+
+	klog.V(4).InfoS("volume reconciler: starting reconcile for volume", "pv", req.Name)
+	defer klog.V(4).InfoS("volume reconciler: ending reconcile for volume", "pv", req.Name)
+	defer func() {
+		if err != nil {
+			klog.ErrorS(err, "volume reconciler: failed to reconcile volume", "pv", req.Name)
+		}
+	}()
+
+	vs := app.NewVolumeState(corev1obj.NewPersistentVolume(req.Name))
+	if ok, err := vs.Load(ctx, vr.cl); err != nil || !ok {
+		return reconcile.Result{}, err
+	}
+
+	err = vs.Persist(ctx, vr.cl)
+	return
+}
+
+func NewVolumeReconciler(cl client.Client) *VolumeReconciler {
+	return &VolumeReconciler{
+		cl: cl,
+	}
+}
+
+func AddVolumeReconcilerToManager(mgr manager.Manager, cfg *opt.Config) error {
+	rl := workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, cfg.ControllerMaxReconcileBackoffDuration),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+	)
+
+	r := NewVolumeReconciler(mgr.GetClient())
+
+	// A released volume is cleaned up once the checkout recorded on it is
+	// gone, so we look at it again whenever a checkout is deleted.
+	enqueueVolumes := handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+		pvs := &corev1.PersistentVolumeList{}
+		if err := mgr.GetClient().List(context.Background(), pvs); err != nil {
+			klog.ErrorS(err, "volume reconciler: failed to list volumes", "checkout", client.ObjectKeyFromObject(obj))
+			return nil
+		}
+
+		var reqs []reconcile.Request
+		for _, pv := range pvs.Items {
+			annotations := pv.GetAnnotations()
+			if annotations[app.CheckoutNamespaceAnnotationKey] != obj.GetNamespace() || annotations[app.CheckoutNameAnnotationKey] != obj.GetName() {
+				continue
+			}
+
+			reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKey{Name: pv.GetName()}})
+		}
+		return reqs
+	})
+	deleted := predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		UpdateFunc:  func(event.UpdateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return true },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+
+	return builder.ControllerManagedBy(mgr).
+		For(
+			&corev1.PersistentVolume{},
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				// We only care about volumes that a checkout has modified.
+				_, ok := obj.GetAnnotations()[app.CheckoutReclaimPolicyAnnotationKey]
+				return ok
+			})),
+		).
+		Watches(
+			&source.Kind{Type: &pvpoolv1alpha1.Checkout{}},
+			enqueueVolumes,
+			builder.WithPredicates(deleted),
+		).
+		WithOptions(controller.Options{RateLimiter: rl}).
+		Complete(r)
+}
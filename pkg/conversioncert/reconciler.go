@@ -0,0 +1,121 @@
+// Package conversioncert manages the caBundle value for custom resource
+// definitions that use the conversion webhook.
+//
+// It complements the webhookcert reconciler, which only supports admission
+// webhook configurations, by reading the same certificate secret.
+package conversioncert
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+
+	corev1obj "github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/api/corev1"
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type Reconciler struct {
+	cl                            client.Client
+	customResourceDefinitionNames []string
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (res reconcile.Result, err error) {
+	klog.InfoS("conversion certificate reconciler: starting reconcile", "secret", req.NamespacedName)
+	defer klog.InfoS("conversion certificate reconciler: ending reconcile", "secret", req.NamespacedName)
+	defer func() {
+		if err != nil {
+			klog.ErrorS(err, "conversion certificate reconciler: failed to reconcile", "secret", req.NamespacedName)
+		}
+	}()
+
+	secret := corev1obj.NewTLSSecret(req.NamespacedName)
+	if _, err := (lifecycle.RequiredLoader{Loader: secret}).Load(ctx, r.cl); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	cert, err := secret.Certificate()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if len(cert.Certificate) < 2 {
+		return reconcile.Result{}, fmt.Errorf("certificate in secret is missing chain")
+	}
+
+	var caBundle []byte
+	for _, certDER := range cert.Certificate[1:] {
+		certPEM := pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: certDER,
+		})
+		caBundle = append(caBundle, certPEM...)
+	}
+
+	var requeue bool
+
+	for _, name := range r.customResourceDefinitionNames {
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := r.cl.Get(ctx, client.ObjectKey{Name: name}, crd); err != nil {
+			klog.ErrorS(err, "conversion certificate reconciler: failed to load", "customresourcedefinition", name)
+			requeue = true
+			continue
+		}
+
+		conv := crd.Spec.Conversion
+		if conv == nil || conv.Strategy != apiextensionsv1.WebhookConverter || conv.Webhook == nil || conv.Webhook.ClientConfig == nil {
+			klog.V(4).InfoS("conversion certificate reconciler: custom resource definition does not use a conversion webhook", "customresourcedefinition", name)
+			continue
+		}
+
+		conv.Webhook.ClientConfig.CABundle = caBundle
+
+		if err := r.cl.Update(ctx, crd); err != nil {
+			klog.ErrorS(err, "conversion certificate reconciler: failed to persist", "customresourcedefinition", name)
+			requeue = true
+			continue
+		}
+
+		klog.V(4).InfoS("conversion certificate reconciler: updated CA bundle", "customresourcedefinition", name)
+	}
+
+	return reconcile.Result{Requeue: requeue}, nil
+}
+
+type Option func(r *Reconciler)
+
+func WithCustomResourceDefinition(name string) Option {
+	return func(r *Reconciler) {
+		r.customResourceDefinitionNames = append(r.customResourceDefinitionNames, name)
+	}
+}
+
+func NewReconciler(cl client.Client, opts ...Option) *Reconciler {
+	r := &Reconciler{
+		cl: cl,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+func AddReconcilerToManager(mgr manager.Manager, secretKey client.ObjectKey, opts ...Option) error {
+	r := NewReconciler(mgr.GetClient(), opts...)
+
+	return builder.ControllerManagedBy(mgr).
+		Named("conversioncert").
+		For(&corev1.Secret{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return client.ObjectKeyFromObject(obj) == secretKey
+		}))).
+		Complete(r)
+}
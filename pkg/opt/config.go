@@ -17,6 +17,10 @@ type Config struct {
 	// known.
 	Namespace string
 
+	// ClusterPoolNamespace is the namespace that holds the replicas of cluster
+	// pools. If not specified, it is the namespace of this deployment.
+	ClusterPoolNamespace string
+
 	// ControllerMaxReconcileBackoffDuration is the amount of time the
 	// controller may wait to reprocess an object that has encountered an error.
 	ControllerMaxReconcileBackoffDuration time.Duration
@@ -32,6 +36,29 @@ type Config struct {
 	// ValidatingWebhookConfigurationName is the name of the admission webhook
 	// configuration for the API server to communicate with our webhook.
 	ValidatingWebhookConfigurationName string
+
+	// MutatingWebhookConfigurationName is the name of the admission webhook
+	// configuration that sets default values for our objects.
+	MutatingWebhookConfigurationName string
+
+	// ConversionCustomResourceDefinitionNames are the names of the custom
+	// resource definitions to install the conversion webhook CA bundle into.
+	ConversionCustomResourceDefinitionNames []string
+
+	// TracingOTLPEndpoint is the host and port of an OpenTelemetry collector
+	// that accepts traces over gRPC. Tracing is disabled if it is not set.
+	TracingOTLPEndpoint string
+
+	// TracingOTLPInsecure disables TLS when connecting to the collector.
+	TracingOTLPInsecure bool
+
+	// TracingSampleRatio is the fraction of traces to sample, between 0 and 1.
+	TracingSampleRatio float64
+}
+
+// TracingEnabled returns true if traces should be exported.
+func (c *Config) TracingEnabled() bool {
+	return c.TracingOTLPEndpoint != ""
 }
 
 func NewConfig(defaultName string) *Config {
@@ -40,14 +67,30 @@ func NewConfig(defaultName string) *Config {
 
 	viper.SetDefault("name", defaultName)
 	viper.SetDefault("controller_max_reconcile_backoff_duration", 1*time.Minute)
+	viper.SetDefault("conversion_custom_resource_definition_names", []string{
+		"checkouts.pvpool.puppet.com",
+		"clusterpools.pvpool.puppet.com",
+		"poolpolicies.pvpool.puppet.com",
+		"pools.pvpool.puppet.com",
+		"pooltemplates.pvpool.puppet.com",
+	})
+	viper.SetDefault("tracing_sample_ratio", 1.0)
+
+	viper.SetDefault("cluster_pool_namespace", viper.GetString("namespace"))
 
 	return &Config{
-		Debug:                                 viper.GetBool("debug"),
-		Name:                                  viper.GetString("name"),
-		Namespace:                             viper.GetString("namespace"),
-		ControllerMaxReconcileBackoffDuration: viper.GetDuration("controller_max_reconcile_backoff_duration"),
-		WebhookServiceName:                    viper.GetString("webhook_service_name"),
-		WebhookCertificateSecretName:          viper.GetString("webhook_certificate_secret_name"),
-		ValidatingWebhookConfigurationName:    viper.GetString("validating_webhook_configuration_name"),
+		Debug:                                   viper.GetBool("debug"),
+		Name:                                    viper.GetString("name"),
+		Namespace:                               viper.GetString("namespace"),
+		ClusterPoolNamespace:                    viper.GetString("cluster_pool_namespace"),
+		ControllerMaxReconcileBackoffDuration:   viper.GetDuration("controller_max_reconcile_backoff_duration"),
+		WebhookServiceName:                      viper.GetString("webhook_service_name"),
+		WebhookCertificateSecretName:            viper.GetString("webhook_certificate_secret_name"),
+		ValidatingWebhookConfigurationName:      viper.GetString("validating_webhook_configuration_name"),
+		MutatingWebhookConfigurationName:        viper.GetString("mutating_webhook_configuration_name"),
+		ConversionCustomResourceDefinitionNames: viper.GetStringSlice("conversion_custom_resource_definition_names"),
+		TracingOTLPEndpoint:                     viper.GetString("tracing_otlp_endpoint"),
+		TracingOTLPInsecure:                     viper.GetBool("tracing_otlp_insecure"),
+		TracingSampleRatio:                      viper.GetFloat64("tracing_sample_ratio"),
 	}
 }
@@ -0,0 +1,82 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	"github.com/puppetlabs/pvpool/pkg/checkout"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func newCheckoutCommand(o *Options) *cobra.Command {
+	var (
+		name          string
+		poolNamespace string
+		clusterPool   bool
+		claimName     string
+		accessModes   []string
+		timeout       time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "checkout POOL",
+		Short: "Check out a PVC from a pool and print its name",
+		Long: "Creates a checkout for the given pool and waits for its PVC to be ready to use. " +
+			"The name of the PVC is printed to standard output. If the PVC isn't ready before the timeout, " +
+			"or the command is interrupted, the checkout is deleted.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			cs, namespace, err := o.Clientset()
+			if err != nil {
+				return err
+			}
+
+			poolRef := pvpoolv1alpha1.PoolReference{
+				Namespace: poolNamespace,
+				Name:      args[0],
+			}
+			if clusterPool {
+				poolRef.Kind = pvpoolv1alpha1.ClusterPoolKind.Kind
+			}
+
+			opts := checkout.AcquireOptions{
+				Namespace: namespace,
+				Name:      name,
+				ClaimName: claimName,
+			}
+			for _, mode := range accessModes {
+				opts.AccessModes = append(opts.AccessModes, corev1.PersistentVolumeAccessMode(mode))
+			}
+
+			fmt.Fprintln(cmd.ErrOrStderr(), "Creating checkout, waiting for its PVC to be ready...")
+
+			// Acquire deletes the checkout if it fails, including when we time
+			// out or are interrupted.
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			co, err := checkout.Acquire(ctx, cs, poolRef, opts)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), checkout.ClaimName(co))
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&name, "name", "", "Name of the checkout to create (generated from the pool name if not specified)")
+	flags.StringVar(&poolNamespace, "pool-namespace", "", "Namespace of the pool, if different from the checkout's namespace")
+	flags.BoolVar(&clusterPool, "cluster-pool", false, "Check out from a cluster pool instead of a pool")
+	flags.StringVar(&claimName, "claim-name", "", "Name of the PVC to create (defaults to the name of the checkout)")
+	flags.StringSliceVar(&accessModes, "access-mode", nil, "Access modes for the PVC (defaults to ReadWriteOnce)")
+	flags.DurationVar(&timeout, "timeout", 5*time.Minute, "How long to wait for the PVC to be ready")
+
+	return cmd
+}
@@ -0,0 +1,140 @@
+package plugin
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	"github.com/puppetlabs/pvpool/pkg/controller/app"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newDescribeCommand(o *Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "describe POOL",
+		Short: "Show a pool with its replicas, jobs, volumes, and checkouts",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			cl, namespace, err := o.Client()
+			if err != nil {
+				return err
+			}
+
+			key := client.ObjectKey{Namespace: namespace, Name: args[0]}
+
+			ps, err := loadPoolState(ctx, cl, key)
+			if err != nil {
+				return err
+			}
+
+			checkouts, err := poolCheckouts(ctx, cl, key)
+			if err != nil {
+				return err
+			}
+
+			return describePool(cmd.OutOrStdout(), ps, checkouts, time.Now())
+		},
+	}
+}
+
+func describePool(out io.Writer, ps *app.PoolState, checkouts []pvpoolv1alpha1.Checkout, now time.Time) error {
+	pool := ps.Pool.Object
+
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Name:\t%s\n", pool.GetName())
+	fmt.Fprintf(w, "Namespace:\t%s\n", pool.GetNamespace())
+	fmt.Fprintf(w, "Age:\t%s\n", age(pool.GetCreationTimestamp().Time, now))
+	fmt.Fprintf(w, "Generation:\t%d (observed %d)\n", pool.GetGeneration(), pool.Status.ObservedGeneration)
+	fmt.Fprintf(w, "Template Hash:\t%s\n", app.PoolTemplateHash(ps.Pool))
+	fmt.Fprintf(w, "Deletion Policy:\t%s\n", pool.Spec.DeletionPolicy)
+	fmt.Fprintf(w, "Replicas:\t%d desired | %d initializing | %d verifying | %d available | %d stale\n",
+		ps.DesiredReplicas(), len(ps.Initializing), len(ps.Verifying), len(ps.Available), len(ps.Stale))
+
+	fmt.Fprintln(w, "Conditions:")
+	if len(pool.Status.Conditions) == 0 {
+		fmt.Fprintln(w, "  <none>")
+	} else {
+		fmt.Fprintln(w, "  TYPE\tSTATUS\tREASON\tMESSAGE")
+		for _, cond := range pool.Status.Conditions {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", cond.Type, cond.Status, cond.Reason, cond.Message)
+		}
+	}
+
+	fmt.Fprintln(w, "Replicas:")
+	replicas := poolReplicas(ps)
+	if len(replicas) == 0 {
+		fmt.Fprintln(w, "  <none>")
+	} else {
+		fmt.Fprintln(w, "  CLAIM\tPHASE\tCLAIM STATUS\tVOLUME\tCAPACITY\tRECLAIM POLICY\tINIT JOB\tHEALTH CHECK JOB\tTEMPLATE HASH\tAGE")
+		for _, r := range replicas {
+			pvc := r.Replica.PersistentVolumeClaim.Object
+
+			volume, capacity, reclaimPolicy := "-", "-", "-"
+			if pv := r.Replica.PersistentVolume; pv != nil {
+				volume = pv.Name
+				capacity = pv.Object.Spec.Capacity.Storage().String()
+				reclaimPolicy = string(pv.Object.Spec.PersistentVolumeReclaimPolicy)
+			}
+
+			templateHash := pvc.GetAnnotations()[app.PoolReplicaTemplateHashAnnotationKey]
+			if templateHash == "" {
+				templateHash = "-"
+			}
+
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				pvc.GetName(),
+				r.Phase,
+				pvc.Status.Phase,
+				volume,
+				capacity,
+				reclaimPolicy,
+				jobState(r.Replica.InitJob),
+				jobState(r.Replica.HealthCheckJob),
+				templateHash,
+				age(pvc.GetCreationTimestamp().Time, now),
+			)
+		}
+	}
+
+	fmt.Fprintln(w, "Checkouts:")
+	if len(checkouts) == 0 {
+		fmt.Fprintln(w, "  <none>")
+	} else {
+		fmt.Fprintln(w, "  NAMESPACE\tNAME\tCLAIM\tVOLUME\tACQUIRED\tAGE")
+		for _, checkout := range checkouts {
+			acquired := "Unknown"
+			for _, cond := range checkout.Status.Conditions {
+				if cond.Type == pvpoolv1alpha1.CheckoutAcquired {
+					acquired = fmt.Sprintf("%s (%s)", cond.Status, cond.Reason)
+				}
+			}
+
+			claim := checkout.Status.VolumeClaimRef.Name
+			if claim == "" {
+				claim = "-"
+			}
+
+			volume := checkout.Status.VolumeName
+			if volume == "" {
+				volume = "-"
+			}
+
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\n",
+				checkout.GetNamespace(),
+				checkout.GetName(),
+				claim,
+				volume,
+				acquired,
+				age(checkout.GetCreationTimestamp().Time, now),
+			)
+		}
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,120 @@
+// Package plugin implements the kubectl-pvpool command, a kubectl plugin for
+// inspecting and using pools and checkouts.
+package plugin
+
+import (
+	"fmt"
+	"time"
+
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	"github.com/puppetlabs/pvpool/pkg/client/clientset/versioned"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var schemes = runtime.NewSchemeBuilder(
+	scheme.AddToScheme,
+	pvpoolv1alpha1.AddToScheme,
+)
+
+// Options are the connection settings shared by every subcommand.
+type Options struct {
+	loadingRules *clientcmd.ClientConfigLoadingRules
+	overrides    *clientcmd.ConfigOverrides
+}
+
+func (o *Options) clientConfig() (*rest.Config, string, error) {
+	cc := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(o.loadingRules, o.overrides)
+
+	namespace, _, err := cc.Namespace()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to determine namespace: %w", err)
+	}
+
+	cfg, err := cc.ClientConfig()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load client configuration: %w", err)
+	}
+
+	return cfg, namespace, nil
+}
+
+// Client returns a Kubernetes client and the namespace to use for commands
+// that operate on namespaced objects.
+func (o *Options) Client() (client.Client, string, error) {
+	cfg, namespace, err := o.clientConfig()
+	if err != nil {
+		return nil, "", err
+	}
+
+	s := runtime.NewScheme()
+	if err := schemes.AddToScheme(s); err != nil {
+		return nil, "", fmt.Errorf("failed to create scheme: %w", err)
+	}
+
+	cl, err := client.New(cfg, client.Options{Scheme: s})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create client: %w", err)
+	}
+
+	return cl, namespace, nil
+}
+
+// Clientset returns a typed PVPool clientset and the namespace to use for
+// commands that operate on namespaced objects.
+func (o *Options) Clientset() (versioned.Interface, string, error) {
+	cfg, namespace, err := o.clientConfig()
+	if err != nil {
+		return nil, "", err
+	}
+
+	cs, err := versioned.NewForConfig(cfg)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create clientset: %w", err)
+	}
+
+	return cs, namespace, nil
+}
+
+// NewCommand creates the root kubectl-pvpool command.
+func NewCommand() *cobra.Command {
+	o := &Options{
+		loadingRules: clientcmd.NewDefaultClientConfigLoadingRules(),
+		overrides:    &clientcmd.ConfigOverrides{},
+	}
+
+	cmd := &cobra.Command{
+		Use:           "kubectl-pvpool",
+		Short:         "Inspect and use PVPool pools and checkouts",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&o.loadingRules.ExplicitPath, clientcmd.RecommendedConfigPathFlag, "", "Path to the kubeconfig file to use")
+	clientcmd.BindOverrideFlags(o.overrides, flags, clientcmd.RecommendedConfigOverrideFlags(""))
+
+	cmd.AddCommand(
+		newStatusCommand(o),
+		newCheckoutCommand(o),
+		newReleaseCommand(o),
+		newDescribeCommand(o),
+		newWhyCommand(o),
+		newValidateCommand(),
+	)
+
+	return cmd
+}
+
+func age(t time.Time, now time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+
+	return duration.HumanDuration(now.Sub(t))
+}
@@ -0,0 +1,110 @@
+package plugin
+
+import (
+	"context"
+
+	batchv1obj "github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/api/batchv1"
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/helper"
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
+	"github.com/puppetlabs/pvpool/pkg/controller/app"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// loadPoolState loads a pool and its replicas the same way the controller
+// does.
+func loadPoolState(ctx context.Context, cl client.Client, key client.ObjectKey) (*app.PoolState, error) {
+	pool := pvpoolv1alpha1obj.NewPool(key)
+	if _, err := (lifecycle.RequiredLoader{Loader: pool}).Load(ctx, cl); err != nil {
+		return nil, err
+	}
+
+	ps := app.NewPoolState(pool)
+	if _, err := ps.Load(ctx, cl); err != nil {
+		return nil, err
+	}
+
+	return ps, nil
+}
+
+type phasedReplica struct {
+	Phase   pvpoolv1alpha1.PoolReplicaPhase
+	Replica *app.PoolReplica
+}
+
+// poolReplicas returns every replica in the pool with its phase, in the same
+// order the pool status uses.
+func poolReplicas(ps *app.PoolState) []phasedReplica {
+	var replicas []phasedReplica
+	for _, group := range []struct {
+		Phase    pvpoolv1alpha1.PoolReplicaPhase
+		Replicas []*app.PoolReplica
+	}{
+		{Phase: pvpoolv1alpha1.PoolReplicaPhaseStale, Replicas: ps.Stale},
+		{Phase: pvpoolv1alpha1.PoolReplicaPhaseInitializing, Replicas: ps.Initializing},
+		{Phase: pvpoolv1alpha1.PoolReplicaPhaseVerifying, Replicas: ps.Verifying},
+		{Phase: pvpoolv1alpha1.PoolReplicaPhaseAvailable, Replicas: ps.Available},
+	} {
+		for _, pr := range group.Replicas {
+			replicas = append(replicas, phasedReplica{Phase: group.Phase, Replica: pr})
+		}
+	}
+	return replicas
+}
+
+func jobState(job *batchv1obj.Job) string {
+	switch {
+	case !helper.Exists(job.Object):
+		return "-"
+	case job.Succeeded():
+		return string(pvpoolv1alpha1.PoolReplicaJobStateSucceeded)
+	case job.Failed():
+		return string(pvpoolv1alpha1.PoolReplicaJobStateFailed)
+	default:
+		return string(pvpoolv1alpha1.PoolReplicaJobStateRunning)
+	}
+}
+
+// poolCheckouts returns the checkouts that reference the given pool. If the
+// caller can't list checkouts across the cluster, only the checkouts in the
+// pool's namespace are returned.
+func poolCheckouts(ctx context.Context, cl client.Client, key client.ObjectKey) ([]pvpoolv1alpha1.Checkout, error) {
+	checkouts := &pvpoolv1alpha1.CheckoutList{}
+	if err := cl.List(ctx, checkouts); err != nil {
+		if err := cl.List(ctx, checkouts, client.InNamespace(key.Namespace)); err != nil {
+			return nil, err
+		}
+	}
+
+	var matching []pvpoolv1alpha1.Checkout
+	for _, checkout := range checkouts.Items {
+		if checkoutPoolKey(&checkout) == key {
+			matching = append(matching, checkout)
+		}
+	}
+	return matching, nil
+}
+
+func checkoutPoolKey(checkout *pvpoolv1alpha1.Checkout) client.ObjectKey {
+	// Checkouts from a cluster pool take their volumes from a pool in the
+	// storage namespace, which is only known once a volume is selected.
+	if src := checkout.Status.Source; src != nil {
+		return client.ObjectKey{
+			Namespace: src.PoolRef.Namespace,
+			Name:      src.PoolRef.Name,
+		}
+	} else if checkout.Spec.PoolRef.Kind == pvpoolv1alpha1.ClusterPoolKind.Kind {
+		return client.ObjectKey{}
+	}
+
+	namespace := checkout.Spec.PoolRef.Namespace
+	if namespace == "" {
+		namespace = checkout.GetNamespace()
+	}
+
+	return client.ObjectKey{
+		Namespace: namespace,
+		Name:      checkout.Spec.PoolRef.Name,
+	}
+}
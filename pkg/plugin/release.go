@@ -0,0 +1,37 @@
+package plugin
+
+import (
+	"fmt"
+
+	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newReleaseCommand(o *Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "release CHECKOUT...",
+		Short: "Release checkouts, deleting their PVCs",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			cl, namespace, err := o.Client()
+			if err != nil {
+				return err
+			}
+
+			for _, name := range args {
+				checkout := pvpoolv1alpha1obj.NewCheckout(client.ObjectKey{Namespace: namespace, Name: name})
+				if ok, err := checkout.Delete(ctx, cl); err != nil {
+					return fmt.Errorf("failed to release checkout %s: %w", name, err)
+				} else if !ok {
+					return fmt.Errorf("checkout %s not found in namespace %s", name, namespace)
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "checkout %s released\n", name)
+			}
+			return nil
+		},
+	}
+}
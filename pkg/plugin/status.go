@@ -0,0 +1,64 @@
+package plugin
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newStatusCommand(o *Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status [POOL]",
+		Short: "Show the number of replicas in each phase for pools",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			cl, namespace, err := o.Client()
+			if err != nil {
+				return err
+			}
+
+			var keys []client.ObjectKey
+			if len(args) > 0 {
+				keys = append(keys, client.ObjectKey{Namespace: namespace, Name: args[0]})
+			} else {
+				pools := &pvpoolv1alpha1.PoolList{}
+				if err := cl.List(ctx, pools, client.InNamespace(namespace)); err != nil {
+					return err
+				}
+
+				for i := range pools.Items {
+					keys = append(keys, client.ObjectKeyFromObject(&pools.Items[i]))
+				}
+			}
+
+			if len(keys) == 0 {
+				fmt.Fprintf(cmd.ErrOrStderr(), "No pools found in namespace %s.\n", namespace)
+				return nil
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 8, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tDESIRED\tINITIALIZING\tVERIFYING\tAVAILABLE\tSTALE")
+			for _, key := range keys {
+				ps, err := loadPoolState(ctx, cl, key)
+				if err != nil {
+					return err
+				}
+
+				fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\n",
+					key.Name,
+					ps.DesiredReplicas(),
+					len(ps.Initializing),
+					len(ps.Verifying),
+					len(ps.Available),
+					len(ps.Stale),
+				)
+			}
+			return w.Flush()
+		},
+	}
+}
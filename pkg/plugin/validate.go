@@ -0,0 +1,513 @@
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	pvpoolv1alpha1defaults "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/defaults"
+	"github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/pooltemplate"
+	pvpoolv1alpha1validation "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/validation"
+	pvpoolv1beta1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1beta1"
+	"github.com/puppetlabs/pvpool/pkg/webhook"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+func newValidateCommand() *cobra.Command {
+	var (
+		filenames []string
+		strict    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "validate -f FILENAME...",
+		Short: "Validate pool and checkout manifests without a cluster",
+		Long: "Runs the same checks as the admission webhook against pools and checkouts in the given files. " +
+			"Pools are also checked against any pool policies in the same files. " +
+			"Pool templates referenced by pools must be in the same files. " +
+			"Documents that are not PVPool resources are ignored. " +
+			"A cluster connection is not required.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(filenames) == 0 {
+				return fmt.Errorf("at least one file must be specified with -f")
+			}
+
+			var docs []*manifest
+			for _, filename := range filenames {
+				fdocs, err := readManifests(cmd.InOrStdin(), filename)
+				if err != nil {
+					return err
+				}
+				docs = append(docs, fdocs...)
+			}
+
+			var nerrs, nwarnings int
+			for _, r := range validateManifests(docs) {
+				for _, p := range r.Errors {
+					fmt.Fprintf(cmd.OutOrStdout(), "%s: %s: error: %s\n", r.Source, r.Object, p)
+				}
+				for _, p := range r.Warnings {
+					fmt.Fprintf(cmd.OutOrStdout(), "%s: %s: warning: %s\n", r.Source, r.Object, p)
+				}
+
+				nerrs += len(r.Errors)
+				nwarnings += len(r.Warnings)
+			}
+
+			if nerrs > 0 || (strict && nwarnings > 0) {
+				return fmt.Errorf("validation failed with %d error(s) and %d warning(s)", nerrs, nwarnings)
+			}
+
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVarP(&filenames, "filename", "f", nil, "Files or directories containing manifests to validate, or - for standard input")
+	flags.BoolVar(&strict, "strict", false, "Treat warnings as errors")
+
+	return cmd
+}
+
+// manifest is a single YAML or JSON document read from a file.
+type manifest struct {
+	Source string
+	Data   []byte
+}
+
+func readManifests(stdin io.Reader, filename string) ([]*manifest, error) {
+	if filename == "-" {
+		return splitManifests("<stdin>", stdin)
+	}
+
+	fi, err := os.Stat(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if !fi.IsDir() {
+		f, err := os.Open(filename)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		return splitManifests(filename, f)
+	}
+
+	var docs []*manifest
+	err = filepath.Walk(filename, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case info.IsDir():
+			return nil
+		case !strings.HasSuffix(path, ".yaml") && !strings.HasSuffix(path, ".yml") && !strings.HasSuffix(path, ".json"):
+			return nil
+		}
+
+		fdocs, err := readManifests(stdin, path)
+		if err != nil {
+			return err
+		}
+
+		docs = append(docs, fdocs...)
+		return nil
+	})
+	return docs, err
+}
+
+func splitManifests(source string, r io.Reader) ([]*manifest, error) {
+	yr := yaml.NewYAMLReader(bufio.NewReader(r))
+
+	var docs []*manifest
+	for i := 0; ; i++ {
+		doc, err := yr.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		data, err := yaml.ToJSON(doc)
+		if err != nil {
+			return nil, fmt.Errorf("%s: document %d: %w", source, i+1, err)
+		}
+
+		docs = append(docs, &manifest{
+			Source: source,
+			Data:   data,
+		})
+	}
+
+	return docs, nil
+}
+
+// validationResult contains the problems found with a single object.
+type validationResult struct {
+	Source   string
+	Object   string
+	Errors   []string
+	Warnings []string
+}
+
+func validateManifests(docs []*manifest) (results []*validationResult) {
+	var (
+		pools        []*pvpoolv1alpha1.Pool
+		clusterPools []*pvpoolv1alpha1.ClusterPool
+		checkouts    []*pvpoolv1alpha1.Checkout
+		policies     []pvpoolv1alpha1.PoolPolicy
+		templates    = make(map[string]*pvpoolv1alpha1.PoolTemplate)
+	)
+	byObject := make(map[interface{}]*validationResult)
+
+	for _, doc := range docs {
+		var tm metav1.TypeMeta
+		if err := json.Unmarshal(doc.Data, &tm); err != nil {
+			results = append(results, &validationResult{Source: doc.Source, Object: "<unknown>", Errors: []string{err.Error()}})
+			continue
+		}
+
+		if tm.GroupVersionKind().Group != pvpoolv1alpha1.SchemeGroupVersion.Group {
+			continue
+		}
+
+		// Objects in other API versions are validated after converting them
+		// to the storage version, as the webhook would see them.
+		var obj client.Object
+		var hub conversion.Hub
+		switch tm.GroupVersionKind() {
+		case pvpoolv1alpha1.PoolKind:
+			obj = &pvpoolv1alpha1.Pool{}
+		case pvpoolv1beta1.PoolKind:
+			obj, hub = &pvpoolv1beta1.Pool{}, &pvpoolv1alpha1.Pool{}
+		case pvpoolv1alpha1.ClusterPoolKind:
+			obj = &pvpoolv1alpha1.ClusterPool{}
+		case pvpoolv1beta1.ClusterPoolKind:
+			obj, hub = &pvpoolv1beta1.ClusterPool{}, &pvpoolv1alpha1.ClusterPool{}
+		case pvpoolv1alpha1.CheckoutKind:
+			obj = &pvpoolv1alpha1.Checkout{}
+		case pvpoolv1beta1.CheckoutKind:
+			obj, hub = &pvpoolv1beta1.Checkout{}, &pvpoolv1alpha1.Checkout{}
+		case pvpoolv1alpha1.PoolPolicyKind:
+			obj = &pvpoolv1alpha1.PoolPolicy{}
+		case pvpoolv1beta1.PoolPolicyKind:
+			obj, hub = &pvpoolv1beta1.PoolPolicy{}, &pvpoolv1alpha1.PoolPolicy{}
+		case pvpoolv1alpha1.PoolTemplateKind:
+			obj = &pvpoolv1alpha1.PoolTemplate{}
+		case pvpoolv1beta1.PoolTemplateKind:
+			obj, hub = &pvpoolv1beta1.PoolTemplate{}, &pvpoolv1alpha1.PoolTemplate{}
+		default:
+			results = append(results, &validationResult{
+				Source: doc.Source,
+				Object: tm.Kind,
+				Errors: []string{fmt.Sprintf("unsupported kind %q in API version %q", tm.Kind, tm.APIVersion)},
+			})
+			continue
+		}
+
+		r := &validationResult{Source: doc.Source}
+		if err := json.Unmarshal(doc.Data, obj); err != nil {
+			r.Object = tm.Kind
+			r.Errors = append(r.Errors, err.Error())
+			results = append(results, r)
+			continue
+		}
+
+		r.Object = objectName(tm.Kind, obj)
+		r.Warnings = append(r.Warnings, unknownFields(doc.Data, obj)...)
+		results = append(results, r)
+
+		if hub != nil {
+			if err := obj.(conversion.Convertible).ConvertTo(hub); err != nil {
+				r.Errors = append(r.Errors, fmt.Sprintf("could not convert to %s: %v", pvpoolv1alpha1.SchemeGroupVersion, err))
+				continue
+			}
+
+			obj = hub.(client.Object)
+		}
+
+		switch obj := obj.(type) {
+		case *pvpoolv1alpha1.Pool:
+			pools = append(pools, obj)
+		case *pvpoolv1alpha1.ClusterPool:
+			clusterPools = append(clusterPools, obj)
+		case *pvpoolv1alpha1.Checkout:
+			checkouts = append(checkouts, obj)
+		case *pvpoolv1alpha1.PoolPolicy:
+			policies = append(policies, *obj)
+		case *pvpoolv1alpha1.PoolTemplate:
+			templates[obj.GetName()] = obj
+		}
+
+		byObject[obj] = r
+	}
+
+	for _, tpl := range templates {
+		r, ok := byObject[tpl]
+		if !ok {
+			continue
+		}
+
+		r.Errors = append(r.Errors, admissionErrors((&webhook.PoolTemplateValidator{PoolTemplate: tpl}).ValidateCreate())...)
+	}
+
+	// The API server runs the mutating webhook before the validating webhook,
+	// so we do the same. Pools that refer to a template we don't have can't be
+	// checked any further.
+	limits := pvpoolv1alpha1validation.MountJobLimitsForPolicies(policies)
+	var resolvedPools []*pvpoolv1alpha1.Pool
+	for _, pool := range pools {
+		if applyPoolTemplate(&pool.Spec, templates, byObject[pool]) {
+			pvpoolv1alpha1defaults.DefaultPoolSpec(&pool.Spec, limits)
+			resolvedPools = append(resolvedPools, pool)
+		}
+	}
+	pools = resolvedPools
+
+	var resolvedClusterPools []*pvpoolv1alpha1.ClusterPool
+	for _, cp := range clusterPools {
+		if applyPoolTemplate(&cp.Spec, templates, byObject[cp]) {
+			pvpoolv1alpha1defaults.DefaultPoolSpec(&cp.Spec, limits)
+			resolvedClusterPools = append(resolvedClusterPools, cp)
+		}
+	}
+	clusterPools = resolvedClusterPools
+	for _, checkout := range checkouts {
+		pvpoolv1alpha1defaults.DefaultCheckoutSpec(&checkout.Spec)
+	}
+
+	namespaceStorage := make(map[string]resource.Quantity)
+	for _, pool := range pools {
+		storage := namespaceStorage[pool.GetNamespace()]
+		storage.Add(pvpoolv1alpha1validation.PoolStorageRequest(&pool.Spec))
+		namespaceStorage[pool.GetNamespace()] = storage
+	}
+
+	for _, pool := range pools {
+		r, ok := byObject[pool]
+		if !ok {
+			continue
+		}
+
+		r.Errors = append(r.Errors, admissionErrors((&webhook.PoolValidator{Pool: pool}).ValidateCreate())...)
+
+		// The storage requested by the other pools in the namespace, as the
+		// policy webhook computes it.
+		storage := namespaceStorage[pool.GetNamespace()].DeepCopy()
+		storage.Sub(pvpoolv1alpha1validation.PoolStorageRequest(&pool.Spec))
+
+		for _, err := range pvpoolv1alpha1validation.ValidatePoolSpecForPolicies(&pool.Spec, policies, storage, field.NewPath("spec")) {
+			r.Errors = append(r.Errors, err.Error())
+		}
+	}
+
+	for _, cp := range clusterPools {
+		r, ok := byObject[cp]
+		if !ok {
+			continue
+		}
+
+		r.Errors = append(r.Errors, admissionErrors((&webhook.ClusterPoolValidator{ClusterPool: cp}).ValidateCreate())...)
+
+		for _, err := range pvpoolv1alpha1validation.ValidatePoolSpecForPolicies(&cp.Spec, policies, resource.Quantity{}, field.NewPath("spec")) {
+			r.Errors = append(r.Errors, err.Error())
+		}
+	}
+
+	poolSpecs := make(map[client.ObjectKey]*pvpoolv1alpha1.PoolSpec, len(pools))
+	for _, pool := range pools {
+		poolSpecs[client.ObjectKeyFromObject(pool)] = &pool.Spec
+	}
+	clusterPoolSpecs := make(map[string]*pvpoolv1alpha1.PoolSpec, len(clusterPools))
+	for _, cp := range clusterPools {
+		clusterPoolSpecs[cp.GetName()] = &cp.Spec
+	}
+
+	for _, checkout := range checkouts {
+		r, ok := byObject[checkout]
+		if !ok {
+			continue
+		}
+
+		r.Errors = append(r.Errors, admissionErrors((&webhook.CheckoutValidator{Checkout: checkout}).ValidateCreate())...)
+
+		// The webhook also checks the access modes against the pool, which we
+		// can only do if the pool is in the given files.
+		var spec *pvpoolv1alpha1.PoolSpec
+		if ref := checkout.Spec.PoolRef; ref.Kind == pvpoolv1alpha1.ClusterPoolKind.Kind {
+			spec = clusterPoolSpecs[ref.Name]
+		} else {
+			key := client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}
+			if key.Namespace == "" {
+				key.Namespace = checkout.GetNamespace()
+			}
+			spec = poolSpecs[key]
+		}
+		if spec != nil {
+			for _, err := range pvpoolv1alpha1validation.ValidateCheckoutAccessModesForPool(&checkout.Spec, spec, field.NewPath("spec", "accessModes")) {
+				r.Errors = append(r.Errors, err.Error())
+			}
+		}
+	}
+
+	return
+}
+
+// applyPoolTemplate resolves the template reference of a pool spec, if any,
+// from the given templates. It returns false if the pool should not be
+// validated further.
+func applyPoolTemplate(spec *pvpoolv1alpha1.PoolSpec, templates map[string]*pvpoolv1alpha1.PoolTemplate, r *validationResult) bool {
+	if spec.TemplateRef == nil || r == nil {
+		return r != nil
+	}
+
+	tpl, found := templates[spec.TemplateRef.Name]
+	if !found {
+		r.Warnings = append(r.Warnings, fmt.Sprintf("spec.templateRef.name: pool template %q is not in the given manifests, skipping further validation", spec.TemplateRef.Name))
+		return false
+	}
+
+	if err := pooltemplate.Apply(spec, tpl); err != nil {
+		r.Errors = append(r.Errors, fmt.Sprintf("spec.templateRef: %v", err))
+		return false
+	}
+
+	return true
+}
+
+func objectName(kind string, obj metav1.Object) string {
+	if obj.GetNamespace() == "" {
+		return fmt.Sprintf("%s %s", kind, obj.GetName())
+	}
+
+	return fmt.Sprintf("%s %s/%s", kind, obj.GetNamespace(), obj.GetName())
+}
+
+// admissionErrors converts an error returned by a webhook validator into a
+// list of messages, one per invalid field.
+func admissionErrors(err error) []string {
+	if err == nil {
+		return nil
+	}
+
+	status, ok := err.(errors.APIStatus)
+	if !ok || status.Status().Details == nil || len(status.Status().Details.Causes) == 0 {
+		return []string{err.Error()}
+	}
+
+	var msgs []string
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Field == "" {
+			msgs = append(msgs, cause.Message)
+		} else {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", cause.Field, cause.Message))
+		}
+	}
+	return msgs
+}
+
+// unknownFields finds fields in the original document that are dropped when it
+// is decoded into obj. The API server prunes these fields, so they are usually
+// typos.
+func unknownFields(data []byte, obj interface{}) []string {
+	var original interface{}
+	if err := json.Unmarshal(data, &original); err != nil {
+		return nil
+	}
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return nil
+	}
+
+	var msgs []string
+	walkUnknownFields(original, decoded, nil, func(p *field.Path) {
+		msgs = append(msgs, fmt.Sprintf("%s: unknown field", p))
+	})
+	return msgs
+}
+
+func walkUnknownFields(original, decoded interface{}, p *field.Path, fn func(p *field.Path)) {
+	switch ot := original.(type) {
+	case map[string]interface{}:
+		dt, _ := decoded.(map[string]interface{})
+
+		keys := make([]string, 0, len(ot))
+		for k := range ot {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			var cp *field.Path
+			if p == nil {
+				cp = field.NewPath(k)
+			} else {
+				cp = p.Child(k)
+			}
+
+			dv, found := dt[k]
+			if !found {
+				// Fields with zero values are omitted when encoding, so they
+				// don't necessarily indicate a problem.
+				if !isZero(ot[k]) {
+					fn(cp)
+				}
+				continue
+			}
+
+			walkUnknownFields(ot[k], dv, cp, fn)
+		}
+	case []interface{}:
+		dt, _ := decoded.([]interface{})
+		for i := range ot {
+			if i >= len(dt) {
+				break
+			}
+
+			walkUnknownFields(ot[i], dt[i], p.Index(i), fn)
+		}
+	}
+}
+
+func isZero(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice:
+		return rv.Len() == 0
+	default:
+		return rv.IsZero()
+	}
+}
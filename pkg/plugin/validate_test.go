@@ -0,0 +1,455 @@
+package plugin
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateManifests(t *testing.T) {
+	tests := []struct {
+		Name             string
+		Manifest         string
+		ExpectedErrors   []string
+		ExpectedWarnings []string
+	}{
+		{
+			Name: "Valid pool",
+			Manifest: `
+apiVersion: pvpool.puppet.com/v1alpha1
+kind: Pool
+metadata:
+  name: test
+spec:
+  selector:
+    matchLabels:
+      app: test
+  template:
+    metadata:
+      labels:
+        app: test
+    spec:
+      resources:
+        requests:
+          storage: 50Mi
+`,
+		},
+		{
+			Name: "Selector does not match template labels",
+			Manifest: `
+apiVersion: pvpool.puppet.com/v1alpha1
+kind: Pool
+metadata:
+  name: test
+spec:
+  selector:
+    matchLabels:
+      app: test
+  template:
+    metadata:
+      labels:
+        app: other
+    spec:
+      resources:
+        requests:
+          storage: 50Mi
+`,
+			ExpectedErrors: []string{"spec.template.metadata.labels"},
+		},
+		{
+			Name: "Init job restart policy",
+			Manifest: `
+apiVersion: pvpool.puppet.com/v1alpha1
+kind: Pool
+metadata:
+  name: test
+spec:
+  selector:
+    matchLabels:
+      app: test
+  template:
+    metadata:
+      labels:
+        app: test
+    spec:
+      resources:
+        requests:
+          storage: 50Mi
+  initJob:
+    template:
+      spec:
+        template:
+          spec:
+            restartPolicy: OnFailure
+            containers:
+            - name: init
+              image: alpine
+`,
+			ExpectedErrors: []string{"spec.initJob.template.spec.template.spec.restartPolicy"},
+		},
+		{
+			Name: "Init job deadline exceeds limit",
+			Manifest: `
+apiVersion: pvpool.puppet.com/v1alpha1
+kind: Pool
+metadata:
+  name: test
+spec:
+  selector:
+    matchLabels:
+      app: test
+  template:
+    metadata:
+      labels:
+        app: test
+    spec:
+      resources:
+        requests:
+          storage: 50Mi
+  initJob:
+    template:
+      spec:
+        activeDeadlineSeconds: 301
+        template:
+          spec:
+            containers:
+            - name: init
+              image: alpine
+`,
+			ExpectedErrors: []string{"spec.initJob.template.spec.activeDeadlineSeconds"},
+		},
+		{
+			Name: "Init job without containers",
+			Manifest: `
+apiVersion: pvpool.puppet.com/v1alpha1
+kind: Pool
+metadata:
+  name: test
+spec:
+  selector:
+    matchLabels:
+      app: test
+  template:
+    metadata:
+      labels:
+        app: test
+    spec:
+      resources:
+        requests:
+          storage: 50Mi
+  initJob:
+    template:
+      spec:
+        template:
+          spec:
+            restartPolicy: Never
+`,
+			ExpectedErrors: []string{"spec.initJob.template.spec.template.spec.containers"},
+		},
+		{
+			Name: "Pool policy in the same file",
+			Manifest: `
+apiVersion: pvpool.puppet.com/v1alpha1
+kind: PoolPolicy
+metadata:
+  name: test
+spec:
+  maxReplicas: 1
+---
+apiVersion: pvpool.puppet.com/v1alpha1
+kind: Pool
+metadata:
+  name: test
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: test
+  template:
+    metadata:
+      labels:
+        app: test
+    spec:
+      resources:
+        requests:
+          storage: 50Mi
+`,
+			ExpectedErrors: []string{"spec.replicas"},
+		},
+		{
+			Name: "Unknown field",
+			Manifest: `
+apiVersion: pvpool.puppet.com/v1alpha1
+kind: Checkout
+metadata:
+  name: test
+spec:
+  poolRef:
+    name: test
+  claimNmae: test
+`,
+			ExpectedWarnings: []string{"spec.claimNmae"},
+		},
+		{
+			Name: "Cluster pool checkout with namespace",
+			Manifest: `
+apiVersion: pvpool.puppet.com/v1alpha1
+kind: Checkout
+metadata:
+  name: test
+spec:
+  poolRef:
+    kind: ClusterPool
+    namespace: storage
+    name: test
+`,
+			ExpectedErrors: []string{"spec.poolRef.namespace"},
+		},
+		{
+			Name: "Cluster pool",
+			Manifest: `
+apiVersion: pvpool.puppet.com/v1alpha1
+kind: ClusterPool
+metadata:
+  name: test
+spec:
+  selector:
+    matchLabels:
+      app: test
+  template:
+    metadata:
+      labels:
+        app: other
+    spec:
+      resources:
+        requests:
+          storage: 50Mi
+`,
+			ExpectedErrors: []string{"spec.template.metadata.labels"},
+		},
+		{
+			Name: "Pool template",
+			Manifest: `
+apiVersion: pvpool.puppet.com/v1alpha1
+kind: PoolTemplate
+metadata:
+  name: test
+spec:
+  template:
+    metadata:
+      labels:
+        app: other
+    spec:
+      resources:
+        requests:
+          storage: 50Mi
+---
+apiVersion: pvpool.puppet.com/v1alpha1
+kind: Pool
+metadata:
+  name: test
+spec:
+  selector:
+    matchLabels:
+      app: test
+  templateRef:
+    name: test
+`,
+			ExpectedErrors: []string{"spec.template.metadata.labels"},
+		},
+		{
+			Name: "Pool template not in manifests",
+			Manifest: `
+apiVersion: pvpool.puppet.com/v1alpha1
+kind: Pool
+metadata:
+  name: test
+spec:
+  selector:
+    matchLabels:
+      app: test
+  templateRef:
+    name: test
+`,
+			ExpectedWarnings: []string{"spec.templateRef.name"},
+		},
+		{
+			Name: "PVC template spec",
+			Manifest: `
+apiVersion: pvpool.puppet.com/v1alpha1
+kind: Pool
+metadata:
+  name: test
+spec:
+  selector:
+    matchLabels:
+      app: test
+  template:
+    metadata:
+      labels:
+        app: test
+    spec:
+      storageClassName: ""
+      volumeName: test
+      accessModes: [ReadWriteOnce, ReadWriteOnce]
+      resources:
+        requests:
+          storage: "0"
+`,
+			ExpectedErrors: []string{
+				"spec.template.spec.accessModes[1]",
+				"spec.template.spec.resources.requests.storage",
+				"spec.template.spec.volumeName",
+				"spec.template.spec.storageClassName",
+			},
+		},
+		{
+			Name: "Checkout claim name and access modes",
+			Manifest: `
+apiVersion: pvpool.puppet.com/v1alpha1
+kind: Checkout
+metadata:
+  name: test
+spec:
+  poolRef:
+    name: test
+  claimName: Not_Valid
+  accessModes: [ReadWriteOnce, ReadWriteOnce, WriteOnly]
+`,
+			ExpectedErrors: []string{
+				"spec.claimName",
+				"spec.accessModes[1]",
+				"spec.accessModes[2]",
+			},
+		},
+		{
+			Name: "Checkout access modes not supported by pool",
+			Manifest: `
+apiVersion: pvpool.puppet.com/v1alpha1
+kind: Pool
+metadata:
+  name: test
+spec:
+  selector:
+    matchLabels:
+      app: test
+  template:
+    metadata:
+      labels:
+        app: test
+    spec:
+      resources:
+        requests:
+          storage: 50Mi
+---
+apiVersion: pvpool.puppet.com/v1alpha1
+kind: Checkout
+metadata:
+  name: test
+spec:
+  poolRef:
+    name: test
+  accessModes: [ReadOnlyMany, ReadWriteMany]
+`,
+			ExpectedErrors: []string{"spec.accessModes[1]"},
+		},
+		{
+			Name: "v1beta1 pool and checkout",
+			Manifest: `
+apiVersion: pvpool.puppet.com/v1beta1
+kind: Pool
+metadata:
+  name: test
+spec:
+  selector:
+    matchLabels:
+      app: test
+  volumeClaimTemplate:
+    metadata:
+      labels:
+        app: test
+    spec:
+      resources:
+        requests:
+          storage: 50Mi
+---
+apiVersion: pvpool.puppet.com/v1beta1
+kind: Checkout
+metadata:
+  name: test
+spec:
+  poolRef:
+    name: test
+  volumeClaimName: test
+`,
+		},
+		{
+			Name: "v1beta1 pool with invalid template",
+			Manifest: `
+apiVersion: pvpool.puppet.com/v1beta1
+kind: Pool
+metadata:
+  name: test
+spec:
+  selector:
+    matchLabels:
+      app: test
+  volumeClaimTemplate:
+    metadata:
+      labels:
+        app: other
+    spec:
+      resources:
+        requests:
+          storage: 50Mi
+---
+apiVersion: pvpool.puppet.com/v1beta1
+kind: Checkout
+metadata:
+  name: test
+spec:
+  poolRef:
+    name: test
+  accessModes: [ReadWriteMany]
+  unknown: true
+`,
+			ExpectedErrors:   []string{"spec.template.metadata.labels", "spec.accessModes[0]"},
+			ExpectedWarnings: []string{"spec.unknown"},
+		},
+		{
+			Name: "Other resources are ignored",
+			Manifest: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  foo: bar
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			docs, err := splitManifests("test.yaml", strings.NewReader(test.Manifest))
+			require.NoError(t, err)
+
+			var errs, warnings []string
+			for _, r := range validateManifests(docs) {
+				errs = append(errs, r.Errors...)
+				warnings = append(warnings, r.Warnings...)
+			}
+
+			require.Len(t, errs, len(test.ExpectedErrors), "errors: %v", errs)
+			for i, prefix := range test.ExpectedErrors {
+				assert.True(t, strings.HasPrefix(errs[i], prefix+":"), "error %q does not refer to %s", errs[i], prefix)
+			}
+
+			require.Len(t, warnings, len(test.ExpectedWarnings), "warnings: %v", warnings)
+			for i, prefix := range test.ExpectedWarnings {
+				assert.True(t, strings.HasPrefix(warnings[i], prefix+":"), "warning %q does not refer to %s", warnings[i], prefix)
+			}
+		})
+	}
+}
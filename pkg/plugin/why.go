@@ -0,0 +1,131 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newWhyCommand(o *Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "why CHECKOUT",
+		Short: "Explain why a checkout has not acquired a PVC",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			cl, namespace, err := o.Client()
+			if err != nil {
+				return err
+			}
+
+			checkout := pvpoolv1alpha1obj.NewCheckout(client.ObjectKey{Namespace: namespace, Name: args[0]})
+			if _, err := (lifecycle.RequiredLoader{Loader: checkout}).Load(ctx, cl); err != nil {
+				return err
+			}
+
+			return explainCheckout(ctx, cmd.OutOrStdout(), cl, checkout)
+		},
+	}
+}
+
+func explainCheckout(ctx context.Context, out io.Writer, cl client.Client, checkout *pvpoolv1alpha1obj.Checkout) error {
+	// Controllers that predate the Ready condition only set Acquired.
+	cond, ok := checkout.Condition(pvpoolv1alpha1.CheckoutReady)
+	if !ok {
+		cond, ok = checkout.Condition(pvpoolv1alpha1.CheckoutAcquired)
+	}
+
+	switch {
+	case !ok:
+		fmt.Fprintf(out, "The controller has not processed checkout %s yet. Make sure pvpool-controller is running.\n", checkout.Key.Name)
+		return nil
+	case cond.Status == corev1.ConditionTrue:
+		fmt.Fprintf(out, "Checkout %s has acquired PVC %s (volume %s).\n", checkout.Key.Name, checkout.Object.Status.VolumeClaimRef.Name, checkout.Object.Status.VolumeName)
+		return nil
+	}
+
+	fmt.Fprintf(out, "Checkout %s has not acquired a PVC.\n\n", checkout.Key.Name)
+	fmt.Fprintf(out, "Reason:  %s\n", cond.Reason)
+	fmt.Fprintf(out, "Message: %s\n", cond.Message)
+	fmt.Fprintf(out, "Since:   %s\n\n", cond.LastTransitionTime.Time)
+
+	poolKey := checkoutPoolKey(checkout.Object)
+	if ref := checkout.Object.Spec.PoolRef; ref.Kind == pvpoolv1alpha1.ClusterPoolKind.Kind {
+		if cond.Reason == pvpoolv1alpha1.CheckoutAcquiredReasonPoolDoesNotExist {
+			fmt.Fprintf(out, "The checkout refers to the cluster pool %s. Check the spec.poolRef field of the checkout.\n", ref.Name)
+			return nil
+		}
+
+		cp := pvpoolv1alpha1obj.NewClusterPool(ref.Name)
+		if ok, err := cp.Load(ctx, cl); err != nil {
+			return err
+		} else if ok && cp.Object.Status.PoolRef != nil {
+			poolKey = client.ObjectKey{Namespace: cp.Object.Status.PoolRef.Namespace, Name: cp.Object.Status.PoolRef.Name}
+		}
+	}
+
+	switch cond.Reason {
+	case pvpoolv1alpha1.CheckoutAcquiredReasonPoolDoesNotExist:
+		fmt.Fprintf(out, "The checkout refers to the pool %s. Check the spec.poolRef field of the checkout.\n", poolKey)
+	case pvpoolv1alpha1.CheckoutAcquiredReasonConflict:
+		fmt.Fprintln(out, "A PVC with the name this checkout wants to use already exists and is not controlled by the checkout.")
+		fmt.Fprintln(out, "Delete the existing PVC or recreate the checkout with a different spec.claimName.")
+	case pvpoolv1alpha1.CheckoutAcquiredReasonInvalid:
+		fmt.Fprintln(out, "The API server rejected the PVC for this checkout. Check the access modes requested by the checkout against the pool's storage class.")
+	case pvpoolv1alpha1.CheckoutAcquiredReasonNotAvailable, pvpoolv1alpha1.CheckoutAcquiredReasonHealthCheckPending:
+		return explainPool(ctx, out, cl, poolKey)
+	case pvpoolv1alpha1.CheckoutReadyReasonTransferring:
+		fmt.Fprintf(out, "The checkout selected volume %s and is waiting for it to be bound to its PVC. If this takes a long time, check the events of the PVC.\n", checkout.Object.Status.VolumeName)
+	}
+
+	return nil
+}
+
+func explainPool(ctx context.Context, out io.Writer, cl client.Client, key client.ObjectKey) error {
+	ps, err := loadPoolState(ctx, cl, key)
+	if errors.IsNotFound(err) {
+		fmt.Fprintf(out, "The pool %s no longer exists.\n", key)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "The pool %s wants %d replicas: %d initializing, %d verifying, %d available, %d stale.\n",
+		key, ps.DesiredReplicas(), len(ps.Initializing), len(ps.Verifying), len(ps.Available), len(ps.Stale))
+
+	for _, cond := range ps.Pool.Object.Status.Conditions {
+		healthy := cond.Status == corev1.ConditionTrue
+		if cond.Type == pvpoolv1alpha1.PoolProvisioningStalled {
+			healthy = cond.Status == corev1.ConditionFalse
+		}
+		if healthy {
+			continue
+		}
+
+		fmt.Fprintf(out, "The pool's %s condition is %s (%s): %s\n", cond.Type, cond.Status, cond.Reason, cond.Message)
+	}
+
+	for _, r := range poolReplicas(ps) {
+		switch r.Phase {
+		case pvpoolv1alpha1.PoolReplicaPhaseInitializing:
+			fmt.Fprintf(out, "Replica %s is initializing (PVC %s, init job %s).\n", r.Replica.PersistentVolumeClaim.Key.Name, r.Replica.PersistentVolumeClaim.Object.Status.Phase, jobState(r.Replica.InitJob))
+		case pvpoolv1alpha1.PoolReplicaPhaseVerifying:
+			fmt.Fprintf(out, "Replica %s is running its health check (job %s).\n", r.Replica.PersistentVolumeClaim.Key.Name, jobState(r.Replica.HealthCheckJob))
+		}
+	}
+
+	if len(ps.Available) == 0 && len(ps.Initializing) == 0 && len(ps.Verifying) == 0 {
+		fmt.Fprintln(out, "Every replica has been checked out. Increase spec.replicas on the pool to make more available.")
+	}
+
+	return nil
+}
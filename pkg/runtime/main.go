@@ -8,20 +8,29 @@ import (
 	"github.com/puppetlabs/leg/k8sutil/pkg/controller/eventctx"
 	"github.com/puppetlabs/leg/mainutil"
 	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	pvpoolv1beta1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1beta1"
 	"github.com/puppetlabs/pvpool/pkg/opt"
+	"github.com/puppetlabs/pvpool/pkg/tracing"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/klog/v2"
 	"k8s.io/klog/v2/klogr"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
 var schemes = runtime.NewSchemeBuilder(
 	scheme.AddToScheme,
+	apiextensionsv1.AddToScheme,
 	pvpoolv1alpha1.AddToScheme,
+	pvpoolv1beta1.AddToScheme,
 )
 
 func Main(cfg *opt.Config, opts manager.Options, transforms ...func(mgr manager.Manager) error) int {
@@ -55,6 +64,27 @@ func Main(cfg *opt.Config, opts manager.Options, transforms ...func(mgr manager.
 		opts.LeaderElectionNamespace = cfg.Namespace
 		opts.LeaderElectionReleaseOnCancel = true
 
+		shutdownTracing, err := tracing.Setup(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to set up tracing: %w", err)
+		}
+		defer func() {
+			if err := shutdownTracing(context.Background()); err != nil {
+				klog.ErrorS(err, "failed to shut down tracing")
+			}
+		}()
+
+		if cfg.TracingEnabled() && opts.NewClient == nil {
+			opts.NewClient = func(cache cache.Cache, config *rest.Config, options client.Options, uncachedObjects ...client.Object) (client.Client, error) {
+				cl, err := cluster.DefaultNewClient(cache, config, options, uncachedObjects...)
+				if err != nil {
+					return nil, err
+				}
+
+				return tracing.WrapClient(cl), nil
+			}
+		}
+
 		mgr, err := manager.New(config.GetConfigOrDie(), opts)
 		if err != nil {
 			return fmt.Errorf("failed to create manager: %w", err)
@@ -0,0 +1,255 @@
+package sim
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// cluster is an in-memory Kubernetes API that stands in for the parts of a
+// real cluster the controller depends on: UID and creation timestamp
+// assignment, the PV binder, dynamic provisioning, and the job controller.
+type cluster struct {
+	client.Client
+
+	cfg *Config
+	now time.Time
+
+	// boundAt records when the simulated binder bound each PVC.
+	boundAt map[types.UID]time.Time
+
+	// provisioned counts the PVs created by the simulated provisioner.
+	provisioned int
+}
+
+func newCluster(s *runtime.Scheme, cfg *Config, now time.Time) *cluster {
+	return &cluster{
+		Client:  fake.NewClientBuilder().WithScheme(s).Build(),
+		cfg:     cfg,
+		now:     now,
+		boundAt: make(map[types.UID]time.Time),
+	}
+}
+
+func (c *cluster) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if obj.GetUID() == "" {
+		obj.SetUID(types.UID(uuid.New().String()))
+	}
+	obj.SetCreationTimestamp(metav1.NewTime(c.now))
+
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+// jittered varies the given duration by up to the configured jitter. The
+// variation is derived from the object's UID so that it doesn't change from
+// one step to the next.
+func (c *cluster) jittered(d time.Duration, uid types.UID) time.Duration {
+	if c.cfg.Jitter == 0 {
+		return d
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(uid))
+	rng := rand.New(rand.NewSource(c.cfg.Seed ^ int64(h.Sum64())))
+
+	return time.Duration(float64(d) * (1 + c.cfg.Jitter*(2*rng.Float64()-1)))
+}
+
+// step advances the simulated cluster controllers. It returns true if any
+// object changed.
+func (c *cluster) step(ctx context.Context) (bool, error) {
+	changed := false
+	for _, fn := range []func(ctx context.Context) (bool, error){c.bindClaims, c.loseClaims, c.completeJobs} {
+		ok, err := fn(ctx)
+		if err != nil {
+			return false, err
+		}
+		changed = changed || ok
+	}
+	return changed, nil
+}
+
+// bindClaims binds pending PVCs. Pre-bound PVCs, like the ones a checkout
+// creates, bind immediately. Other PVCs are dynamically provisioned once the
+// bind latency elapses.
+func (c *cluster) bindClaims(ctx context.Context) (bool, error) {
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	if err := c.List(ctx, pvcs); err != nil {
+		return false, err
+	}
+
+	changed := false
+	for i := range pvcs.Items {
+		pvc := &pvcs.Items[i]
+		if pvc.Status.Phase == corev1.ClaimBound || pvc.Status.Phase == corev1.ClaimLost {
+			continue
+		}
+
+		pv := &corev1.PersistentVolume{}
+		if pvc.Spec.VolumeName != "" {
+			if err := c.Get(ctx, client.ObjectKey{Name: pvc.Spec.VolumeName}, pv); err != nil {
+				if client.IgnoreNotFound(err) != nil {
+					return false, err
+				}
+				continue
+			}
+
+			if pv.Spec.ClaimRef == nil || pv.Spec.ClaimRef.UID != pvc.GetUID() {
+				continue
+			}
+		} else {
+			if c.now.Before(pvc.GetCreationTimestamp().Add(c.jittered(c.cfg.BindLatency, pvc.GetUID()))) {
+				continue
+			}
+
+			pv = &corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: fmt.Sprintf("pvc-%s", pvc.GetUID()),
+				},
+				Spec: corev1.PersistentVolumeSpec{
+					Capacity:    corev1.ResourceList{corev1.ResourceStorage: pvc.Spec.Resources.Requests[corev1.ResourceStorage]},
+					AccessModes: pvc.Spec.AccessModes,
+					ClaimRef: &corev1.ObjectReference{
+						APIVersion: "v1",
+						Kind:       "PersistentVolumeClaim",
+						Namespace:  pvc.GetNamespace(),
+						Name:       pvc.GetName(),
+						UID:        pvc.GetUID(),
+					},
+					PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete,
+					PersistentVolumeSource: corev1.PersistentVolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{Path: "/sim/" + string(pvc.GetUID())},
+					},
+				},
+			}
+			if pvc.Spec.StorageClassName != nil {
+				pv.Spec.StorageClassName = *pvc.Spec.StorageClassName
+			}
+			if err := c.Create(ctx, pv); err != nil {
+				return false, err
+			}
+			c.provisioned++
+
+			pvc.Spec.VolumeName = pv.GetName()
+		}
+
+		pvc.Status.Phase = corev1.ClaimBound
+		pvc.Status.AccessModes = pv.Spec.AccessModes
+		pvc.Status.Capacity = pv.Spec.Capacity
+		if err := c.Update(ctx, pvc); err != nil {
+			return false, err
+		}
+
+		pv.Status.Phase = corev1.VolumeBound
+		if err := c.Update(ctx, pv); err != nil {
+			return false, err
+		}
+
+		c.boundAt[pvc.GetUID()] = c.now
+		changed = true
+	}
+	return changed, nil
+}
+
+// loseClaims marks bound PVCs as lost when their PV has been given to another
+// claim, which is how a pool notices that a checkout took one of its volumes.
+func (c *cluster) loseClaims(ctx context.Context) (bool, error) {
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	if err := c.List(ctx, pvcs); err != nil {
+		return false, err
+	}
+
+	changed := false
+	for i := range pvcs.Items {
+		pvc := &pvcs.Items[i]
+		if pvc.Status.Phase != corev1.ClaimBound {
+			continue
+		}
+
+		pv := &corev1.PersistentVolume{}
+		if err := c.Get(ctx, client.ObjectKey{Name: pvc.Spec.VolumeName}, pv); client.IgnoreNotFound(err) != nil {
+			return false, err
+		} else if err == nil && pv.Spec.ClaimRef != nil && pv.Spec.ClaimRef.UID == pvc.GetUID() {
+			continue
+		}
+
+		pvc.Status.Phase = corev1.ClaimLost
+		if err := c.Update(ctx, pvc); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+// completeJobs finishes jobs once every PVC they mount has been bound for the
+// init duration.
+func (c *cluster) completeJobs(ctx context.Context) (bool, error) {
+	jobs := &batchv1.JobList{}
+	if err := c.List(ctx, jobs); err != nil {
+		return false, err
+	}
+
+	changed := false
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if job.Status.CompletionTime != nil {
+			continue
+		}
+
+		start, ok := c.jobStartTime(ctx, job)
+		if !ok || c.now.Before(start.Add(c.jittered(c.cfg.InitDuration, job.GetUID()))) {
+			continue
+		}
+
+		startTime, completionTime := metav1.NewTime(start), metav1.NewTime(c.now)
+		job.Status.StartTime = &startTime
+		job.Status.CompletionTime = &completionTime
+		job.Status.Succeeded = 1
+		job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+			Type:               batchv1.JobComplete,
+			Status:             corev1.ConditionTrue,
+			LastProbeTime:      completionTime,
+			LastTransitionTime: completionTime,
+		})
+		if err := c.Update(ctx, job); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+func (c *cluster) jobStartTime(ctx context.Context, job *batchv1.Job) (time.Time, bool) {
+	start := job.GetCreationTimestamp().Time
+	for _, volume := range job.Spec.Template.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: job.GetNamespace(), Name: volume.PersistentVolumeClaim.ClaimName}, pvc); err != nil {
+			return time.Time{}, false
+		}
+
+		boundAt, ok := c.boundAt[pvc.GetUID()]
+		if !ok {
+			return time.Time{}, false
+		}
+		if boundAt.After(start) {
+			start = boundAt
+		}
+	}
+	return start, true
+}
@@ -0,0 +1,164 @@
+package sim
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Config describes a simulation.
+type Config struct {
+	// Replicas is the number of replicas requested by the pool. It is ignored
+	// if Autoscale is set.
+	Replicas int32
+
+	// Autoscale, if set, adjusts the pool's replicas during the simulation.
+	Autoscale *AutoscalePolicy
+
+	// BindLatency is the time the simulated provisioner takes to bind a new
+	// PVC in the pool.
+	BindLatency time.Duration
+
+	// InitDuration is the time an init job takes to run once its PVC is bound.
+	InitDuration time.Duration
+
+	// Jitter randomly varies each bind latency and init job duration by up to
+	// this fraction, between 0 and 1.
+	Jitter float64
+
+	// Arrivals determines when checkouts are created.
+	Arrivals ArrivalPattern
+
+	// Duration is the amount of simulated time to run for.
+	Duration time.Duration
+
+	// Step is the resolution of the simulation clock. Every step, the
+	// simulated provisioner runs and the pool and pending checkouts are
+	// reconciled.
+	Step time.Duration
+
+	// Seed initializes the random number generator used for arrivals and
+	// jitter.
+	Seed int64
+}
+
+// Validate checks that the configuration can be simulated.
+func (c *Config) Validate() error {
+	switch {
+	case c.Arrivals == nil:
+		return fmt.Errorf("an arrival pattern is required")
+	case c.Duration <= 0:
+		return fmt.Errorf("duration must be positive")
+	case c.Step <= 0:
+		return fmt.Errorf("step must be positive")
+	case c.BindLatency < 0 || c.InitDuration < 0:
+		return fmt.Errorf("bind latency and init duration must not be negative")
+	case c.Jitter < 0 || c.Jitter > 1:
+		return fmt.Errorf("jitter must be between 0 and 1")
+	case c.Autoscale == nil && c.Replicas < 0:
+		return fmt.Errorf("replicas must not be negative")
+	case c.Autoscale != nil:
+		return c.Autoscale.Validate()
+	}
+
+	return nil
+}
+
+// AutoscalePolicy sizes the pool from the recent checkout arrival rate so that
+// enough replicas are ready to cover the arrivals expected while new replicas
+// are being prepared.
+type AutoscalePolicy struct {
+	// MinReplicas and MaxReplicas bound the replicas chosen by the policy.
+	MinReplicas int32
+	MaxReplicas int32
+
+	// Window is the period over which the arrival rate is measured.
+	Window time.Duration
+
+	// Headroom multiplies the expected arrivals to leave room for bursts.
+	Headroom float64
+}
+
+// Validate checks that the policy is usable.
+func (ap *AutoscalePolicy) Validate() error {
+	switch {
+	case ap.MinReplicas < 0 || ap.MaxReplicas < ap.MinReplicas:
+		return fmt.Errorf("autoscale replicas must satisfy 0 <= min <= max")
+	case ap.Window <= 0:
+		return fmt.Errorf("autoscale window must be positive")
+	case ap.Headroom <= 0:
+		return fmt.Errorf("autoscale headroom must be positive")
+	}
+
+	return nil
+}
+
+// ArrivalPattern generates the times at which checkouts are created.
+type ArrivalPattern interface {
+	// Arrivals returns the offsets from the start of the simulation at which
+	// checkouts arrive, in ascending order and less than the given duration.
+	Arrivals(rng *rand.Rand, duration time.Duration) []time.Duration
+}
+
+// ConstantArrivals creates a checkout at a fixed interval.
+type ConstantArrivals struct {
+	Interval time.Duration
+}
+
+var _ ArrivalPattern = ConstantArrivals{}
+
+func (ca ConstantArrivals) Arrivals(rng *rand.Rand, duration time.Duration) []time.Duration {
+	var arrivals []time.Duration
+	if ca.Interval <= 0 {
+		return arrivals
+	}
+
+	for t := ca.Interval; t < duration; t += ca.Interval {
+		arrivals = append(arrivals, t)
+	}
+	return arrivals
+}
+
+// PoissonArrivals creates checkouts independently at an average rate per
+// minute.
+type PoissonArrivals struct {
+	RatePerMinute float64
+}
+
+var _ ArrivalPattern = PoissonArrivals{}
+
+func (pa PoissonArrivals) Arrivals(rng *rand.Rand, duration time.Duration) []time.Duration {
+	var arrivals []time.Duration
+	if pa.RatePerMinute <= 0 {
+		return arrivals
+	}
+
+	mean := float64(time.Minute) / pa.RatePerMinute
+	for t := time.Duration(rng.ExpFloat64() * mean); t < duration; t += time.Duration(rng.ExpFloat64() * mean) {
+		arrivals = append(arrivals, t)
+	}
+	return arrivals
+}
+
+// BurstArrivals creates a fixed number of checkouts at once at a fixed
+// interval.
+type BurstArrivals struct {
+	Size     int
+	Interval time.Duration
+}
+
+var _ ArrivalPattern = BurstArrivals{}
+
+func (ba BurstArrivals) Arrivals(rng *rand.Rand, duration time.Duration) []time.Duration {
+	var arrivals []time.Duration
+	if ba.Interval <= 0 {
+		return arrivals
+	}
+
+	for t := ba.Interval; t < duration; t += ba.Interval {
+		for i := 0; i < ba.Size; i++ {
+			arrivals = append(arrivals, t)
+		}
+	}
+	return arrivals
+}
@@ -0,0 +1,153 @@
+package sim
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Report summarizes the outcome of a simulation.
+type Report struct {
+	// Duration is the amount of simulated time.
+	Duration metav1.Duration `json:"duration"`
+
+	// Checkouts is the number of checkouts created.
+	Checkouts int `json:"checkouts"`
+
+	// Served is the number of checkouts whose PVC was bound.
+	Served int `json:"served"`
+
+	// Immediate is the number of checkouts whose PVC was bound in the same
+	// step they were created.
+	Immediate int `json:"immediate"`
+
+	// Unserved is the number of checkouts still waiting when the simulation
+	// ended. They are not included in the wait statistics.
+	Unserved int `json:"unserved"`
+
+	// Wait describes how long served checkouts waited for their PVC.
+	Wait WaitReport `json:"wait"`
+
+	// Pool describes the size and utilization of the pool.
+	Pool PoolReport `json:"pool"`
+}
+
+// WaitReport describes the distribution of checkout wait times.
+type WaitReport struct {
+	Mean metav1.Duration `json:"mean"`
+	P50  metav1.Duration `json:"p50"`
+	P90  metav1.Duration `json:"p90"`
+	P99  metav1.Duration `json:"p99"`
+	Max  metav1.Duration `json:"max"`
+}
+
+// PoolReport describes the pool over the course of a simulation.
+type PoolReport struct {
+	// MeanReplicas and MaxReplicas describe the replicas requested by the
+	// pool spec, which only vary when autoscaling.
+	MeanReplicas float64 `json:"meanReplicas"`
+	MaxReplicas  int32   `json:"maxReplicas"`
+
+	// MeanAvailable is the average number of replicas ready to be checked
+	// out.
+	MeanAvailable float64 `json:"meanAvailable"`
+
+	// EmptyFraction is the fraction of time the pool had no available
+	// replicas.
+	EmptyFraction float64 `json:"emptyFraction"`
+
+	// IdleVolumeHours is the total time replicas spent available but unused.
+	IdleVolumeHours float64 `json:"idleVolumeHours"`
+
+	// Provisioned is the number of volumes the pool provisioned.
+	Provisioned int `json:"provisioned"`
+
+	// Utilization is the fraction of provisioned volumes that were checked
+	// out.
+	Utilization float64 `json:"utilization"`
+}
+
+func (sim *simulation) report() *Report {
+	r := &Report{
+		Duration:  metav1.Duration{Duration: sim.cfg.Duration},
+		Checkouts: sim.next,
+		Served:    len(sim.waits),
+		Unserved:  len(sim.pending),
+	}
+
+	if len(sim.waits) > 0 {
+		waits := append([]time.Duration{}, sim.waits...)
+		sort.Slice(waits, func(i, j int) bool { return waits[i] < waits[j] })
+
+		var total time.Duration
+		for _, wait := range waits {
+			total += wait
+			if wait == 0 {
+				r.Immediate++
+			}
+		}
+
+		percentile := func(p float64) metav1.Duration {
+			i := int(p*float64(len(waits))+0.5) - 1
+			if i < 0 {
+				i = 0
+			} else if i >= len(waits) {
+				i = len(waits) - 1
+			}
+			return metav1.Duration{Duration: waits[i]}
+		}
+
+		r.Wait = WaitReport{
+			Mean: metav1.Duration{Duration: total / time.Duration(len(waits))},
+			P50:  percentile(0.5),
+			P90:  percentile(0.9),
+			P99:  percentile(0.99),
+			Max:  metav1.Duration{Duration: waits[len(waits)-1]},
+		}
+	}
+
+	if len(sim.samples) > 0 {
+		var replicas, available, empty float64
+		for _, s := range sim.samples {
+			replicas += float64(s.Desired)
+			available += float64(s.Available)
+			if s.Available == 0 {
+				empty++
+			}
+			if s.Desired > r.Pool.MaxReplicas {
+				r.Pool.MaxReplicas = s.Desired
+			}
+		}
+
+		n := float64(len(sim.samples))
+		r.Pool.MeanReplicas = replicas / n
+		r.Pool.MeanAvailable = available / n
+		r.Pool.EmptyFraction = empty / n
+		r.Pool.IdleVolumeHours = available * sim.cfg.Step.Hours()
+	}
+
+	r.Pool.Provisioned = sim.cl.provisioned
+	if r.Pool.Provisioned > 0 {
+		r.Pool.Utilization = float64(r.Served) / float64(r.Pool.Provisioned)
+	}
+
+	return r
+}
+
+// WriteText writes a human-readable version of the report.
+func (r *Report) WriteText(out io.Writer) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Simulated time:\t%s\n", r.Duration.Duration)
+	fmt.Fprintf(w, "Checkouts:\t%d created, %d served (%d immediately), %d unserved\n", r.Checkouts, r.Served, r.Immediate, r.Unserved)
+	fmt.Fprintf(w, "Wait time:\tmean %s, p50 %s, p90 %s, p99 %s, max %s\n", r.Wait.Mean.Round(time.Second/10), r.Wait.P50.Duration, r.Wait.P90.Duration, r.Wait.P99.Duration, r.Wait.Max.Duration)
+	fmt.Fprintf(w, "Replicas:\tmean %.1f, max %d\n", r.Pool.MeanReplicas, r.Pool.MaxReplicas)
+	fmt.Fprintf(w, "Available replicas:\tmean %.1f, empty %.1f%% of the time\n", r.Pool.MeanAvailable, r.Pool.EmptyFraction*100)
+	fmt.Fprintf(w, "Volumes:\t%d provisioned, %.1f%% checked out, %.2f idle volume hours\n", r.Pool.Provisioned, r.Pool.Utilization*100, r.Pool.IdleVolumeHours)
+
+	return w.Flush()
+}
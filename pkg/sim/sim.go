@@ -0,0 +1,297 @@
+// Package sim runs the pool and checkout state machines against an in-memory
+// cluster to estimate how a pool configuration performs under a given
+// checkout workload.
+package sim
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/puppetlabs/leg/errmap/pkg/errmark"
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
+	"github.com/puppetlabs/pvpool/pkg/controller/app"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Namespace is the namespace that holds the simulated objects.
+	Namespace = "default"
+
+	// PoolName is the name of the simulated pool.
+	PoolName = "sim"
+
+	// passesPerStep is the maximum number of times the simulated cluster and
+	// the controller state machines run each step. Taking a PVC from the pool
+	// requires a few round trips between them, and a real controller would
+	// make them much faster than a typical step.
+	passesPerStep = 4
+)
+
+var schemes = runtime.NewSchemeBuilder(
+	scheme.AddToScheme,
+	pvpoolv1alpha1.AddToScheme,
+)
+
+type checkoutRequest struct {
+	Key       client.ObjectKey
+	ArrivedAt time.Time
+}
+
+type simulation struct {
+	cfg   *Config
+	cl    *cluster
+	start time.Time
+
+	pool     client.ObjectKey
+	replicas int32
+
+	arrivals []time.Duration
+	next     int
+	pending  []*checkoutRequest
+
+	waits   []time.Duration
+	samples []sample
+}
+
+type sample struct {
+	Desired   int32
+	Available int
+}
+
+// Run simulates the given configuration and reports the results.
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	s := runtime.NewScheme()
+	if err := schemes.AddToScheme(s); err != nil {
+		return nil, fmt.Errorf("failed to create scheme: %w", err)
+	}
+
+	// The controller compares some timestamps against the real clock, so we
+	// start the simulated clock now and only ever move it forward.
+	start := time.Now().UTC().Truncate(time.Second)
+
+	sim := &simulation{
+		cfg:      &cfg,
+		cl:       newCluster(s, &cfg, start),
+		start:    start,
+		pool:     client.ObjectKey{Namespace: Namespace, Name: PoolName},
+		replicas: cfg.Replicas,
+		arrivals: cfg.Arrivals.Arrivals(rand.New(rand.NewSource(cfg.Seed)), cfg.Duration),
+	}
+	if cfg.Autoscale != nil {
+		sim.replicas = cfg.Autoscale.MinReplicas
+	}
+
+	if err := sim.createPool(ctx); err != nil {
+		return nil, err
+	}
+
+	for elapsed := time.Duration(0); elapsed <= cfg.Duration; elapsed += cfg.Step {
+		if err := sim.step(ctx, elapsed); err != nil {
+			return nil, fmt.Errorf("at %s: %w", elapsed, err)
+		}
+	}
+
+	return sim.report(), nil
+}
+
+func (sim *simulation) createPool(ctx context.Context) error {
+	pool := pvpoolv1alpha1obj.NewPool(sim.pool)
+	pool.Object.Spec = pvpoolv1alpha1.PoolSpec{
+		Replicas: pointer.Int32Ptr(sim.replicas),
+		Selector: metav1.LabelSelector{
+			MatchLabels: map[string]string{"app": PoolName},
+		},
+		Template: pvpoolv1alpha1.PersistentVolumeClaimTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"app": PoolName},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: pointer.StringPtr(PoolName),
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse("1Gi"),
+					},
+				},
+			},
+		},
+		DeletionPolicy: pvpoolv1alpha1.PoolDeletionPolicyDelete,
+	}
+	pool.Object.SetGeneration(1)
+
+	return sim.cl.Create(ctx, pool.Object)
+}
+
+func (sim *simulation) step(ctx context.Context, elapsed time.Duration) error {
+	sim.cl.now = sim.start.Add(elapsed)
+
+	for ; sim.next < len(sim.arrivals) && sim.arrivals[sim.next] <= elapsed; sim.next++ {
+		req, err := sim.createCheckout(ctx, sim.next)
+		if err != nil {
+			return err
+		}
+		sim.pending = append(sim.pending, req)
+	}
+
+	if err := sim.autoscale(ctx, elapsed); err != nil {
+		return err
+	}
+
+	var ps *app.PoolState
+	for i := 0; i < passesPerStep; i++ {
+		changed, err := sim.cl.step(ctx)
+		if err != nil {
+			return err
+		} else if i > 0 && !changed {
+			// Nothing for the controller to react to.
+			break
+		}
+
+		if ps, err = sim.reconcilePool(ctx); err != nil {
+			return err
+		}
+
+		if err := sim.reconcileCheckouts(ctx); err != nil {
+			return err
+		}
+	}
+
+	sim.samples = append(sim.samples, sample{
+		Desired:   ps.DesiredReplicas(),
+		Available: len(ps.Available),
+	})
+	return nil
+}
+
+func (sim *simulation) createCheckout(ctx context.Context, n int) (*checkoutRequest, error) {
+	checkout := pvpoolv1alpha1obj.NewCheckout(client.ObjectKey{
+		Namespace: Namespace,
+		Name:      fmt.Sprintf("%s-%06d", PoolName, n),
+	})
+	checkout.Object.Spec = pvpoolv1alpha1.CheckoutSpec{
+		PoolRef:     pvpoolv1alpha1.PoolReference{Name: PoolName},
+		AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+	}
+	if err := sim.cl.Create(ctx, checkout.Object); err != nil {
+		return nil, err
+	}
+
+	return &checkoutRequest{Key: checkout.Key, ArrivedAt: sim.cl.now}, nil
+}
+
+// autoscale applies the autoscaling policy, if any, to the pool.
+func (sim *simulation) autoscale(ctx context.Context, elapsed time.Duration) error {
+	ap := sim.cfg.Autoscale
+	if ap == nil {
+		return nil
+	}
+
+	recent := 0
+	for _, arrival := range sim.arrivals[:sim.next] {
+		if arrival > elapsed-ap.Window {
+			recent++
+		}
+	}
+
+	// Cover the arrivals we expect while a new replica is being prepared.
+	lead := sim.cfg.BindLatency + sim.cfg.InitDuration + sim.cfg.Step
+	desired := int32(math.Ceil(float64(recent) * float64(lead) / float64(ap.Window) * ap.Headroom))
+	if desired < ap.MinReplicas {
+		desired = ap.MinReplicas
+	} else if desired > ap.MaxReplicas {
+		desired = ap.MaxReplicas
+	}
+
+	if desired == sim.replicas {
+		return nil
+	}
+	sim.replicas = desired
+
+	pool := pvpoolv1alpha1obj.NewPool(sim.pool)
+	if _, err := (lifecycle.RequiredLoader{Loader: pool}).Load(ctx, sim.cl); err != nil {
+		return err
+	}
+
+	pool.Object.Spec.Replicas = pointer.Int32Ptr(desired)
+	pool.Object.SetGeneration(pool.Object.GetGeneration() + 1)
+	return sim.cl.Update(ctx, pool.Object)
+}
+
+// reconcilePool runs the same sequence of operations as the pool reconciler.
+func (sim *simulation) reconcilePool(ctx context.Context) (*app.PoolState, error) {
+	pool := pvpoolv1alpha1obj.NewPool(sim.pool)
+	if _, err := (lifecycle.RequiredLoader{Loader: pool}).Load(ctx, sim.cl); err != nil {
+		return nil, err
+	}
+
+	ps := app.NewPoolState(pool)
+	if _, err := ps.Load(ctx, sim.cl); err != nil {
+		return nil, err
+	}
+
+	ps = app.ConfigurePoolState(ps)
+	if err := ps.Persist(ctx, sim.cl); err != nil && !errmark.Matches(err, errmark.RuleMarkedTransient) {
+		return nil, err
+	}
+
+	return ps, nil
+}
+
+// reconcileCheckouts runs the same sequence of operations as the checkout
+// reconciler for each checkout that has not yet been bound.
+func (sim *simulation) reconcileCheckouts(ctx context.Context) error {
+	pending := sim.pending[:0]
+	for _, req := range sim.pending {
+		bound, err := sim.reconcileCheckout(ctx, req.Key)
+		if err != nil {
+			return err
+		}
+
+		if bound {
+			sim.waits = append(sim.waits, sim.cl.now.Sub(req.ArrivedAt))
+		} else {
+			pending = append(pending, req)
+		}
+	}
+	sim.pending = pending
+	return nil
+}
+
+func (sim *simulation) reconcileCheckout(ctx context.Context, key client.ObjectKey) (bool, error) {
+	checkout := pvpoolv1alpha1obj.NewCheckout(key)
+	if _, err := (lifecycle.RequiredLoader{Loader: checkout}).Load(ctx, sim.cl); err != nil {
+		return false, err
+	}
+
+	cs := app.NewCheckoutState(checkout)
+	if ok, err := cs.Load(ctx, sim.cl); err != nil && !errmark.Matches(err, errmark.RuleMarkedTransient) {
+		return false, err
+	} else if err != nil || !ok {
+		return false, nil
+	}
+
+	cs, err := app.ConfigureCheckoutState(cs)
+	if err != nil {
+		return false, err
+	}
+
+	if err := cs.Persist(ctx, sim.cl); err != nil && !errmark.Matches(err, errmark.RuleMarkedTransient) {
+		return false, err
+	}
+
+	return cs.PersistentVolumeClaim.Object.Status.Phase == corev1.ClaimBound, nil
+}
@@ -0,0 +1,70 @@
+package sim_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/puppetlabs/pvpool/pkg/sim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunWithSufficientReplicas(t *testing.T) {
+	report, err := sim.Run(context.Background(), sim.Config{
+		Replicas:     2,
+		BindLatency:  5 * time.Second,
+		InitDuration: 10 * time.Second,
+		Arrivals:     sim.ConstantArrivals{Interval: 2 * time.Minute},
+		Duration:     20 * time.Minute,
+		Step:         time.Second,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 9, report.Checkouts)
+	assert.Equal(t, report.Checkouts, report.Served)
+	assert.Equal(t, report.Checkouts, report.Immediate)
+	assert.Zero(t, report.Wait.Max.Duration)
+	assert.Equal(t, 2.0, report.Pool.MeanReplicas)
+	assert.Equal(t, report.Checkouts+2, report.Pool.Provisioned)
+}
+
+func TestRunWithInsufficientReplicas(t *testing.T) {
+	report, err := sim.Run(context.Background(), sim.Config{
+		Replicas:     1,
+		BindLatency:  10 * time.Second,
+		InitDuration: 20 * time.Second,
+		Arrivals:     sim.BurstArrivals{Size: 3, Interval: 2 * time.Minute},
+		Duration:     10 * time.Minute,
+		Step:         time.Second,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 12, report.Checkouts)
+	assert.Equal(t, report.Checkouts, report.Served)
+	assert.Less(t, report.Immediate, report.Served)
+
+	// The second and third checkouts in each burst have to wait for a new
+	// replica to be provisioned and initialized.
+	assert.GreaterOrEqual(t, report.Wait.Max.Duration, 30*time.Second)
+}
+
+func TestRunWithAutoscaling(t *testing.T) {
+	report, err := sim.Run(context.Background(), sim.Config{
+		Autoscale: &sim.AutoscalePolicy{
+			MinReplicas: 1,
+			MaxReplicas: 5,
+			Window:      5 * time.Minute,
+			Headroom:    2,
+		},
+		BindLatency:  10 * time.Second,
+		InitDuration: 20 * time.Second,
+		Arrivals:     sim.ConstantArrivals{Interval: 10 * time.Second},
+		Duration:     10 * time.Minute,
+		Step:         time.Second,
+	})
+	require.NoError(t, err)
+
+	assert.Greater(t, report.Pool.MaxReplicas, int32(1))
+	assert.LessOrEqual(t, report.Pool.MaxReplicas, int32(5))
+}
@@ -0,0 +1,109 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Client wraps a Kubernetes client so that each API call is recorded as a
+// span.
+type Client struct {
+	client.Client
+}
+
+var _ client.Client = &Client{}
+
+// WrapClient returns a client that traces every call made through it.
+func WrapClient(cl client.Client) *Client {
+	return &Client{Client: cl}
+}
+
+func (c *Client) attributes(obj runtime.Object) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		attrs = append(attrs, KindKey.String(gvk.Kind))
+	}
+
+	if o, ok := obj.(client.Object); ok && o.GetName() != "" {
+		attrs = append(attrs, ObjectKeyAttributes(client.ObjectKeyFromObject(o))...)
+	}
+
+	return attrs
+}
+
+func (c *Client) Get(ctx context.Context, key client.ObjectKey, obj client.Object) (err error) {
+	ctx, span := Start(ctx, "k8s.Get", append(c.attributes(obj), ObjectKeyAttributes(key)...)...)
+	defer func() { End(span, err) }()
+
+	return c.Client.Get(ctx, key, obj)
+}
+
+func (c *Client) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (err error) {
+	ctx, span := Start(ctx, "k8s.List", c.attributes(list)...)
+	defer func() { End(span, err) }()
+
+	return c.Client.List(ctx, list, opts...)
+}
+
+func (c *Client) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) (err error) {
+	ctx, span := Start(ctx, "k8s.Create", c.attributes(obj)...)
+	defer func() { End(span, err) }()
+
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *Client) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) (err error) {
+	ctx, span := Start(ctx, "k8s.Delete", c.attributes(obj)...)
+	defer func() { End(span, err) }()
+
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *Client) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) (err error) {
+	ctx, span := Start(ctx, "k8s.Update", c.attributes(obj)...)
+	defer func() { End(span, err) }()
+
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *Client) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) (err error) {
+	ctx, span := Start(ctx, "k8s.Patch", c.attributes(obj)...)
+	defer func() { End(span, err) }()
+
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *Client) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) (err error) {
+	ctx, span := Start(ctx, "k8s.DeleteAllOf", c.attributes(obj)...)
+	defer func() { End(span, err) }()
+
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *Client) Status() client.StatusWriter {
+	return &statusWriter{StatusWriter: c.Client.Status(), c: c}
+}
+
+type statusWriter struct {
+	client.StatusWriter
+	c *Client
+}
+
+func (sw *statusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) (err error) {
+	ctx, span := Start(ctx, "k8s.Status.Update", sw.c.attributes(obj)...)
+	defer func() { End(span, err) }()
+
+	return sw.StatusWriter.Update(ctx, obj, opts...)
+}
+
+func (sw *statusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) (err error) {
+	ctx, span := Start(ctx, "k8s.Status.Patch", sw.c.attributes(obj)...)
+	defer func() { End(span, err) }()
+
+	return sw.StatusWriter.Patch(ctx, obj, patch, opts...)
+}
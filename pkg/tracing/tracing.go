@@ -0,0 +1,95 @@
+// Package tracing provides OpenTelemetry instrumentation for the controllers.
+//
+// When tracing is not configured, the global no-op tracer provider is used and
+// spans cost almost nothing.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/puppetlabs/pvpool/pkg/opt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// InstrumentationName is the name of the tracer used by this module.
+	InstrumentationName = "github.com/puppetlabs/pvpool"
+)
+
+// Attribute keys attached to spans.
+const (
+	NamespaceKey = attribute.Key("k8s.namespace.name")
+	NameKey      = attribute.Key("k8s.object.name")
+	KindKey      = attribute.Key("k8s.object.kind")
+)
+
+// Start creates a new span as a child of any span in the given context.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(InstrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the given error, if any, and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// ObjectKeyAttributes returns span attributes describing the given object key.
+func ObjectKeyAttributes(key client.ObjectKey) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{NameKey.String(key.Name)}
+	if key.Namespace != "" {
+		attrs = append(attrs, NamespaceKey.String(key.Namespace))
+	}
+	return attrs
+}
+
+// Setup configures the global tracer provider to export spans to the OTLP
+// endpoint in the given configuration. If no endpoint is configured, tracing
+// remains disabled. The returned function flushes and stops the exporter.
+func Setup(ctx context.Context, cfg *opt.Config) (func(ctx context.Context) error, error) {
+	if !cfg.TracingEnabled() {
+		return func(ctx context.Context) error { return nil }, nil
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.TracingOTLPEndpoint)}
+	if cfg.TracingOTLPInsecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(cfg.Name)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.TracingSampleRatio))),
+	)
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return tp.Shutdown, nil
+}
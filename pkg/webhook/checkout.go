@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	pvpoolv1alpha1defaults "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/defaults"
 	pvpoolv1alpha1validation "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/validation"
 	admissionv1 "k8s.io/api/admission/v1"
 	authorizationv1 "k8s.io/api/authorization/v1"
@@ -34,6 +35,13 @@ type CheckoutValidator struct {
 var _ webhook.Validator = &CheckoutValidator{}
 
 func (cv *CheckoutValidator) ValidateCreate() error {
+	var errs field.ErrorList
+	errs = append(errs, pvpoolv1alpha1validation.ValidateCheckoutSpec(&cv.Spec, field.NewPath("spec"))...)
+
+	if len(errs) != 0 {
+		return k8serrors.NewInvalid(pvpoolv1alpha1.CheckoutKind.GroupKind(), cv.GetName(), errs)
+	}
+
 	return nil
 }
 
@@ -57,6 +65,21 @@ func (cv *CheckoutValidator) ValidateDelete() error {
 	return nil
 }
 
+// +kubebuilder:webhook:name=checkout.mutate.webhook.pvpool.puppet.com,groups=pvpool.puppet.com,versions=v1alpha1,resources=checkouts,verbs=create;update,path=/mutate-pvpool-puppet-com-v1alpha1-checkout,failurePolicy=fail,mutating=true,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// CheckoutDefaulter extends the Checkout type to set default values.
+//
+// +kubebuilder:object:root=true
+type CheckoutDefaulter struct {
+	*pvpoolv1alpha1.Checkout `json:",inline"`
+}
+
+var _ webhook.Defaulter = &CheckoutDefaulter{}
+
+func (cd *CheckoutDefaulter) Default() {
+	pvpoolv1alpha1defaults.DefaultCheckoutSpec(&cd.Spec)
+}
+
 // CheckoutRBACValidatorHandler performs access control validation for the
 // Checkout type.
 type CheckoutRBACValidatorHandler struct {
@@ -77,14 +100,18 @@ func (crvh *CheckoutRBACValidatorHandler) Handle(ctx context.Context, req admiss
 		return admission.Errored(http.StatusBadRequest, err)
 	}
 
-	gvr, err := crvh.mapper.RESTMapping(pvpoolv1alpha1.PoolKind.GroupKind())
-	if err != nil {
-		return admission.Errored(http.StatusInternalServerError, err)
+	kind, namespace := pvpoolv1alpha1.PoolKind, checkout.Spec.PoolRef.Namespace
+	if checkout.Spec.PoolRef.Kind == pvpoolv1alpha1.ClusterPoolKind.Kind {
+		// Cluster pools are not namespaced, so access is granted by a cluster
+		// role binding.
+		kind, namespace = pvpoolv1alpha1.ClusterPoolKind, ""
+	} else if namespace == "" {
+		namespace = checkout.GetNamespace()
 	}
 
-	namespace := checkout.Spec.PoolRef.Namespace
-	if namespace == "" {
-		namespace = checkout.GetNamespace()
+	gvr, err := crvh.mapper.RESTMapping(kind.GroupKind())
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
 	}
 
 	extra := make(map[string]authorizationv1.ExtraValue, len(req.UserInfo.Extra))
@@ -115,6 +142,8 @@ func (crvh *CheckoutRBACValidatorHandler) Handle(ctx context.Context, req admiss
 		var err error
 		if review.Status.Reason != "" {
 			err = errors.New(review.Status.Reason)
+		} else if namespace == "" {
+			err = fmt.Errorf("User %q cannot use resource %q in API group %q at the cluster scope", req.UserInfo.Username, gvr.Resource.Resource, gvr.Resource.Group)
 		} else {
 			err = fmt.Errorf("User %q cannot use resource %q in API group %q in the namespace %q", req.UserInfo.Username, gvr.Resource.Resource, gvr.Resource.Group, namespace)
 		}
@@ -149,16 +178,89 @@ func (crvh *CheckoutRBACValidatorHandler) InjectMapper(m meta.RESTMapper) error
 	return nil
 }
 
+// +kubebuilder:rbac:groups=pvpool.puppet.com,resources=pools;clusterpools,verbs=get;list;watch
+
+// CheckoutPoolValidatorHandler checks a new Checkout against the pool it
+// refers to. It warns about checkouts that will have to wait for the pool.
+type CheckoutPoolValidatorHandler struct {
+	cl      client.Client
+	decoder *admission.Decoder
+}
+
+func (cpvh *CheckoutPoolValidatorHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create {
+		return admission.Allowed("")
+	}
+
+	checkout := &pvpoolv1alpha1.Checkout{}
+	if err := cpvh.decoder.Decode(req, checkout); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	var (
+		desc   string
+		spec   *pvpoolv1alpha1.PoolSpec
+		status *pvpoolv1alpha1.PoolStatus
+		err    error
+	)
+	if ref := checkout.Spec.PoolRef; ref.Kind == pvpoolv1alpha1.ClusterPoolKind.Kind {
+		cp := &pvpoolv1alpha1.ClusterPool{}
+		desc, spec, status = fmt.Sprintf("cluster pool %q", ref.Name), &cp.Spec, &cp.Status.PoolStatus
+		err = cpvh.cl.Get(ctx, client.ObjectKey{Name: ref.Name}, cp)
+	} else {
+		key := client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}
+		if key.Namespace == "" {
+			key.Namespace = checkout.GetNamespace()
+		}
+
+		pool := &pvpoolv1alpha1.Pool{}
+		desc, spec, status = fmt.Sprintf("pool %q", key), &pool.Spec, &pool.Status
+		err = cpvh.cl.Get(ctx, key, pool)
+	}
+	if k8serrors.IsNotFound(err) {
+		return admission.Allowed("").WithWarnings(fmt.Sprintf("The %s does not exist. The checkout will wait until it is created.", desc))
+	} else if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	errs := pvpoolv1alpha1validation.ValidateCheckoutAccessModesForPool(&checkout.Spec, spec, field.NewPath("spec", "accessModes"))
+	if len(errs) != 0 {
+		status := k8serrors.NewInvalid(pvpoolv1alpha1.CheckoutKind.GroupKind(), checkout.GetName(), errs).Status()
+		return admission.Response{
+			AdmissionResponse: admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result:  &status,
+			},
+		}
+	}
+
+	if status.AvailableReplicas == 0 {
+		return admission.Allowed("").WithWarnings(fmt.Sprintf("The %s has no available replicas. The checkout will wait until one is available.", desc))
+	}
+
+	return admission.Allowed("")
+}
+
+var _ admission.DecoderInjector = &CheckoutPoolValidatorHandler{}
+
+func (cpvh *CheckoutPoolValidatorHandler) InjectDecoder(d *admission.Decoder) error {
+	cpvh.decoder = d
+	return nil
+}
+
 func AddCheckoutValidatorToManager(mgr manager.Manager) error {
 	mgr.GetWebhookServer().Register(
 		"/validate-pvpool-puppet-com-v1alpha1-checkout",
 		&admission.Webhook{
-			Handler: admission.MultiValidatingHandler(
+			Handler: validatingHandlers{
 				admission.ValidatingWebhookFor(&CheckoutValidator{}).Handler,
 				&CheckoutRBACValidatorHandler{
 					cl: mgr.GetClient(),
 				},
-			),
+				&CheckoutPoolValidatorHandler{
+					cl: mgr.GetClient(),
+				},
+			},
 		},
 	)
 	if err := mgr.AddHealthzCheck("checkout", func(_ *http.Request) error {
@@ -173,3 +275,11 @@ func AddCheckoutValidatorToManager(mgr manager.Manager) error {
 	}
 	return nil
 }
+
+func AddCheckoutDefaulterToManager(mgr manager.Manager) error {
+	mgr.GetWebhookServer().Register(
+		"/mutate-pvpool-puppet-com-v1alpha1-checkout",
+		admission.DefaultingWebhookFor(&CheckoutDefaulter{}),
+	)
+	return nil
+}
@@ -0,0 +1,195 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	pvpoolv1alpha1defaults "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/defaults"
+	pvpoolv1alpha1validation "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/validation"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:name=clusterpool.validate.webhook.pvpool.puppet.com,groups=pvpool.puppet.com,versions=v1alpha1,resources=clusterpools,verbs=create;update,path=/validate-pvpool-puppet-com-v1alpha1-clusterpool,failurePolicy=fail,mutating=false,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// ClusterPoolValidator extends the ClusterPool type to provide validation.
+//
+// +kubebuilder:object:root=true
+type ClusterPoolValidator struct {
+	*pvpoolv1alpha1.ClusterPool `json:",inline"`
+}
+
+var _ webhook.Validator = &ClusterPoolValidator{}
+
+func (cpv *ClusterPoolValidator) ValidateCreate() error {
+	var errs field.ErrorList
+	errs = append(errs, pvpoolv1alpha1validation.ValidatePoolSpec(&cpv.Spec, field.NewPath("spec"))...)
+
+	if len(errs) != 0 {
+		return errors.NewInvalid(pvpoolv1alpha1.ClusterPoolKind.GroupKind(), cpv.GetName(), errs)
+	}
+
+	return nil
+}
+
+func (cpv *ClusterPoolValidator) ValidateUpdate(old runtime.Object) error {
+	oldCPV, ok := old.(*ClusterPoolValidator)
+	if !ok {
+		return fmt.Errorf("unexpected type %T for old object in update", old)
+	}
+
+	var errs field.ErrorList
+	errs = append(errs, pvpoolv1alpha1validation.ValidatePoolSpecUpdate(&cpv.Spec, &oldCPV.Spec, field.NewPath("spec"))...)
+
+	if len(errs) != 0 {
+		return errors.NewInvalid(pvpoolv1alpha1.ClusterPoolKind.GroupKind(), cpv.GetName(), errs)
+	}
+
+	return nil
+}
+
+func (cpv *ClusterPoolValidator) ValidateDelete() error {
+	return nil
+}
+
+// ClusterPoolPolicyValidatorHandler checks that a ClusterPool satisfies the
+// cluster's PoolPolicy objects. Per-namespace storage limits are checked when
+// the controller creates the backing Pool in the storage namespace.
+type ClusterPoolPolicyValidatorHandler struct {
+	cl      client.Client
+	decoder *admission.Decoder
+}
+
+func (cppvh *ClusterPoolPolicyValidatorHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	switch req.Operation {
+	case admissionv1.Create, admissionv1.Update:
+	default:
+		return admission.Allowed("")
+	}
+
+	cp := &pvpoolv1alpha1.ClusterPool{}
+	if err := cppvh.decoder.Decode(req, cp); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	policies := &pvpoolv1alpha1.PoolPolicyList{}
+	if err := cppvh.cl.List(ctx, policies); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	errs := pvpoolv1alpha1validation.ValidatePoolSpecForPolicies(&cp.Spec, policies.Items, resource.Quantity{}, field.NewPath("spec"))
+	if len(errs) != 0 {
+		status := errors.NewInvalid(pvpoolv1alpha1.ClusterPoolKind.GroupKind(), cp.GetName(), errs).Status()
+		return admission.Response{
+			AdmissionResponse: admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result:  &status,
+			},
+		}
+	}
+
+	return admission.Allowed("")
+}
+
+var _ admission.DecoderInjector = &ClusterPoolPolicyValidatorHandler{}
+
+func (cppvh *ClusterPoolPolicyValidatorHandler) InjectDecoder(d *admission.Decoder) error {
+	cppvh.decoder = d
+	return nil
+}
+
+// +kubebuilder:webhook:name=clusterpool.mutate.webhook.pvpool.puppet.com,groups=pvpool.puppet.com,versions=v1alpha1,resources=clusterpools,verbs=create;update,path=/mutate-pvpool-puppet-com-v1alpha1-clusterpool,failurePolicy=fail,mutating=true,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// ClusterPoolDefaulterHandler sets the default values of a ClusterPool in the
+// same way as PoolDefaulterHandler.
+type ClusterPoolDefaulterHandler struct {
+	cl      client.Client
+	decoder *admission.Decoder
+}
+
+func (cpdh *ClusterPoolDefaulterHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	switch req.Operation {
+	case admissionv1.Create, admissionv1.Update:
+	default:
+		return admission.Allowed("")
+	}
+
+	cp := &pvpoolv1alpha1.ClusterPool{}
+	if err := cpdh.decoder.Decode(req, cp); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := applyPoolTemplate(ctx, cpdh.cl, req.Operation, &cp.Spec); errors.IsNotFound(err) {
+		return admission.Denied(err.Error())
+	} else if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	policies := &pvpoolv1alpha1.PoolPolicyList{}
+	if err := cpdh.cl.List(ctx, policies); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	pvpoolv1alpha1defaults.DefaultPoolSpec(&cp.Spec, pvpoolv1alpha1validation.MountJobLimitsForPolicies(policies.Items))
+
+	b, err := json.Marshal(cp)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, b)
+}
+
+var _ admission.DecoderInjector = &ClusterPoolDefaulterHandler{}
+
+func (cpdh *ClusterPoolDefaulterHandler) InjectDecoder(d *admission.Decoder) error {
+	cpdh.decoder = d
+	return nil
+}
+
+func AddClusterPoolValidatorToManager(mgr manager.Manager) error {
+	mgr.GetWebhookServer().Register(
+		"/validate-pvpool-puppet-com-v1alpha1-clusterpool",
+		&admission.Webhook{
+			Handler: admission.MultiValidatingHandler(
+				admission.ValidatingWebhookFor(&ClusterPoolValidator{}).Handler,
+				&ClusterPoolPolicyValidatorHandler{
+					cl: mgr.GetClient(),
+				},
+			),
+		},
+	)
+	if err := mgr.AddHealthzCheck("clusterpool", func(_ *http.Request) error {
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := mgr.AddReadyzCheck("clusterpool", func(_ *http.Request) error {
+		return nil
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func AddClusterPoolDefaulterToManager(mgr manager.Manager) error {
+	mgr.GetWebhookServer().Register(
+		"/mutate-pvpool-puppet-com-v1alpha1-clusterpool",
+		&admission.Webhook{
+			Handler: &ClusterPoolDefaulterHandler{
+				cl: mgr.GetClient(),
+			},
+		},
+	)
+	return nil
+}
@@ -0,0 +1,25 @@
+package webhook
+
+import (
+	"net/http"
+
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+)
+
+// AddConversionToManager serves conversions between the API versions of our
+// custom resources. The hub for each resource is the v1alpha1 version.
+func AddConversionToManager(mgr manager.Manager) error {
+	mgr.GetWebhookServer().Register("/convert", &conversion.Webhook{})
+	if err := mgr.AddHealthzCheck("conversion", func(_ *http.Request) error {
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := mgr.AddReadyzCheck("conversion", func(_ *http.Request) error {
+		return nil
+	}); err != nil {
+		return err
+	}
+	return nil
+}
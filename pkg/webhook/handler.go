@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// validatingHandlers combines validating handlers in the same way as
+// admission.MultiValidatingHandler, but keeps the warnings each handler
+// returns.
+type validatingHandlers []admission.Handler
+
+var _ admission.Handler = validatingHandlers{}
+var _ admission.DecoderInjector = validatingHandlers{}
+var _ inject.Injector = validatingHandlers{}
+
+func (hs validatingHandlers) Handle(ctx context.Context, req admission.Request) admission.Response {
+	var warnings []string
+	for _, handler := range hs {
+		resp := handler.Handle(ctx, req)
+		if !resp.Allowed {
+			return resp.WithWarnings(warnings...)
+		}
+
+		warnings = append(warnings, resp.Warnings...)
+	}
+
+	return admission.Allowed("").WithWarnings(warnings...)
+}
+
+func (hs validatingHandlers) InjectFunc(f inject.Func) error {
+	for _, handler := range hs {
+		if err := f(handler); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (hs validatingHandlers) InjectDecoder(d *admission.Decoder) error {
+	for _, handler := range hs {
+		if _, err := admission.InjectDecoderInto(d, handler); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
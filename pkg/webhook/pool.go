@@ -1,20 +1,27 @@
 package webhook
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 
 	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	pvpoolv1alpha1defaults "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/defaults"
 	pvpoolv1alpha1validation "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/validation"
+	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 // +kubebuilder:webhook:name=pool.validate.webhook.pvpool.puppet.com,groups=pvpool.puppet.com,versions=v1alpha1,resources=pools,verbs=create;update,path=/validate-pvpool-puppet-com-v1alpha1-pool,failurePolicy=fail,mutating=false,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=pvpool.puppet.com,resources=pools;poolpolicies;pooltemplates,verbs=get;list;watch
 
 // PoolValidator extends the Pool type to provide validation.
 //
@@ -56,10 +63,122 @@ func (pv *PoolValidator) ValidateDelete() error {
 	return nil
 }
 
+// +kubebuilder:webhook:name=pool.mutate.webhook.pvpool.puppet.com,groups=pvpool.puppet.com,versions=v1alpha1,resources=pools,verbs=create;update,path=/mutate-pvpool-puppet-com-v1alpha1-pool,failurePolicy=fail,mutating=true,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// PoolDefaulterHandler sets the default values of a Pool. The default mount job
+// deadlines depend on the cluster's PoolPolicy objects.
+type PoolDefaulterHandler struct {
+	cl      client.Client
+	decoder *admission.Decoder
+}
+
+func (pdh *PoolDefaulterHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	switch req.Operation {
+	case admissionv1.Create, admissionv1.Update:
+	default:
+		return admission.Allowed("")
+	}
+
+	pool := &pvpoolv1alpha1.Pool{}
+	if err := pdh.decoder.Decode(req, pool); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := applyPoolTemplate(ctx, pdh.cl, req.Operation, &pool.Spec); errors.IsNotFound(err) {
+		return admission.Denied(err.Error())
+	} else if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	policies := &pvpoolv1alpha1.PoolPolicyList{}
+	if err := pdh.cl.List(ctx, policies); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	pvpoolv1alpha1defaults.DefaultPoolSpec(&pool.Spec, pvpoolv1alpha1validation.MountJobLimitsForPolicies(policies.Items))
+
+	b, err := json.Marshal(pool)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, b)
+}
+
+var _ admission.DecoderInjector = &PoolDefaulterHandler{}
+
+func (pdh *PoolDefaulterHandler) InjectDecoder(d *admission.Decoder) error {
+	pdh.decoder = d
+	return nil
+}
+
+// PoolPolicyValidatorHandler checks that a Pool satisfies the cluster's
+// PoolPolicy objects.
+type PoolPolicyValidatorHandler struct {
+	cl      client.Client
+	decoder *admission.Decoder
+}
+
+func (ppvh *PoolPolicyValidatorHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	switch req.Operation {
+	case admissionv1.Create, admissionv1.Update:
+	default:
+		return admission.Allowed("")
+	}
+
+	pool := &pvpoolv1alpha1.Pool{}
+	if err := ppvh.decoder.Decode(req, pool); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	policies := &pvpoolv1alpha1.PoolPolicyList{}
+	if err := ppvh.cl.List(ctx, policies); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	// Only bother computing namespace usage if some policy needs it.
+	var namespaceStorage resource.Quantity
+	if pvpoolv1alpha1validation.PoliciesLimitNamespaceStorage(policies.Items) {
+		pools := &pvpoolv1alpha1.PoolList{}
+		if err := ppvh.cl.List(ctx, pools, client.InNamespace(req.Namespace)); err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+
+		namespaceStorage = pvpoolv1alpha1validation.NamespaceStorageRequest(pools.Items, req.Name)
+	}
+
+	errs := pvpoolv1alpha1validation.ValidatePoolSpecForPolicies(&pool.Spec, policies.Items, namespaceStorage, field.NewPath("spec"))
+	if len(errs) != 0 {
+		status := errors.NewInvalid(pvpoolv1alpha1.PoolKind.GroupKind(), pool.GetName(), errs).Status()
+		return admission.Response{
+			AdmissionResponse: admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result:  &status,
+			},
+		}
+	}
+
+	return admission.Allowed("")
+}
+
+var _ admission.DecoderInjector = &PoolPolicyValidatorHandler{}
+
+func (ppvh *PoolPolicyValidatorHandler) InjectDecoder(d *admission.Decoder) error {
+	ppvh.decoder = d
+	return nil
+}
+
 func AddPoolValidatorToManager(mgr manager.Manager) error {
 	mgr.GetWebhookServer().Register(
 		"/validate-pvpool-puppet-com-v1alpha1-pool",
-		admission.ValidatingWebhookFor(&PoolValidator{}),
+		&admission.Webhook{
+			Handler: admission.MultiValidatingHandler(
+				admission.ValidatingWebhookFor(&PoolValidator{}).Handler,
+				&PoolPolicyValidatorHandler{
+					cl: mgr.GetClient(),
+				},
+			),
+		},
 	)
 	if err := mgr.AddHealthzCheck("pool", func(_ *http.Request) error {
 		return nil
@@ -73,3 +192,15 @@ func AddPoolValidatorToManager(mgr manager.Manager) error {
 	}
 	return nil
 }
+
+func AddPoolDefaulterToManager(mgr manager.Manager) error {
+	mgr.GetWebhookServer().Register(
+		"/mutate-pvpool-puppet-com-v1alpha1-pool",
+		&admission.Webhook{
+			Handler: &PoolDefaulterHandler{
+				cl: mgr.GetClient(),
+			},
+		},
+	)
+	return nil
+}
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	"github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/pooltemplate"
+	pvpoolv1alpha1validation "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/validation"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:name=pooltemplate.validate.webhook.pvpool.puppet.com,groups=pvpool.puppet.com,versions=v1alpha1,resources=pooltemplates,verbs=create;update,path=/validate-pvpool-puppet-com-v1alpha1-pooltemplate,failurePolicy=fail,mutating=false,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// PoolTemplateValidator extends the PoolTemplate type to provide validation.
+//
+// +kubebuilder:object:root=true
+type PoolTemplateValidator struct {
+	*pvpoolv1alpha1.PoolTemplate `json:",inline"`
+}
+
+var _ webhook.Validator = &PoolTemplateValidator{}
+
+func (ptv *PoolTemplateValidator) ValidateCreate() error {
+	var errs field.ErrorList
+	errs = append(errs, pvpoolv1alpha1validation.ValidatePoolTemplateSpec(&ptv.Spec, field.NewPath("spec"))...)
+
+	if len(errs) != 0 {
+		return errors.NewInvalid(pvpoolv1alpha1.PoolTemplateKind.GroupKind(), ptv.GetName(), errs)
+	}
+
+	return nil
+}
+
+func (ptv *PoolTemplateValidator) ValidateUpdate(old runtime.Object) error {
+	if _, ok := old.(*PoolTemplateValidator); !ok {
+		return fmt.Errorf("unexpected type %T for old object in update", old)
+	}
+
+	return ptv.ValidateCreate()
+}
+
+func (ptv *PoolTemplateValidator) ValidateDelete() error {
+	return nil
+}
+
+// applyPoolTemplate copies the template referenced by a pool spec into the
+// spec. If the template does not exist, pools that are being created are
+// rejected, but other requests keep the last copy of the template so that,
+// for example, the controller can still remove a pool's finalizer.
+func applyPoolTemplate(ctx context.Context, cl client.Client, op admissionv1.Operation, spec *pvpoolv1alpha1.PoolSpec) error {
+	if spec.TemplateRef == nil {
+		return nil
+	}
+
+	tpl := &pvpoolv1alpha1.PoolTemplate{}
+	if err := cl.Get(ctx, client.ObjectKey{Name: spec.TemplateRef.Name}, tpl); errors.IsNotFound(err) && op != admissionv1.Create {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return pooltemplate.Apply(spec, tpl)
+}
+
+func AddPoolTemplateValidatorToManager(mgr manager.Manager) error {
+	mgr.GetWebhookServer().Register(
+		"/validate-pvpool-puppet-com-v1alpha1-pooltemplate",
+		admission.ValidatingWebhookFor(&PoolTemplateValidator{}),
+	)
+	if err := mgr.AddHealthzCheck("pooltemplate", func(_ *http.Request) error {
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := mgr.AddReadyzCheck("pooltemplate", func(_ *http.Request) error {
+		return nil
+	}); err != nil {
+		return err
+	}
+	return nil
+}
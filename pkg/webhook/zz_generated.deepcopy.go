@@ -9,6 +9,34 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckoutDefaulter) DeepCopyInto(out *CheckoutDefaulter) {
+	*out = *in
+	if in.Checkout != nil {
+		in, out := &in.Checkout, &out.Checkout
+		*out = new(v1alpha1.Checkout)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckoutDefaulter.
+func (in *CheckoutDefaulter) DeepCopy() *CheckoutDefaulter {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckoutDefaulter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CheckoutDefaulter) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CheckoutValidator) DeepCopyInto(out *CheckoutValidator) {
 	*out = *in
@@ -37,6 +65,62 @@ func (in *CheckoutValidator) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolValidator) DeepCopyInto(out *ClusterPoolValidator) {
+	*out = *in
+	if in.ClusterPool != nil {
+		in, out := &in.ClusterPool, &out.ClusterPool
+		*out = new(v1alpha1.ClusterPool)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolValidator.
+func (in *ClusterPoolValidator) DeepCopy() *ClusterPoolValidator {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolValidator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPoolValidator) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolTemplateValidator) DeepCopyInto(out *PoolTemplateValidator) {
+	*out = *in
+	if in.PoolTemplate != nil {
+		in, out := &in.PoolTemplate, &out.PoolTemplate
+		*out = new(v1alpha1.PoolTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolTemplateValidator.
+func (in *PoolTemplateValidator) DeepCopy() *PoolTemplateValidator {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolTemplateValidator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PoolTemplateValidator) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PoolValidator) DeepCopyInto(out *PoolValidator) {
 	*out = *in
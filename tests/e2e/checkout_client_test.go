@@ -0,0 +1,128 @@
+package e2e_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	"github.com/puppetlabs/pvpool/pkg/checkout"
+	"github.com/puppetlabs/pvpool/pkg/client/clientset/versioned"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCheckoutAcquireRelease(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	WithEnvironmentInTest(t, func(eit *EnvironmentInTest) {
+		eit.WithNamespace(ctx, func(ns *corev1.Namespace) {
+			poolKey := client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test-pool",
+			}
+			_ = eit.PoolHelpers.RequireCreatePoolThenWaitSettled(ctx, poolKey, WithReplicas(1))
+
+			cs, err := versioned.NewForConfig(eit.RESTConfig)
+			require.NoError(t, err)
+
+			co, err := checkout.Acquire(ctx, cs, pvpoolv1alpha1.PoolReference{
+				Namespace: poolKey.Namespace,
+				Name:      poolKey.Name,
+			}, checkout.AcquireOptions{
+				ClaimName: "test-claim",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, "test-claim", checkout.ClaimName(co))
+
+			require.NoError(t, checkout.Release(ctx, cs, co))
+			_, err = cs.PvpoolV1alpha1().Checkouts(ns.GetName()).Get(ctx, co.GetName(), metav1.GetOptions{})
+			assert.True(t, err == nil || apierrors.IsNotFound(err))
+
+			// Releasing twice is fine.
+			require.NoError(t, checkout.Release(ctx, cs, co))
+		})
+	})
+}
+
+func TestCheckoutAcquireConflict(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	WithEnvironmentInTest(t, func(eit *EnvironmentInTest) {
+		eit.WithNamespace(ctx, func(ns *corev1.Namespace) {
+			poolKey := client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test-pool",
+			}
+			_ = eit.PoolHelpers.RequireCreatePoolThenWaitSettled(ctx, poolKey, WithReplicas(1))
+
+			require.NoError(t, eit.ControllerClient.Create(ctx, &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: ns.GetName(),
+					Name:      "test-claim",
+				},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse("1Mi"),
+						},
+					},
+				},
+			}))
+
+			cs, err := versioned.NewForConfig(eit.RESTConfig)
+			require.NoError(t, err)
+
+			_, err = checkout.Acquire(ctx, cs, pvpoolv1alpha1.PoolReference{
+				Namespace: poolKey.Namespace,
+				Name:      poolKey.Name,
+			}, checkout.AcquireOptions{
+				Name:      "test-checkout",
+				ClaimName: "test-claim",
+			})
+
+			var cerr *checkout.ConflictError
+			require.True(t, errors.As(err, &cerr), "unexpected error: %+v", err)
+			assert.Equal(t, "test-claim", cerr.ClaimName)
+
+			_, err = cs.PvpoolV1alpha1().Checkouts(ns.GetName()).Get(ctx, "test-checkout", metav1.GetOptions{})
+			assert.True(t, apierrors.IsNotFound(err), "checkout was not cleaned up")
+		})
+	})
+}
+
+func TestCheckoutAcquireCanceled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	WithEnvironmentInTest(t, func(eit *EnvironmentInTest) {
+		eit.WithNamespace(ctx, func(ns *corev1.Namespace) {
+			cs, err := versioned.NewForConfig(eit.RESTConfig)
+			require.NoError(t, err)
+
+			acquireCtx, acquireCancel := context.WithTimeout(ctx, 5*time.Second)
+			defer acquireCancel()
+
+			// This pool does not exist, so the checkout can never be acquired.
+			_, err = checkout.Acquire(acquireCtx, cs, pvpoolv1alpha1.PoolReference{
+				Namespace: ns.GetName(),
+				Name:      "test-pool",
+			}, checkout.AcquireOptions{
+				Name: "test-checkout",
+			})
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+
+			_, err = cs.PvpoolV1alpha1().Checkouts(ns.GetName()).Get(ctx, "test-checkout", metav1.GetOptions{})
+			assert.True(t, apierrors.IsNotFound(err), "checkout was not cleaned up")
+		})
+	})
+}
@@ -12,6 +12,8 @@ import (
 	rbacv1obj "github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/api/rbacv1"
 	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
 	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	"github.com/puppetlabs/pvpool/pkg/controller/app"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -44,6 +46,82 @@ func TestCheckout(t *testing.T) {
 	})
 }
 
+func TestCheckoutStatusLineage(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	WithEnvironmentInTest(t, func(eit *EnvironmentInTest) {
+		eit.WithNamespace(ctx, func(ns *corev1.Namespace) {
+			poolKey := client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test-pool",
+			}
+			checkoutKey := client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test-checkout",
+			}
+			p := eit.PoolHelpers.RequireCreatePoolThenWaitSettled(ctx, poolKey, WithReplicas(1))
+			co := eit.CheckoutHelpers.RequireCreateCheckoutThenWaitCheckedOut(ctx, checkoutKey, client.ObjectKey{Name: poolKey.Name})
+
+			status := co.Object.Status
+			require.NotNil(t, status.Source)
+			require.Equal(t, poolKey.Namespace, status.Source.PoolRef.Namespace)
+			require.Equal(t, poolKey.Name, status.Source.PoolRef.Name)
+			require.Equal(t, p.Object.GetGeneration(), status.Source.PoolGeneration)
+			require.NotEmpty(t, status.Source.PersistentVolumeClaimName)
+			require.NotEmpty(t, status.Source.PersistentVolumeName)
+
+			require.NotNil(t, status.RequestedAt)
+			require.NotNil(t, status.VolumeSelectedAt)
+			require.NotNil(t, status.ClaimBoundAt)
+			require.NotNil(t, status.WaitDuration)
+			require.False(t, status.ClaimBoundAt.Before(status.RequestedAt))
+		})
+	})
+}
+
+func TestCheckoutProvenanceAnnotations(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	WithEnvironmentInTest(t, func(eit *EnvironmentInTest) {
+		eit.WithNamespace(ctx, func(ns *corev1.Namespace) {
+			poolKey := client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test-pool",
+			}
+			checkoutKey := client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test-checkout",
+			}
+			p := eit.PoolHelpers.RequireCreatePoolThenWaitSettled(ctx, poolKey, WithReplicas(1))
+			co := eit.CheckoutHelpers.RequireCreateCheckoutThenWaitCheckedOut(ctx, checkoutKey, client.ObjectKey{Name: poolKey.Name})
+
+			pvc := corev1obj.NewPersistentVolumeClaim(client.ObjectKey{
+				Namespace: co.Object.GetNamespace(),
+				Name:      co.Object.Status.VolumeClaimRef.Name,
+			})
+			_, err := (lifecycle.RequiredLoader{Loader: pvc}).Load(ctx, eit.ControllerClient)
+			require.NoError(t, err)
+
+			pv := corev1obj.NewPersistentVolume(pvc.Object.Spec.VolumeName)
+			_, err = (lifecycle.RequiredLoader{Loader: pv}).Load(ctx, eit.ControllerClient)
+			require.NoError(t, err)
+
+			for _, annotations := range []map[string]string{pvc.Object.GetAnnotations(), pv.Object.GetAnnotations()} {
+				require.Equal(t, string(co.Object.GetUID()), annotations[app.CheckoutUIDAnnotationKey])
+				require.Equal(t, poolKey.Namespace, annotations[app.CheckoutSourcePoolNamespaceAnnotationKey])
+				require.Equal(t, poolKey.Name, annotations[app.CheckoutSourcePoolNameAnnotationKey])
+				require.Equal(t, fmt.Sprintf("%d", p.Object.GetGeneration()), annotations[app.CheckoutSourcePoolGenerationAnnotationKey])
+				require.Equal(t, co.Object.Status.Source.PersistentVolumeClaimName, annotations[app.CheckoutSourceClaimNameAnnotationKey])
+				require.Equal(t, co.Object.Status.Source.PersistentVolumeName, annotations[app.CheckoutSourceVolumeNameAnnotationKey])
+				require.NotEmpty(t, annotations[app.CheckoutSourceInitJobNameAnnotationKey])
+				require.NotEmpty(t, annotations[app.CheckoutSourceInitCompletedAtAnnotationKey])
+			}
+		})
+	})
+}
+
 func TestCheckoutAcrossNamespaces(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
@@ -234,6 +312,66 @@ func TestCheckoutBeforePoolHasReplicas(t *testing.T) {
 	})
 }
 
+func TestCheckoutConditions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	WithEnvironmentInTest(t, func(eit *EnvironmentInTest) {
+		eit.WithNamespace(ctx, func(ns *corev1.Namespace) {
+			poolKey := client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test-pool",
+			}
+			checkoutKey := client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test-checkout",
+			}
+			p := eit.PoolHelpers.RequireCreatePoolThenWaitSettled(ctx, poolKey, WithReplicas(0))
+			co := eit.CheckoutHelpers.RequireCreateCheckout(ctx, checkoutKey, poolKey)
+
+			// An empty pool should be reported on the stage that selects a
+			// volume.
+			require.NoError(t, Wait(ctx, func(ctx context.Context) (bool, error) {
+				if _, err := (lifecycle.RequiredLoader{Loader: co}).Load(ctx, eit.ControllerClient); err != nil {
+					return true, err
+				}
+
+				if cond, _ := co.Condition(pvpoolv1alpha1.CheckoutVolumeSelected); cond.Reason != pvpoolv1alpha1.CheckoutAcquiredReasonNotAvailable {
+					return false, fmt.Errorf("waiting for checkout to report an empty pool")
+				}
+
+				return true, nil
+			}))
+
+			cond, _ := co.Condition(pvpoolv1alpha1.CheckoutReady)
+			assert.Equal(t, corev1.ConditionFalse, cond.Status)
+			assert.Equal(t, pvpoolv1alpha1.CheckoutAcquiredReasonNotAvailable, cond.Reason)
+
+			cond, _ = co.Condition(pvpoolv1alpha1.CheckoutTransferring)
+			assert.Equal(t, corev1.ConditionFalse, cond.Status)
+			assert.Equal(t, pvpoolv1alpha1.CheckoutTransferringReasonWaitingForVolume, cond.Reason)
+
+			_ = eit.PoolHelpers.RequireScalePoolThenWaitSettled(ctx, p, 1)
+			co = eit.CheckoutHelpers.RequireWaitCheckedOut(ctx, co)
+
+			for typ, expected := range map[pvpoolv1alpha1.CheckoutConditionType]struct {
+				Status corev1.ConditionStatus
+				Reason string
+			}{
+				pvpoolv1alpha1.CheckoutVolumeSelected: {corev1.ConditionTrue, pvpoolv1alpha1.CheckoutVolumeSelectedReasonSelected},
+				pvpoolv1alpha1.CheckoutTransferring:   {corev1.ConditionFalse, pvpoolv1alpha1.CheckoutTransferringReasonComplete},
+				pvpoolv1alpha1.CheckoutBound:          {corev1.ConditionTrue, pvpoolv1alpha1.CheckoutBoundReasonClaimBound},
+				pvpoolv1alpha1.CheckoutReady:          {corev1.ConditionTrue, pvpoolv1alpha1.CheckoutReadyReasonCheckedOut},
+			} {
+				cond, found := co.Condition(typ)
+				require.True(t, found, "condition %s", typ)
+				assert.Equal(t, expected.Status, cond.Status, "condition %s", typ)
+				assert.Equal(t, expected.Reason, cond.Reason, "condition %s", typ)
+			}
+		})
+	})
+}
+
 func TestCheckoutClaimName(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
@@ -377,6 +515,31 @@ func TestCheckoutAccessModes(t *testing.T) {
 	})
 }
 
+func TestCheckoutAccessModesNotSupportedByPool(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	WithEnvironmentInTest(t, func(eit *EnvironmentInTest) {
+		eit.WithNamespace(ctx, func(ns *corev1.Namespace) {
+			poolKey := client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test-pool",
+			}
+			checkoutKey := client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test-checkout",
+			}
+
+			// Create pool in RWO.
+			_ = eit.PoolHelpers.RequireCreatePool(ctx, poolKey, WithReplicas(1), WithAccessModes{corev1.ReadWriteOnce})
+
+			// A checkout can't write to the volume from many nodes.
+			_, err := eit.CheckoutHelpers.CreateCheckout(ctx, checkoutKey, poolKey, WithAccessModes{corev1.ReadWriteMany})
+			require.True(t, errors.IsInvalid(err), "expected invalid error, got %+v", err)
+		})
+	})
+}
+
 func TestCheckoutPVCReplacement(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
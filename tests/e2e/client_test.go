@@ -0,0 +1,78 @@
+package e2e_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	"github.com/puppetlabs/pvpool/pkg/client/clientset/versioned"
+	"github.com/puppetlabs/pvpool/pkg/client/informers/externalversions"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestClientset(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	WithEnvironmentInTest(t, func(eit *EnvironmentInTest) {
+		eit.WithNamespace(ctx, func(ns *corev1.Namespace) {
+			poolKey := client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test-pool",
+			}
+			_ = eit.PoolHelpers.RequireCreatePoolThenWaitSettled(ctx, poolKey, WithReplicas(1))
+
+			cs, err := versioned.NewForConfig(eit.RESTConfig)
+			require.NoError(t, err)
+
+			factory := externalversions.NewSharedInformerFactoryWithOptions(cs, 0, externalversions.WithNamespace(ns.GetName()))
+			checkouts := factory.Pvpool().V1alpha1().Checkouts()
+
+			claims := make(chan string, 1)
+			send := func(obj interface{}) {
+				select {
+				case claims <- obj.(*pvpoolv1alpha1.Checkout).Status.VolumeClaimRef.Name:
+				default:
+				}
+			}
+			checkouts.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+				FilterFunc: func(obj interface{}) bool {
+					return obj.(*pvpoolv1alpha1.Checkout).Status.VolumeClaimRef.Name != ""
+				},
+				Handler: cache.ResourceEventHandlerFuncs{
+					AddFunc:    send,
+					UpdateFunc: func(_, obj interface{}) { send(obj) },
+				},
+			})
+
+			factory.Start(ctx.Done())
+			require.True(t, cache.WaitForCacheSync(ctx.Done(), checkouts.Informer().HasSynced))
+
+			_, err = cs.PvpoolV1alpha1().Checkouts(ns.GetName()).Create(ctx, &pvpoolv1alpha1.Checkout{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-checkout",
+				},
+				Spec: pvpoolv1alpha1.CheckoutSpec{
+					PoolRef: pvpoolv1alpha1.PoolReference{Name: poolKey.Name},
+				},
+			}, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			select {
+			case claim := <-claims:
+				require.Equal(t, "test-checkout", claim)
+			case <-ctx.Done():
+				require.Fail(t, "timed out waiting for checkout")
+			}
+
+			checkout, err := checkouts.Lister().Checkouts(ns.GetName()).Get("test-checkout")
+			require.NoError(t, err)
+			require.Equal(t, "test-checkout", checkout.Status.VolumeClaimRef.Name)
+		})
+	})
+}
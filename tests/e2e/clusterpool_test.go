@@ -0,0 +1,97 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestClusterPoolCheckout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	WithEnvironmentInTest(t, func(eit *EnvironmentInTest) {
+		eit.WithNamespace(ctx, func(ns *corev1.Namespace) {
+			// Cluster pools aren't cleaned up with the test namespace, so we
+			// name this one after it to avoid collisions.
+			cp := pvpoolv1alpha1obj.NewClusterPool(ns.GetName())
+			cp.Object.Spec = pvpoolv1alpha1.PoolSpec{
+				Replicas: pointer.Int32Ptr(1),
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"app": "test",
+					},
+				},
+				Template: pvpoolv1alpha1.PersistentVolumeClaimTemplate{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							"app": "test",
+						},
+					},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						StorageClassName: eit.StorageClassNamePtr(),
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceStorage: resource.MustParse("10Mi"),
+							},
+						},
+					},
+				},
+			}
+			require.NoError(t, cp.Persist(ctx, eit.ControllerClient))
+			defer func() {
+				_, err := cp.Delete(context.Background(), eit.ControllerClient)
+				assert.NoError(t, err)
+			}()
+
+			require.NoError(t, Wait(ctx, func(ctx context.Context) (bool, error) {
+				if _, err := (lifecycle.RequiredLoader{Loader: cp}).Load(ctx, eit.ControllerClient); err != nil {
+					return true, err
+				}
+
+				if cp.Object.Status.PoolRef == nil {
+					return false, fmt.Errorf("cluster pool has no backing pool")
+				}
+
+				return true, nil
+			}))
+
+			p := pvpoolv1alpha1obj.NewPool(client.ObjectKey{
+				Namespace: cp.Object.Status.PoolRef.Namespace,
+				Name:      cp.Object.Status.PoolRef.Name,
+			})
+			p = eit.PoolHelpers.RequireWaitSettled(ctx, p)
+			require.NotNil(t, metav1.GetControllerOf(p.Object))
+			assert.Equal(t, cp.Object.GetUID(), metav1.GetControllerOf(p.Object).UID)
+
+			co := pvpoolv1alpha1obj.NewCheckout(client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test-checkout",
+			})
+			co.Object.Spec = pvpoolv1alpha1.CheckoutSpec{
+				PoolRef: pvpoolv1alpha1.PoolReference{
+					Kind: pvpoolv1alpha1.ClusterPoolKind.Kind,
+					Name: cp.Name,
+				},
+			}
+			require.NoError(t, co.Persist(ctx, eit.ControllerClient))
+
+			co = eit.CheckoutHelpers.RequireWaitCheckedOut(ctx, co)
+			require.NotNil(t, co.Object.Status.Source)
+			assert.Equal(t, p.Key.Namespace, co.Object.Status.Source.PoolRef.Namespace)
+			assert.Equal(t, p.Key.Name, co.Object.Status.Source.PoolRef.Name)
+		})
+	})
+}
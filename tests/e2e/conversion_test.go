@@ -0,0 +1,78 @@
+package e2e_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
+	pvpoolv1beta1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1beta1"
+	"github.com/puppetlabs/pvpool/pkg/client/clientset/versioned"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestPoolConversion(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	WithEnvironmentInTest(t, func(eit *EnvironmentInTest) {
+		eit.WithNamespace(ctx, func(ns *corev1.Namespace) {
+			cs, err := versioned.NewForConfig(eit.RESTConfig)
+			require.NoError(t, err)
+
+			_, err = cs.PvpoolV1beta1().Pools(ns.GetName()).Create(ctx, &pvpoolv1beta1.Pool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-pool",
+				},
+				Spec: pvpoolv1beta1.PoolSpec{
+					Replicas: pointer.Int32Ptr(1),
+					Selector: metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"app": "test",
+						},
+					},
+					VolumeClaimTemplate: pvpoolv1beta1.PersistentVolumeClaimTemplate{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								"app": "test",
+							},
+						},
+						Spec: corev1.PersistentVolumeClaimSpec{
+							StorageClassName: eit.StorageClassNamePtr(),
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceStorage: resource.MustParse("10Mi"),
+								},
+							},
+						},
+					},
+				},
+			}, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			// The controller only understands v1alpha1, so the pool settling is
+			// proof that the stored object converted correctly.
+			pool := pvpoolv1alpha1obj.NewPool(client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test-pool",
+			})
+			pool = eit.PoolHelpers.RequireWaitSettled(ctx, pool)
+			assert.Equal(t, "test", pool.Object.Spec.Template.GetLabels()["app"])
+			require.Len(t, pool.Object.Status.ReplicaStatuses, 1)
+
+			converted, err := cs.PvpoolV1beta1().Pools(ns.GetName()).Get(ctx, "test-pool", metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, pool.Object.Spec.Template.Spec.Resources, converted.Spec.VolumeClaimTemplate.Spec.Resources)
+			require.Len(t, converted.Status.ReplicaStatuses, 1)
+			assert.Equal(t, pool.Object.Status.ReplicaStatuses[0].ClaimName, converted.Status.ReplicaStatuses[0].VolumeClaimName)
+			assert.True(t, meta.IsStatusConditionTrue(converted.Status.Conditions, pvpoolv1beta1.PoolSettlement))
+		})
+	})
+}
@@ -18,6 +18,7 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2/klogr"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -34,12 +35,24 @@ type EnvironmentInTest struct {
 	*endtoend.Environment
 	Labels           map[string]string
 	StorageClassName string
+	Kubeconfigs      []string
+	Context          string
 	PoolHelpers      *PoolHelpers
 	CheckoutHelpers  *CheckoutHelpers
 	t                *testing.T
 	nf               endtoend.NamespaceFactory
 }
 
+// StorageClassNamePtr returns the storage class name to use in a pool's PVC
+// template, or nil to use the cluster's default storage class.
+func (eit *EnvironmentInTest) StorageClassNamePtr() *string {
+	if eit.StorageClassName == "" {
+		return nil
+	}
+
+	return pointer.StringPtr(eit.StorageClassName)
+}
+
 func (eit *EnvironmentInTest) WithNamespace(ctx context.Context, fn func(ns *corev1.Namespace)) {
 	require.NoError(eit.t, endtoend.WithNamespace(ctx, eit.Environment, eit.nf, fn))
 }
@@ -52,6 +65,8 @@ func (eit *EnvironmentInTest) Impersonate(ic rest.ImpersonationConfig) *Environm
 		Environment:      e,
 		Labels:           eit.Labels,
 		StorageClassName: eit.StorageClassName,
+		Kubeconfigs:      eit.Kubeconfigs,
+		Context:          eit.Context,
 		t:                eit.t,
 		nf:               eit.nf,
 	}
@@ -92,6 +107,8 @@ func WithEnvironmentInTest(t *testing.T, fn func(eit *EnvironmentInTest)) {
 			Environment:      e,
 			Labels:           ls,
 			StorageClassName: strings.TrimSpace(viper.GetString("storage_class_name")),
+			Kubeconfigs:      filepath.SplitList(kubeconfigs),
+			Context:          viper.GetString("context"),
 			t:                t,
 			nf:               endtoend.NewTestNamespaceFactory(t, endtoend.NamespaceWithLabels(ls)),
 		}
@@ -34,6 +34,38 @@ func (wam WithAccessModes) ApplyToCreatePoolOptions(target *CreatePoolOptions) {
 	target.AccessModes = wam
 }
 
+type WithStorageClass string
+
+var _ CreatePoolOption = WithStorageClass("")
+
+func (wsc WithStorageClass) ApplyToCreatePoolOptions(target *CreatePoolOptions) {
+	target.StorageClass = string(wsc)
+}
+
+type WithProvisioning pvpoolv1alpha1.PoolProvisioning
+
+var _ CreatePoolOption = WithProvisioning{}
+
+func (wp WithProvisioning) ApplyToCreatePoolOptions(target *CreatePoolOptions) {
+	target.Provisioning = (*pvpoolv1alpha1.PoolProvisioning)(&wp)
+}
+
+type WithDeletionPolicy pvpoolv1alpha1.PoolDeletionPolicy
+
+var _ CreatePoolOption = WithDeletionPolicy("")
+
+func (wdp WithDeletionPolicy) ApplyToCreatePoolOptions(target *CreatePoolOptions) {
+	target.DeletionPolicy = pvpoolv1alpha1.PoolDeletionPolicy(wdp)
+}
+
+type WithAdoption pvpoolv1alpha1.PoolAdoption
+
+var _ CreatePoolOption = WithAdoption{}
+
+func (wa WithAdoption) ApplyToCreatePoolOptions(target *CreatePoolOptions) {
+	target.Adoption = (*pvpoolv1alpha1.PoolAdoption)(&wa)
+}
+
 type WithInitJob pvpoolv1alpha1.MountJob
 
 var _ CreatePoolOption = WithInitJob{}
@@ -43,3 +75,13 @@ var _ CreatePoolOption = WithInitJob{}
 func (wij WithInitJob) ApplyToCreatePoolOptions(target *CreatePoolOptions) {
 	target.InitJob = (*pvpoolv1alpha1.MountJob)(&wij)
 }
+
+type WithHealthCheck pvpoolv1alpha1.PoolHealthCheck
+
+var _ CreatePoolOption = WithHealthCheck{}
+
+// nolint:gocritic // This is the most expressive way to represent this test
+//                 // option.
+func (whc WithHealthCheck) ApplyToCreatePoolOptions(target *CreatePoolOptions) {
+	target.HealthCheck = (*pvpoolv1alpha1.PoolHealthCheck)(&whc)
+}
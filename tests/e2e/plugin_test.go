@@ -0,0 +1,63 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/puppetlabs/pvpool/pkg/plugin"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func runPlugin(ctx context.Context, t *testing.T, eit *EnvironmentInTest, namespace string, args ...string) string {
+	require.NoError(t, os.Setenv(clientcmd.RecommendedConfigPathEnvVar, strings.Join(eit.Kubeconfigs, string(filepath.ListSeparator))))
+
+	var out bytes.Buffer
+
+	cmd := plugin.NewCommand()
+	cmd.SetArgs(append([]string{"--context", eit.Context, "--namespace", namespace}, args...))
+	cmd.SetOut(&out)
+	require.NoError(t, cmd.ExecuteContext(ctx))
+
+	return out.String()
+}
+
+func TestPlugin(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	WithEnvironmentInTest(t, func(eit *EnvironmentInTest) {
+		eit.WithNamespace(ctx, func(ns *corev1.Namespace) {
+			poolKey := client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test-pool",
+			}
+			p := eit.PoolHelpers.RequireCreatePoolThenWaitSettled(ctx, poolKey, WithReplicas(2))
+
+			status := runPlugin(ctx, t, eit, ns.GetName(), "status")
+			require.Regexp(t, `test-pool\s+2\s+0\s+0\s+2\s+0`, status)
+
+			claim := strings.TrimSpace(runPlugin(ctx, t, eit, ns.GetName(), "checkout", poolKey.Name, "--name", "test-checkout"))
+			require.Equal(t, "test-checkout", claim)
+
+			why := runPlugin(ctx, t, eit, ns.GetName(), "why", "test-checkout")
+			require.Contains(t, why, "has acquired PVC test-checkout")
+
+			_ = eit.PoolHelpers.RequireWaitSettled(ctx, p)
+
+			describe := runPlugin(ctx, t, eit, ns.GetName(), "describe", poolKey.Name)
+			require.Contains(t, describe, "2 desired")
+			require.Contains(t, describe, "test-checkout")
+
+			release := runPlugin(ctx, t, eit, ns.GetName(), "release", "test-checkout")
+			require.Contains(t, release, "checkout test-checkout released")
+		})
+	})
+}
@@ -49,9 +49,14 @@ func (ph *PoolHelpers) RequireWaitSettled(ctx context.Context, p *pvpoolv1alpha1
 }
 
 type CreatePoolOptions struct {
-	Replicas    *int32
-	AccessModes []corev1.PersistentVolumeAccessMode
-	InitJob     *pvpoolv1alpha1.MountJob
+	Replicas       *int32
+	AccessModes    []corev1.PersistentVolumeAccessMode
+	InitJob        *pvpoolv1alpha1.MountJob
+	HealthCheck    *pvpoolv1alpha1.PoolHealthCheck
+	DeletionPolicy pvpoolv1alpha1.PoolDeletionPolicy
+	Provisioning   *pvpoolv1alpha1.PoolProvisioning
+	Adoption       *pvpoolv1alpha1.PoolAdoption
+	StorageClass   string
 }
 
 type CreatePoolOption interface {
@@ -65,9 +70,17 @@ func (o *CreatePoolOptions) ApplyOptions(opts []CreatePoolOption) {
 }
 
 func (ph *PoolHelpers) CreatePool(ctx context.Context, key client.ObjectKey, opts ...CreatePoolOption) (*pvpoolv1alpha1obj.Pool, error) {
-	o := &CreatePoolOptions{}
+	o := &CreatePoolOptions{
+		StorageClass: ph.eit.StorageClassName,
+	}
 	o.ApplyOptions(opts)
 
+	// An empty storage class name selects the default storage class.
+	var storageClassName *string
+	if o.StorageClass != "" {
+		storageClassName = pointer.StringPtr(o.StorageClass)
+	}
+
 	p := pvpoolv1alpha1obj.NewPool(key)
 	p.Object.Spec = pvpoolv1alpha1.PoolSpec{
 		Replicas: o.Replicas,
@@ -84,7 +97,7 @@ func (ph *PoolHelpers) CreatePool(ctx context.Context, key client.ObjectKey, opt
 			},
 			Spec: corev1.PersistentVolumeClaimSpec{
 				AccessModes:      o.AccessModes,
-				StorageClassName: pointer.StringPtr(ph.eit.StorageClassName),
+				StorageClassName: storageClassName,
 				Resources: corev1.ResourceRequirements{
 					Requests: corev1.ResourceList{
 						corev1.ResourceStorage: resource.MustParse("10Mi"),
@@ -92,7 +105,11 @@ func (ph *PoolHelpers) CreatePool(ctx context.Context, key client.ObjectKey, opt
 				},
 			},
 		},
-		InitJob: o.InitJob,
+		InitJob:        o.InitJob,
+		HealthCheck:    o.HealthCheck,
+		DeletionPolicy: o.DeletionPolicy,
+		Provisioning:   o.Provisioning,
+		Adoption:       o.Adoption,
 	}
 	if err := p.Persist(ctx, ph.eit.ControllerClient); err != nil {
 		return nil, err
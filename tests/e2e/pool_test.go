@@ -8,10 +8,17 @@ import (
 
 	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
 	"github.com/puppetlabs/leg/mathutil/pkg/rand"
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
 	"github.com/puppetlabs/pvpool/pkg/controller/app"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -96,3 +103,360 @@ func TestPoolsWithSameSelector(t *testing.T) {
 		})
 	})
 }
+
+func TestPoolPolicy(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	WithEnvironmentInTest(t, func(eit *EnvironmentInTest) {
+		eit.WithNamespace(ctx, func(ns *corev1.Namespace) {
+			policy := pvpoolv1alpha1obj.NewPoolPolicy(ns.GetName())
+			policy.Object.Spec.MaxReplicas = pointer.Int32Ptr(2)
+			require.NoError(t, policy.Persist(ctx, eit.ControllerClient))
+			defer func() {
+				_, err := policy.Delete(ctx, eit.ControllerClient)
+				assert.NoError(t, err)
+			}()
+
+			// A pool that asks for more replicas than the policy allows should
+			// be rejected.
+			_, err := eit.PoolHelpers.CreatePool(ctx, client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test-too-large",
+			}, WithReplicas(3))
+			require.True(t, errors.IsInvalid(err), "unexpected error: %+v", err)
+
+			// But one within the limit is fine.
+			_ = eit.PoolHelpers.RequireCreatePoolThenWaitSettled(ctx, client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test",
+			}, WithReplicas(2))
+		})
+	})
+}
+
+func TestPoolDefaults(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	WithEnvironmentInTest(t, func(eit *EnvironmentInTest) {
+		eit.WithNamespace(ctx, func(ns *corev1.Namespace) {
+			tpl := pvpoolv1alpha1.MountJob{
+				Template: pvpoolv1alpha1.JobTemplate{
+					Spec: batchv1.JobSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:  "init",
+										Image: "busybox:stable-musl",
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			// The webhook fills in the job configuration the controller will
+			// use.
+			pool := eit.PoolHelpers.RequireCreatePool(ctx, client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test",
+			}, WithInitJob(tpl))
+			assert.Equal(t, int32(1), *pool.Object.Spec.Replicas)
+			assert.Equal(t, pvpoolv1alpha1.PoolDeletionPolicyDelete, pool.Object.Spec.DeletionPolicy)
+			assert.Equal(t, "workspace", pool.Object.Spec.InitJob.VolumeName)
+
+			spec := pool.Object.Spec.InitJob.Template.Spec
+			assert.Equal(t, corev1.RestartPolicyNever, spec.Template.Spec.RestartPolicy)
+			assert.Equal(t, int64(300), *spec.ActiveDeadlineSeconds)
+			assert.Equal(t, int32(6), *spec.BackoffLimit)
+
+			// Values that exceed the limits are rejected instead of being
+			// silently lowered.
+			tpl.Template.Spec.ActiveDeadlineSeconds = pointer.Int64Ptr(301)
+			_, err := eit.PoolHelpers.CreatePool(ctx, client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test-too-long",
+			}, WithInitJob(tpl))
+			require.True(t, errors.IsInvalid(err), "unexpected error: %+v", err)
+		})
+	})
+}
+
+func TestPoolInitJobIdentity(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	WithEnvironmentInTest(t, func(eit *EnvironmentInTest) {
+		eit.WithNamespace(ctx, func(ns *corev1.Namespace) {
+			key := client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test",
+			}
+
+			// The init job will fail, and the pool will never settle, unless
+			// the replica identity is passed to the container.
+			tpl := pvpoolv1alpha1.MountJob{
+				Template: pvpoolv1alpha1.JobTemplate{
+					Spec: batchv1.JobSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:  "init",
+										Image: "busybox:stable-musl",
+										Command: []string{
+											"/bin/sh",
+											"-c",
+											fmt.Sprintf(
+												`test "$PVPOOL_POOL_NAMESPACE/$PVPOOL_POOL_NAME" = %q && test -n "$PVPOOL_REPLICA_CLAIM_NAME" && test "$PVPOOL_POOL_GENERATION" -gt 0`,
+												key.String(),
+											),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			_ = eit.PoolHelpers.RequireCreatePoolThenWaitSettled(ctx, key, WithReplicas(2), WithInitJob(tpl))
+		})
+	})
+}
+
+func TestPoolHealthCheck(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	WithEnvironmentInTest(t, func(eit *EnvironmentInTest) {
+		eit.WithNamespace(ctx, func(ns *corev1.Namespace) {
+			key := client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test",
+			}
+
+			// The health check succeeds only if the init job ran first.
+			job := func(name string, command string) pvpoolv1alpha1.MountJob {
+				return pvpoolv1alpha1.MountJob{
+					Template: pvpoolv1alpha1.JobTemplate{
+						Spec: batchv1.JobSpec{
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{
+										{
+											Name:    name,
+											Image:   "busybox:stable-musl",
+											Command: []string{"/bin/sh", "-c", command},
+											VolumeMounts: []corev1.VolumeMount{
+												{
+													Name:      "workspace",
+													MountPath: "/workspace",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			}
+
+			p := eit.PoolHelpers.RequireCreatePoolThenWaitSettled(
+				ctx, key,
+				WithReplicas(2),
+				WithInitJob(job("init", "touch /workspace/ok")),
+				WithHealthCheck(pvpoolv1alpha1.PoolHealthCheck{
+					Job:            job("check", "test -f /workspace/ok"),
+					BeforeCheckout: true,
+				}),
+			)
+
+			// A checkout must wait for a replica to be verified, and then
+			// should complete normally.
+			_ = eit.CheckoutHelpers.RequireCreateCheckoutThenWaitCheckedOut(ctx, client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test",
+			}, client.ObjectKeyFromObject(p.Object))
+		})
+	})
+}
+
+func TestPoolDeletionPolicyOrphan(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	WithEnvironmentInTest(t, func(eit *EnvironmentInTest) {
+		eit.WithNamespace(ctx, func(ns *corev1.Namespace) {
+			key := client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test",
+			}
+			p := eit.PoolHelpers.RequireCreatePoolThenWaitSettled(ctx, key, WithReplicas(2), WithDeletionPolicy(pvpoolv1alpha1.PoolDeletionPolicyOrphan))
+
+			ps := app.NewPoolState(p)
+			_, err := (lifecycle.RequiredLoader{Loader: ps}).Load(ctx, eit.ControllerClient)
+			require.NoError(t, err)
+			require.Len(t, ps.Available, 2)
+
+			uids := make(map[types.UID]struct{})
+			for _, pr := range ps.Available {
+				uids[pr.PersistentVolumeClaim.Object.GetUID()] = struct{}{}
+			}
+
+			// Delete the pool and wait for it to go away.
+			_, err = p.Delete(ctx, eit.ControllerClient)
+			require.NoError(t, err)
+			require.NoError(t, Wait(ctx, func(ctx context.Context) (bool, error) {
+				if ok, err := pvpoolv1alpha1obj.NewPool(key).Load(ctx, eit.ControllerClient); err != nil {
+					return true, err
+				} else if ok {
+					return false, fmt.Errorf("pool still exists")
+				}
+
+				return true, nil
+			}))
+
+			// A new pool with the same selector should adopt the replicas.
+			p = eit.PoolHelpers.RequireCreatePoolThenWaitSettled(ctx, key, WithReplicas(2))
+
+			ps = app.NewPoolState(p)
+			_, err = (lifecycle.RequiredLoader{Loader: ps}).Load(ctx, eit.ControllerClient)
+			require.NoError(t, err)
+			require.Len(t, ps.Available, 2)
+			for _, pr := range ps.Available {
+				assert.Contains(t, uids, pr.PersistentVolumeClaim.Object.GetUID())
+			}
+		})
+	})
+}
+
+func TestPoolAdoption(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	WithEnvironmentInTest(t, func(eit *EnvironmentInTest) {
+		eit.WithNamespace(ctx, func(ns *corev1.Namespace) {
+			key := client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test",
+			}
+
+			// Get a bound PVC by orphaning it from a pool, then make it look
+			// like it was provisioned by hand.
+			p := eit.PoolHelpers.RequireCreatePoolThenWaitSettled(ctx, key, WithReplicas(1), WithDeletionPolicy(pvpoolv1alpha1.PoolDeletionPolicyOrphan))
+
+			ps := app.NewPoolState(p)
+			_, err := (lifecycle.RequiredLoader{Loader: ps}).Load(ctx, eit.ControllerClient)
+			require.NoError(t, err)
+			require.Len(t, ps.Available, 1)
+			pvc := ps.Available[0].PersistentVolumeClaim
+
+			_, err = p.Delete(ctx, eit.ControllerClient)
+			require.NoError(t, err)
+			require.NoError(t, Wait(ctx, func(ctx context.Context) (bool, error) {
+				if ok, err := pvpoolv1alpha1obj.NewPool(key).Load(ctx, eit.ControllerClient); err != nil {
+					return true, err
+				} else if ok {
+					return false, fmt.Errorf("pool still exists")
+				}
+
+				return true, nil
+			}))
+
+			_, err = (lifecycle.RequiredLoader{Loader: pvc}).Load(ctx, eit.ControllerClient)
+			require.NoError(t, err)
+			require.Nil(t, metav1.GetControllerOf(pvc.Object))
+
+			pvc.Object.SetLabels(map[string]string{"warm": "true"})
+			pvc.Object.SetAnnotations(nil)
+			require.NoError(t, pvc.Persist(ctx, eit.ControllerClient))
+
+			// The new pool should adopt the PVC and create one more replica
+			// to make up the difference.
+			p = eit.PoolHelpers.RequireCreatePoolThenWaitSettled(ctx, key, WithReplicas(2), WithAdoption{
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"warm": "true"},
+				},
+			})
+
+			ps = app.NewPoolState(p)
+			_, err = (lifecycle.RequiredLoader{Loader: ps}).Load(ctx, eit.ControllerClient)
+			require.NoError(t, err)
+			require.Len(t, ps.Available, 2)
+
+			var adopted *app.PoolReplica
+			for _, pr := range ps.Available {
+				if pr.PersistentVolumeClaim.Object.GetUID() == pvc.Object.GetUID() {
+					adopted = pr
+				}
+			}
+			require.NotNil(t, adopted, "PVC was not adopted")
+			assert.Equal(t, "test", adopted.PersistentVolumeClaim.Object.GetLabels()["app"])
+			assert.NotEmpty(t, adopted.PersistentVolumeClaim.Object.GetAnnotations()[app.PoolReplicaAdoptedAtAnnotationKey])
+			assert.True(t, metav1.IsControlledBy(adopted.PersistentVolumeClaim.Object, p.Object))
+		})
+	})
+}
+
+func TestPoolReplicaStatuses(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	WithEnvironmentInTest(t, func(eit *EnvironmentInTest) {
+		eit.WithNamespace(ctx, func(ns *corev1.Namespace) {
+			key := client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test",
+			}
+			p := eit.PoolHelpers.RequireCreatePoolThenWaitSettled(ctx, key, WithReplicas(2))
+
+			require.NotEmpty(t, p.Object.Status.TemplateHash)
+			require.Len(t, p.Object.Status.ReplicaStatuses, 2)
+			for _, rs := range p.Object.Status.ReplicaStatuses {
+				assert.Equal(t, pvpoolv1alpha1.PoolReplicaPhaseAvailable, rs.Phase)
+				assert.NotEmpty(t, rs.ClaimName)
+				assert.NotEmpty(t, rs.VolumeName)
+				assert.Equal(t, p.Object.Status.TemplateHash, rs.TemplateHash)
+			}
+		})
+	})
+}
+
+func TestPoolProvisioningStalled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	WithEnvironmentInTest(t, func(eit *EnvironmentInTest) {
+		eit.WithNamespace(ctx, func(ns *corev1.Namespace) {
+			key := client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test",
+			}
+
+			// No provisioner will ever bind a PVC with a storage class that
+			// doesn't exist.
+			p := eit.PoolHelpers.RequireCreatePool(
+				ctx, key,
+				WithReplicas(1),
+				WithStorageClass(fmt.Sprintf("%s-does-not-exist", ns.GetName())),
+				WithProvisioning{StalledAfter: &metav1.Duration{Duration: time.Second}},
+			)
+
+			require.NoError(t, Wait(ctx, func(ctx context.Context) (bool, error) {
+				if _, err := (lifecycle.RequiredLoader{Loader: p}).Load(ctx, eit.ControllerClient); err != nil {
+					return true, err
+				}
+
+				if cond, _ := p.Condition(pvpoolv1alpha1.PoolProvisioningStalled); cond.Status != corev1.ConditionTrue {
+					return false, fmt.Errorf("pool provisioning has not stalled")
+				}
+
+				return true, nil
+			}))
+		})
+	})
+}
@@ -0,0 +1,104 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
+	pvpoolv1alpha1 "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1"
+	pvpoolv1alpha1obj "github.com/puppetlabs/pvpool/pkg/apis/pvpool.puppet.com/v1alpha1/obj"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestPoolTemplatePropagation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	WithEnvironmentInTest(t, func(eit *EnvironmentInTest) {
+		eit.WithNamespace(ctx, func(ns *corev1.Namespace) {
+			// Pool templates aren't cleaned up with the test namespace, so we
+			// name this one after it to avoid collisions.
+			pt := pvpoolv1alpha1obj.NewPoolTemplate(ns.GetName())
+			pt.Object.Spec = pvpoolv1alpha1.PoolTemplateSpec{
+				Template: pvpoolv1alpha1.PersistentVolumeClaimTemplate{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							"app": "test",
+						},
+					},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						StorageClassName: eit.StorageClassNamePtr(),
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceStorage: resource.MustParse("10Mi"),
+							},
+						},
+					},
+				},
+			}
+			require.NoError(t, pt.Persist(ctx, eit.ControllerClient))
+			defer func() {
+				_, err := pt.Delete(context.Background(), eit.ControllerClient)
+				assert.NoError(t, err)
+			}()
+
+			p := pvpoolv1alpha1obj.NewPool(client.ObjectKey{
+				Namespace: ns.GetName(),
+				Name:      "test",
+			})
+			p.Object.Spec = pvpoolv1alpha1.PoolSpec{
+				Replicas: pointer.Int32Ptr(1),
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"app": "test",
+					},
+				},
+				TemplateRef: &pvpoolv1alpha1.PoolTemplateReference{
+					Name: pt.Name,
+					Overrides: &pvpoolv1alpha1.PoolTemplateOverrides{
+						Template: &pvpoolv1alpha1.PersistentVolumeClaimTemplate{
+							ObjectMeta: metav1.ObjectMeta{
+								Labels: map[string]string{
+									"tier": "override",
+								},
+							},
+						},
+					},
+				},
+			}
+			require.NoError(t, p.Persist(ctx, eit.ControllerClient))
+
+			p = eit.PoolHelpers.RequireWaitSettled(ctx, p)
+			assert.Equal(t, map[string]string{"app": "test", "tier": "override"}, p.Object.Spec.Template.Labels)
+			assert.Equal(t, resource.MustParse("10Mi"), p.Object.Spec.Template.Spec.Resources.Requests[corev1.ResourceStorage])
+
+			// Changing the template should update the pool.
+			pt.Object.Spec.Template.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("20Mi")
+			require.NoError(t, pt.Persist(ctx, eit.ControllerClient))
+
+			require.NoError(t, Wait(ctx, func(ctx context.Context) (bool, error) {
+				if _, err := (lifecycle.RequiredLoader{Loader: p}).Load(ctx, eit.ControllerClient); err != nil {
+					return true, err
+				}
+
+				storage := p.Object.Spec.Template.Spec.Resources.Requests[corev1.ResourceStorage]
+				if storage.Cmp(resource.MustParse("20Mi")) != 0 {
+					return false, fmt.Errorf("pool has storage request %s", storage.String())
+				}
+
+				return true, nil
+			}))
+
+			p = eit.PoolHelpers.RequireWaitSettled(ctx, p)
+			assert.Equal(t, map[string]string{"app": "test", "tier": "override"}, p.Object.Spec.Template.Labels)
+		})
+	})
+}